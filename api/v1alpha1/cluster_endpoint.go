@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// name suffix of the Service pointing at the reader endpoint of a DB cluster
+const readerEndpointSuffix = "ro"
+
+// DBClusterEndpoint is a custom endpoint of a DB cluster, reachable in the namespace through an
+// ExternalName Service named <metadata.name>-<name>
+type DBClusterEndpoint struct {
+	// Name of the endpoint, the cloud identifier is <dbClusterID>-<name>. "ro" is reserved for the reader endpoint.
+	// +kubebuilder:validation:Pattern=`^[a-z]([a-z0-9-]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=30
+	Name string `json:"name"`
+
+	// Which instances the endpoint can route to, one of ANY or READER
+	// +optional
+	// +kubebuilder:validation:Enum=ANY;READER
+	// +kubebuilder:default=ANY
+	Type string `json:"type,omitempty"`
+
+	// Identifiers of the DB instances that are part of the endpoint, mutually exclusive with excludedMembers
+	// +optional
+	StaticMembers []string `json:"staticMembers,omitempty"`
+
+	// Identifiers of the DB instances that are not part of the endpoint, every other eligible instance is
+	// +optional
+	ExcludedMembers []string `json:"excludedMembers,omitempty"`
+}
+
+// DBClusterEndpointStatus is the observed state of a custom endpoint managed by the operator
+type DBClusterEndpointStatus struct {
+	Name string `json:"name"`
+
+	// Identifier of the endpoint in the cloud provider
+	Identifier string `json:"identifier"`
+
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Raw status reported by the cloud provider, e.g available, creating, modifying
+	// +optional
+	Status string `json:"status,omitempty"`
+}
+
+// DBClusterEndpointState is returned by factories when the custom endpoints of a cluster are described
+// +kubebuilder:object:generate=false
+type DBClusterEndpointState struct {
+	Identifier      string
+	Type            string
+	Endpoint        string
+	Status          string
+	StaticMembers   []string
+	ExcludedMembers []string
+}
+
+// GetType returns the endpoint type, defaults to ANY
+func (in DBClusterEndpoint) GetType() string {
+	if in.Type == "" {
+		return "ANY"
+	}
+	return in.Type
+}
+
+// IsUpToDate is true when the custom endpoint in the cloud provider has the type and members of the spec
+func (in DBClusterEndpoint) IsUpToDate(state DBClusterEndpointState) bool {
+	return strings.EqualFold(in.GetType(), state.Type) &&
+		sameMembers(in.StaticMembers, state.StaticMembers) &&
+		sameMembers(in.ExcludedMembers, state.ExcludedMembers)
+}
+
+func sameMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA, sortedB := append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func validateDBClusterEndpoints(endpoints []DBClusterEndpoint) error {
+	names := map[string]bool{}
+	for _, endpoint := range endpoints {
+		if endpoint.Name == readerEndpointSuffix {
+			return fmt.Errorf("endpoint name %q is reserved for the reader endpoint", readerEndpointSuffix)
+		}
+		if names[endpoint.Name] {
+			return fmt.Errorf("endpoint name %q is used more than once", endpoint.Name)
+		}
+		names[endpoint.Name] = true
+		if len(endpoint.StaticMembers) > 0 && len(endpoint.ExcludedMembers) > 0 {
+			return fmt.Errorf("endpoint %q can only set one of staticMembers or excludedMembers", endpoint.Name)
+		}
+	}
+	return nil
+}
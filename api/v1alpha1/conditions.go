@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// condition types reported in .status.conditions
+const (
+	// ConditionReady is True when the cloud resource is available and matches the spec
+	ConditionReady = "Ready"
+	// ConditionSynced is True when the last reconcile was able to apply the spec to the cloud provider
+	ConditionSynced = "Synced"
+	// ConditionCloudResourceExists is True when the cloud resource exists in the provider
+	ConditionCloudResourceExists = "CloudResourceExists"
+	// ConditionCredentialsValid is True when the provider credentials were loaded and accepted by the provider
+	ConditionCredentialsValid = "CredentialsValid"
+	// ConditionDeleting is True once the object is marked for deletion
+	ConditionDeleting = "Deleting"
+	// ConditionPasswordRotated is set when the master password was updated after the password secret changed,
+	// its lastTransitionTime is the time of the last rotation
+	ConditionPasswordRotated = "PasswordRotated"
+)
+
+// condition reasons set by the operator. Reasons coming from a cloud provider
+// use the provider error code or resource status instead.
+const (
+	ReasonAvailable            = "Available"
+	ReasonCreating             = "Creating"
+	ReasonRestoring            = "Restoring"
+	ReasonUpdating             = "Updating"
+	ReasonUpToDate             = "UpToDate"
+	ReasonFound                = "Found"
+	ReasonNotFound             = "NotFound"
+	ReasonCredentialsAccepted  = "CredentialsAccepted"
+	ReasonProviderSecretError  = "ProviderSecretError"
+	ReasonProviderClientError  = "ProviderClientError"
+	ReasonPasswordSecretError  = "PasswordSecretError"
+	ReasonCreateFailed         = "CreateFailed"
+	ReasonRestoreFailed        = "RestoreFailed"
+	ReasonUpdateFailed         = "UpdateFailed"
+	ReasonDeleteFailed         = "DeleteFailed"
+	ReasonDeletionRequested    = "DeletionRequested"
+	ReasonReconcileError       = "ReconcileError"
+	ReasonPasswordRotated      = "SecretChanged"
+	ReasonRotationFailed       = "RotationFailed"
+	ReasonSourceNotFound       = "SourceNotFound"
+	ReasonSourceNotAvailable   = "SourceNotAvailable"
+	ReasonAdopted              = "Adopted"
+	ReasonAdoptFailed          = "AdoptFailed"
+	ReasonOwnershipConflict    = "OwnershipConflict"
+	ReasonObserveOnly          = "ObserveOnly"
+	ReasonFinalSnapshotPending = "FinalSnapshotPending"
+	ReasonPromoting            = "Promoting"
+	ReasonPromoted             = "Promoted"
+	ReasonPromoteFailed        = "PromoteFailed"
+	ReasonInvalidSpec          = "InvalidSpec"
+	ReasonFailingOver          = "FailingOver"
+	ReasonFailoverFailed       = "FailoverFailed"
+	ReasonWaitingForMembers    = "WaitingForMembers"
+	ReasonUnsupportedFeature   = "UnsupportedFeature"
+)
+
+// ConditionedObject is implemented by every kind that reports a phase and
+// conditions in its status, so status helpers do not need a type switch per kind.
+// +kubebuilder:object:generate=false
+type ConditionedObject interface {
+	client.Object
+	GetConditions() *[]metav1.Condition
+	GetPhase() Phase
+	SetPhase(phase Phase)
+	SetObservedGeneration(generation int64)
+}
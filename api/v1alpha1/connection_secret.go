@@ -0,0 +1,17 @@
+package v1alpha1
+
+// ConnectionSecret configures the Secret the operator writes with everything
+// an application needs to connect to the database ( host, port, username, password,
+// dbname and engine specific uri/dsn/jdbcUrl ).
+type ConnectionSecret struct {
+	// Name of the Secret to write in the same namespace, defaults to <metadata.name>-connection
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+func connectionSecretName(in *ConnectionSecret, ownerName string) string {
+	if in != nil && in.Name != "" {
+		return in.Name
+	}
+	return ownerName + "-connection"
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBActionType is a one-off operation run against a DBInstance or DBCluster
+// +kubebuilder:validation:Enum=Promote
+type DBActionType string
+
+const (
+	// DBActionPromote promotes a read replica DBInstance or a replica DBCluster to a standalone database
+	DBActionPromote DBActionType = "Promote"
+)
+
+// DBActionSpec defines the desired state of DBAction
+type DBActionSpec struct {
+	// Operation to run, only Promote is supported
+	Action DBActionType `json:"action"`
+
+	// Name of the DBInstance in the same namespace to run the action against, mutually exclusive with dbClusterName
+	// +optional
+	DBInstanceName string `json:"dbInstanceName,omitempty"`
+
+	// Name of the DBCluster in the same namespace to run the action against, mutually exclusive with dbInstanceName
+	// +optional
+	DBClusterName string `json:"dbClusterName,omitempty"`
+}
+
+// DBActionStatus defines the observed state of DBAction
+type DBActionStatus struct {
+	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the action, see Ready and Synced condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Time the action was handed to the target
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// Time the target reported the action as done
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// DBAction is the Schema for the dbactions API. An action runs once, create a new DBAction to run it again.
+// +kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+// +kubebuilder:printcolumn:name="DBInstance",type=string,JSONPath=`.spec.dbInstanceName`
+// +kubebuilder:printcolumn:name="DBCluster",type=string,JSONPath=`.spec.dbClusterName`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+type DBAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBActionSpec   `json:"spec,omitempty"`
+	Status DBActionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBActionList contains a list of DBAction
+type DBActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBAction{}, &DBActionList{})
+}
+
+// Validate returns an error unless exactly one target is set
+func (in *DBAction) Validate() error {
+	if (in.Spec.DBInstanceName == "") == (in.Spec.DBClusterName == "") {
+		return errors.New("exactly one of dbInstanceName or dbClusterName is required")
+	}
+	return nil
+}
+
+// IsDone is true once the action succeeded or failed, actions are never run twice
+func (in *DBAction) IsDone() bool {
+	return in.Status.Phase == Succeeded || in.Status.Phase == Failed
+}
+
+func (in *DBAction) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *DBAction) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *DBAction) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *DBAction) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
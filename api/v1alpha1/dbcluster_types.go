@@ -30,8 +30,11 @@ type MasterUserPasswordSecretRef struct {
 
 // DBClusterSpec defines the desired state of DBCluster
 type DBClusterSpec struct {
-	Provider Provider `json:"provider,required"`
-	Region   string   `json:"region,required"`
+	Provider ProviderConfig `json:"provider,required"`
+
+	// Region of the cloud resource, defaults to the region of the referenced Provider
+	// +optional
+	Region string `json:"region,omitempty"`
 	// A list of Availability Zones (AZs) where instances in the DB cluster can
 	// be created. For information on AWS Regions and Availability Zones, see Choosing
 	// the Regions and Availability Zones (https://docs.aws.amazon.com/AmazonRDS/latest/AuroraUserGuide/Concepts.RegionsAndAvailabilityZones.html)
@@ -73,6 +76,11 @@ type DBClusterSpec struct {
 	//    group.
 	DBClusterParameterGroupName string `json:"dbClusterParameterGroupName,optional"`
 
+	// A DBClusterParameterGroup in the same namespace to associate with this DB cluster, mutually exclusive
+	// with dbClusterParameterGroupName. The DB cluster is only created once the DBClusterParameterGroup exists.
+	// +optional
+	DBClusterParameterGroupRef *v1.LocalObjectReference `json:"dbClusterParameterGroupRef,omitempty"`
+
 	// A DB subnet group to associate with this DB cluster.
 	//
 	// Constraints: Must match the name of an existing DBSubnetGroup. Must not be
@@ -82,6 +90,11 @@ type DBClusterSpec struct {
 	// +optional
 	DBSubnetGroupName string `json:"dbSubnetGroupName,optional"`
 
+	// A DBSubnetGroup in the same namespace to associate with this DB cluster, mutually exclusive with
+	// dbSubnetGroupName. The DB cluster is only created once the DBSubnetGroup is available.
+	// +optional
+	DBSubnetGroupRef *v1.LocalObjectReference `json:"dbSubnetGroupRef,omitempty"`
+
 	// The name for your database of up to 64 alphanumeric characters. If you do
 	// not provide a name, Amazon RDS doesn't create a database in the DB cluster
 	// you are creating.
@@ -128,6 +141,12 @@ type DBClusterSpec struct {
 	// +optional
 	EnableGlobalWriteForwarding bool `json:"enableGlobalWriteForwarding,optional"`
 
+	// The global cluster identifier of an Aurora global database (GlobalCluster) to join as a secondary
+	// cluster. The master credentials and database name come from the primary cluster, masterUsername,
+	// the master password and databaseName are not sent on create. Only used when the DB cluster is created.
+	// +optional
+	GlobalClusterIdentifier string `json:"globalClusterIdentifier,omitempty"`
+
 	// A value that indicates whether to enable the HTTP endpoint for an Aurora
 	// Serverless DB cluster. By default, the HTTP endpoint is disabled.
 	//
@@ -227,8 +246,10 @@ type DBClusterSpec struct {
 	// +optional
 	KmsKeyId string `json:"kmsKeyID,optional"`
 
-	// Specifies the secret to use
-	PasswordRef PasswordRef `json:"passwordRef,required"`
+	// Specifies the secret to use. When omitted, a password is generated and stored in
+	// the <metadata.name>-master-password secret owned by this DBCluster.
+	// +optional
+	PasswordRef *PasswordRef `json:"passwordRef,omitempty"`
 
 	// The name of the master user for the DB cluster.
 	// Constraints:
@@ -244,6 +265,11 @@ type DBClusterSpec struct {
 	// +optional
 	OptionGroupName string `json:"optionGroupName,optional"`
 
+	// An OptionGroup in the same namespace to associate with this DB cluster, mutually exclusive
+	// with optionGroupName. The DB cluster is only created once the OptionGroup exists.
+	// +optional
+	OptionGroupRef *v1.LocalObjectReference `json:"optionGroupRef,omitempty"`
+
 	// The port number on which the instances in the DB cluster accept connections.
 	// Default: 3306 if engine is set as aurora or 5432 if set to aurora-postgresql.
 	// +optional
@@ -305,19 +331,112 @@ type DBClusterSpec struct {
 	// +optional
 	VpcSecurityGroupIds []string `json:"vpcSecurityGroupIds,optional"`
 
+	// Deprecated: use deletionPolicy, only used when deletionPolicy is not set
 	// +optional
 	// +kubebuilder:default=true
 	SkipFinalSnapshot bool `json:"skipFinalSnapshot,optional"`
+
+	// What happens to the db cluster when this object is deleted, one of Delete, Snapshot, Retain or Orphan.
+	// Defaults to Delete, or Snapshot when skipFinalSnapshot is false. There is no deleteAutomatedBackups
+	// like on DBInstance, the RDS DeleteDBCluster API has no such switch
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Create a DBClusterSnapshot named <metadata.name>-final pointing at the final snapshot once it is available, so it can be
+	// restored later with restoreFrom.snapshotRef. Only used with deletionPolicy Snapshot
+	// +optional
+	FinalSnapshotRecord bool `json:"finalSnapshotRecord,omitempty"`
+
+	// When set, the operator writes and keeps up to date a Secret with the connection
+	// details of the writer endpoint. The Secret is owned by the DBCluster.
+	// +optional
+	ConnectionSecret *ConnectionSecret `json:"connectionSecret,omitempty"`
+
+	// Restore the db cluster from a snapshot instead of creating an empty one
+	// +optional
+	RestoreFrom *RestoreFrom `json:"restoreFrom,omitempty"`
+
+	// What the operator is allowed to do with the cloud resource, one of Full, Adopt or ObserveOnly
+	// +optional
+	// +kubebuilder:default=Full
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// When adopting an existing resource, fill spec fields left empty from the live state of the resource.
+	// Boolean fields cannot be told apart from an explicit false, a false boolean takes the live value.
+	// +optional
+	BackfillSpec bool `json:"backfillSpec,omitempty"`
+
+	// Capacity range of an Aurora Serverless v1 DB cluster, only used with engineMode serverless
+	// +optional
+	ScalingConfiguration *ScalingConfiguration `json:"scalingConfiguration,omitempty"`
+
+	// Capacity range of the db.serverless instances of an Aurora Serverless v2 DB cluster, only used with
+	// engineMode provisioned
+	// +optional
+	ServerlessV2ScalingConfiguration *ServerlessV2ScalingConfiguration `json:"serverlessV2ScalingConfiguration,omitempty"`
+
+	// Custom endpoints of the DB cluster, each one gets an ExternalName Service named <metadata.name>-<name>.
+	// Endpoints removed from this list are deleted.
+	// +optional
+	Endpoints []DBClusterEndpoint `json:"endpoints,omitempty"`
 }
 
 type DBClusterStatus struct {
 	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the DB cluster, see Ready, Synced, CloudResourceExists,
+	// CredentialsValid and Deleting condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Details of the DB cluster as reported by the cloud provider.
+	// +optional
+	CloudResource *CloudResourceStatus `json:"cloudResource,omitempty"`
+
+	// Salted SHA-256 of the master password last applied to the DB cluster, the password is rotated when
+	// the password in the secret changes. Other changes to the secret, like labels, are ignored.
+	// +optional
+	PasswordHash string `json:"passwordHash,omitempty"`
+
+	// Identifier of the snapshot, or of the source for a point in time restore, the DB cluster was restored from
+	// +optional
+	RestoredFrom string `json:"restoredFrom,omitempty"`
+
+	// Time the DB cluster was restored to by a point in time restore
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+
+	// Identifier of the final snapshot requested when the db cluster was deleted with deletionPolicy Snapshot.
+	// It is picked once, so retried deletes do not take several final snapshots.
+	// +optional
+	FinalSnapshotIdentifier string `json:"finalSnapshotIdentifier,omitempty"`
+
+	// Identifier of the source the DB cluster replicates as reported by the cloud provider, empty once promoted
+	// +optional
+	ReplicationSourceIdentifier string `json:"replicationSourceIdentifier,omitempty"`
+
+	// Time the replica DB cluster was promoted to a standalone cluster, spec.replicationSourceIdentifier
+	// is ignored from then on
+	// +optional
+	PromotedAt *metav1.Time `json:"promotedAt,omitempty"`
+
+	// Custom endpoints created from spec.endpoints
+	// +optional
+	Endpoints []DBClusterEndpointStatus `json:"endpoints,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // DBCluster is the Schema for the dbclusters API
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.cloudResource.endpoint`,priority=1
 type DBCluster struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -346,3 +465,110 @@ func (in *DBCluster) GetDBClusterID() string {
 	}
 	return clusterID
 }
+
+// GetPasswordSecretNameAndKey returns the secret and key holding the master password
+func (in *DBCluster) GetPasswordSecretNameAndKey() (string, string) {
+	return passwordSecretNameAndKey(in.Spec.PasswordRef, in.GetName())
+}
+
+// IsPasswordGenerated is true when the master password is generated by the operator
+func (in *DBCluster) IsPasswordGenerated() bool {
+	return passwordGenerated(in.Spec.PasswordRef)
+}
+
+func (in *DBCluster) GetConnectionSecretName() string {
+	return connectionSecretName(in.Spec.ConnectionSecret, in.GetName())
+}
+
+// GetDeletionPolicy returns what happens to the db cluster when the object is deleted
+func (in *DBCluster) GetDeletionPolicy() DeletionPolicy {
+	return deletionPolicy(in.Spec.DeletionPolicy, in.Spec.SkipFinalSnapshot)
+}
+
+// GetOwnerTagValue returns the value of the owner tag put on the cloud resource
+func (in *DBCluster) GetOwnerTagValue() string {
+	return ownerTagValue("DBCluster", in.GetNamespace(), in.GetName())
+}
+
+// GetCloudTags returns the tags to put on the cloud resource, spec.tags plus the owner tag
+func (in *DBCluster) GetCloudTags() map[string]string {
+	return tagsWithOwner(in.Spec.Tags, in.GetOwnerTagValue())
+}
+
+// IsObserveOnly is true when the operator must not change the cloud resource
+func (in *DBCluster) IsObserveOnly() bool {
+	return in.Spec.ManagementPolicy == ManagementPolicyObserveOnly
+}
+
+// GetDBClusterEndpointID returns the identifier of a custom endpoint in the cloud provider
+func (in *DBCluster) GetDBClusterEndpointID(name string) string {
+	return fmt.Sprintf("%s-%s", in.GetDBClusterID(), name)
+}
+
+// GetEndpointServiceName returns the name of the ExternalName Service of a custom endpoint
+func (in *DBCluster) GetEndpointServiceName(name string) string {
+	return fmt.Sprintf("%s-%s", in.GetName(), name)
+}
+
+// GetReaderServiceName returns the name of the ExternalName Service of the reader endpoint
+func (in *DBCluster) GetReaderServiceName() string {
+	return in.GetEndpointServiceName(readerEndpointSuffix)
+}
+
+// Validate returns an error when spec.endpoints has duplicate or reserved names or conflicting members,
+// when a scaling configuration does not match the engine mode or when the provider or the subnet group is set twice
+func (in *DBCluster) Validate() error {
+	if err := in.Spec.Provider.Validate(); err != nil {
+		return err
+	}
+	if err := validateDBClusterEndpoints(in.Spec.Endpoints); err != nil {
+		return err
+	}
+	if err := validateDBSubnetGroup(in.Spec.DBSubnetGroupName, in.Spec.DBSubnetGroupRef); err != nil {
+		return err
+	}
+	if err := validateNameOrRef("dbClusterParameterGroup", in.Spec.DBClusterParameterGroupName,
+		in.Spec.DBClusterParameterGroupRef); err != nil {
+		return err
+	}
+	if err := validateNameOrRef("optionGroup", in.Spec.OptionGroupName, in.Spec.OptionGroupRef); err != nil {
+		return err
+	}
+	return validateScaling(in.Spec.EngineMode, in.Spec.ScalingConfiguration, in.Spec.ServerlessV2ScalingConfiguration)
+}
+
+// GetReplicationSourceIdentifier returns the source to replicate, empty once the DB cluster was promoted
+func (in *DBCluster) GetReplicationSourceIdentifier() string {
+	if in.Status.PromotedAt != nil {
+		return ""
+	}
+	return in.Spec.ReplicationSourceIdentifier
+}
+
+// IsReplica is true while the DB cluster replicates another cluster or is a secondary cluster of an Aurora
+// global database, its master password is the one of the source
+func (in *DBCluster) IsReplica() bool {
+	return in.GetReplicationSourceIdentifier() != "" || in.Status.ReplicationSourceIdentifier != "" ||
+		in.Spec.GlobalClusterIdentifier != ""
+}
+
+// IsPromoteRequested is true when the promote annotation is set and the DB cluster was not promoted yet
+func (in *DBCluster) IsPromoteRequested() bool {
+	return promoteRequested(in, in.Status.PromotedAt)
+}
+
+func (in *DBCluster) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *DBCluster) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *DBCluster) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *DBCluster) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBClusterSnapshotSpec defines the desired state of DBClusterSnapshot
+type DBClusterSnapshotSpec struct {
+	// Name of the DBCluster in the same namespace to take the snapshot from
+	DBClusterName string `json:"dbClusterName"`
+
+	// Identifier of the snapshot in the cloud provider, defaults to <namespace>-<name>.
+	// Changing it after the snapshot was requested has no effect.
+	// +optional
+	SnapshotIdentifierOverride string `json:"snapshotIdentifierOverride,omitempty"`
+
+	// Tags to assign to the snapshot.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Whether the cloud snapshot is deleted or retained when this DBClusterSnapshot is deleted
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy SnapshotDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DBClusterSnapshotStatus defines the observed state of DBClusterSnapshot
+type DBClusterSnapshotStatus struct {
+	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the snapshot, see Ready, Synced, CloudResourceExists,
+	// CredentialsValid and Deleting condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Identifier of the snapshot requested in the cloud provider
+	// +optional
+	SnapshotIdentifier string `json:"snapshotIdentifier,omitempty"`
+
+	// Provider and region copied from the source DBCluster, so the snapshot can
+	// still be managed once the DBCluster is gone.
+	// +optional
+	Provider *ProviderConfig `json:"provider,omitempty"`
+
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Details of the snapshot as reported by the cloud provider.
+	// +optional
+	Snapshot *CloudSnapshotStatus `json:"snapshot,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// DBClusterSnapshot is the Schema for the dbclustersnapshots API
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.dbClusterName`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Progress",type=integer,JSONPath=`.status.snapshot.percentProgress`
+// +kubebuilder:printcolumn:name="Size",type=integer,JSONPath=`.status.snapshot.allocatedStorage`,priority=1
+type DBClusterSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBClusterSnapshotSpec   `json:"spec,omitempty"`
+	Status DBClusterSnapshotStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBClusterSnapshotList contains a list of DBClusterSnapshot
+type DBClusterSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBClusterSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBClusterSnapshot{}, &DBClusterSnapshotList{})
+}
+
+// GetDBClusterSnapshotID returns the identifier of the snapshot in the cloud provider
+func (in *DBClusterSnapshot) GetDBClusterSnapshotID() string {
+	return snapshotID(in.Status.SnapshotIdentifier, in.Spec.SnapshotIdentifierOverride, in.GetNamespace(), in.GetName())
+}
+
+func (in *DBClusterSnapshot) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *DBClusterSnapshot) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *DBClusterSnapshot) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *DBClusterSnapshot) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,11 +27,26 @@ import (
 
 // DBInstanceSpec defines the desired state of DBInstance
 type DBInstanceSpec struct {
-	Region   string   `json:"region,required"`
-	Provider Provider `json:"provider,required"`
+	// Region of the cloud resource, defaults to the region of the referenced Provider
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	Provider ProviderConfig `json:"provider,required"`
+	// Deprecated: use deletionPolicy, only used when deletionPolicy is not set
 	// +optional
 	// +kubebuilder:default=true
 	SkipFinalSnapshot bool `json:"skipFinalSnapshot,omitempty"`
+	// What happens to the db instance when this object is deleted, one of Delete, Snapshot, Retain or Orphan.
+	// Defaults to Delete, or Snapshot when skipFinalSnapshot is false
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Create a DBSnapshot named <metadata.name>-final pointing at the final snapshot once it is available, so it can be
+	// restored later with restoreFrom.snapshotRef. Only used with deletionPolicy Snapshot
+	// +optional
+	FinalSnapshotRecord bool `json:"finalSnapshotRecord,omitempty"`
+	// Delete the automated backups of the db instance when it is deleted, they are kept by default
+	// +optional
+	DeleteAutomatedBackups bool `json:"deleteAutomatedBackups,omitempty"`
 	// Applicable for AWS aurora db clusters
 	// +optional
 	DBClusterID string `json:"dbClusterID,omitempty" required-for-engines:"aurora,aurora-mysql,aurora-postgresql"`
@@ -207,6 +223,11 @@ type DBInstanceSpec struct {
 	// +kubebuilder:validation:MaxLength=255
 	DBParameterGroupName string `json:"dbParameterGroupName,omitempty"`
 
+	// A DBParameterGroup in the same namespace to associate with this DB instance, mutually exclusive
+	// with dbParameterGroupName. The DB instance is only created once the DBParameterGroup exists.
+	// +optional
+	DBParameterGroupRef *v1.LocalObjectReference `json:"dbParameterGroupRef,omitempty"`
+
 	// A list of DB security groups to associate with this DB instance.
 	// Default: The default DB security group for the database engine.
 	// +optional
@@ -217,6 +238,11 @@ type DBInstanceSpec struct {
 	// +optional
 	DBSubnetGroupName string `json:"dbSubnetGroupName,omitempty"`
 
+	// A DBSubnetGroup in the same namespace to associate with this DB instance, mutually exclusive with
+	// dbSubnetGroupName. The DB instance is only created once the DBSubnetGroup is available.
+	// +optional
+	DBSubnetGroupRef *v1.LocalObjectReference `json:"dbSubnetGroupRef,omitempty"`
+
 	// A value that indicates whether the DB instance has deletion protection enabled.
 	// The database can't be deleted when deletion protection is enabled. By default,
 	// deletion protection is disabled. For more information, see Deleting a DB
@@ -367,9 +393,11 @@ type DBInstanceSpec struct {
 	//
 	// PostgreSQL
 	// Constraints: Must contain from 8 to 128 characters.
-	// required for non-aurora dbs
+	//
+	// When omitted for non-aurora dbs, a password is generated and stored in
+	// the <metadata.name>-master-password secret owned by this DBInstance.
 	// +optional
-	PasswordRef PasswordRef `json:"passwordRef,omitempty" required-for-engines:"mariadb,mysql,oracle-ee,oracle-se2,oracle-se1,postgres,sqlserver-ee,sqlserver-se,sqlserver-ex,sqlserver-web"`
+	PasswordRef *PasswordRef `json:"passwordRef,omitempty"`
 
 	// The name for the master user.
 	// Amazon Aurora
@@ -445,6 +473,11 @@ type DBInstanceSpec struct {
 	// +optional
 	OptionGroupName string `json:"optionGroupName,omitempty"`
 
+	// An OptionGroup in the same namespace to associate with this DB instance, mutually exclusive
+	// with optionGroupName. The DB instance is only created once the OptionGroup exists.
+	// +optional
+	OptionGroupRef *v1.LocalObjectReference `json:"optionGroupRef,omitempty"`
+
 	// The AWS KMS key identifier for encryption of Performance Insights data.
 	// The AWS KMS key identifier is the key ARN, key ID, alias ARN, or alias name
 	// for the AWS KMS customer master key (CMK).
@@ -520,11 +553,76 @@ type DBInstanceSpec struct {
 	// Default: The default EC2 VPC security group for the DB subnet group's VPC.
 	// +optional
 	VpcSecurityGroupIds []string `json:"vpcSecurityGroupIDs,omitempty" applicable-for-engines:"mariadb,mysql,oracle-ee,oracle-se2,oracle-se1,postgres,sqlserver-ee,sqlserver-se,sqlserver-ex,sqlserver-web"`
+
+	// When set, the operator writes and keeps up to date a Secret with the connection
+	// details of this DB instance. The Secret is owned by the DBInstance.
+	// +optional
+	ConnectionSecret *ConnectionSecret `json:"connectionSecret,omitempty"`
+
+	// Restore the db instance from a snapshot instead of creating an empty one
+	// +optional
+	RestoreFrom *RestoreFrom `json:"restoreFrom,omitempty"`
+
+	// Create the DB instance as a read replica of another DB instance, mutually exclusive with restoreFrom
+	// and dbClusterID. The replica uses the master password of its source.
+	// +optional
+	ReplicaOf *ReplicaOf `json:"replicaOf,omitempty"`
+
+	// What the operator is allowed to do with the cloud resource, one of Full, Adopt or ObserveOnly
+	// +optional
+	// +kubebuilder:default=Full
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// When adopting an existing resource, fill spec fields left empty from the live state of the resource.
+	// Boolean fields cannot be told apart from an explicit false, a false boolean takes the live value.
+	// +optional
+	BackfillSpec bool `json:"backfillSpec,omitempty"`
 }
 
 // DBInstanceStatus defines the observed state of DBInstance
 type DBInstanceStatus struct {
 	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the DB instance, see Ready, Synced, CloudResourceExists,
+	// CredentialsValid and Deleting condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Details of the DB instance as reported by the cloud provider.
+	// +optional
+	CloudResource *CloudResourceStatus `json:"cloudResource,omitempty"`
+
+	// Salted SHA-256 of the master password last applied to the DB instance, the password is rotated when
+	// the password in the secret changes. Other changes to the secret, like labels, are ignored.
+	// +optional
+	PasswordHash string `json:"passwordHash,omitempty"`
+
+	// Identifier of the snapshot, or of the source for a point in time restore, the DB instance was restored from
+	// +optional
+	RestoredFrom string `json:"restoredFrom,omitempty"`
+
+	// Time the DB instance was restored to by a point in time restore
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+
+	// Identifier of the final snapshot requested when the db instance was deleted with deletionPolicy Snapshot.
+	// It is picked once, so retried deletes do not take several final snapshots.
+	// +optional
+	FinalSnapshotIdentifier string `json:"finalSnapshotIdentifier,omitempty"`
+
+	// Replication state, only set for read replicas
+	// +optional
+	Replication *ReplicationStatus `json:"replication,omitempty"`
+
+	// Time the read replica was promoted to a standalone DB instance, spec.replicaOf is ignored from then on
+	// +optional
+	PromotedAt *metav1.Time `json:"promotedAt,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -532,6 +630,8 @@ type DBInstanceStatus struct {
 
 // DBInstance is the Schema for the dbinstances API
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.cloudResource.endpoint`,priority=1
 type DBInstance struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -560,3 +660,63 @@ func (in *DBInstance) GetDBInstanceID() string {
 	}
 	return out
 }
+
+// GetPasswordSecretNameAndKey returns the secret and key holding the master password
+func (in *DBInstance) GetPasswordSecretNameAndKey() (string, string) {
+	return passwordSecretNameAndKey(in.Spec.PasswordRef, in.GetName())
+}
+
+// IsPasswordGenerated is true when the master password is generated by the operator
+func (in *DBInstance) IsPasswordGenerated() bool {
+	return passwordGenerated(in.Spec.PasswordRef)
+}
+
+func (in *DBInstance) GetConnectionSecretName() string {
+	return connectionSecretName(in.Spec.ConnectionSecret, in.GetName())
+}
+
+// GetDeletionPolicy returns what happens to the db instance when the object is deleted
+func (in *DBInstance) GetDeletionPolicy() DeletionPolicy {
+	return deletionPolicy(in.Spec.DeletionPolicy, in.Spec.SkipFinalSnapshot)
+}
+
+// IsReadReplica is true when the DB instance is created as a read replica and was not promoted since
+func (in *DBInstance) IsReadReplica() bool {
+	return in.Spec.ReplicaOf != nil && in.Status.PromotedAt == nil
+}
+
+// IsPromoteRequested is true when the promote annotation is set and the DB instance was not promoted yet
+func (in *DBInstance) IsPromoteRequested() bool {
+	return promoteRequested(in, in.Status.PromotedAt)
+}
+
+// GetOwnerTagValue returns the value of the owner tag put on the cloud resource
+func (in *DBInstance) GetOwnerTagValue() string {
+	return ownerTagValue("DBInstance", in.GetNamespace(), in.GetName())
+}
+
+// GetCloudTags returns the tags to put on the cloud resource, spec.tags plus the owner tag
+func (in *DBInstance) GetCloudTags() map[string]string {
+	return tagsWithOwner(in.Spec.Tags, in.GetOwnerTagValue())
+}
+
+// IsObserveOnly is true when the operator must not change the cloud resource
+func (in *DBInstance) IsObserveOnly() bool {
+	return in.Spec.ManagementPolicy == ManagementPolicyObserveOnly
+}
+
+func (in *DBInstance) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *DBInstance) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *DBInstance) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *DBInstance) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
@@ -51,6 +51,34 @@ func (r *DBInstance) ValidateCreate() error {
 	if err := r.validateRequiredFieldsPerEngine(); err != nil {
 		return err
 	}
+	if r.Spec.RestoreFrom != nil {
+		if err := r.Spec.RestoreFrom.Validate(); err != nil {
+			return fmt.Errorf("%s - %v", namespacedName, err)
+		}
+	}
+	if err := r.Spec.Provider.Validate(); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateProviderFeatures(r.Spec.Provider, r.RequiredFeatures()); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateDBSubnetGroup(r.Spec.DBSubnetGroupName, r.Spec.DBSubnetGroupRef); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateNameOrRef("dbParameterGroup", r.Spec.DBParameterGroupName, r.Spec.DBParameterGroupRef); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateNameOrRef("optionGroup", r.Spec.OptionGroupName, r.Spec.OptionGroupRef); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if r.Spec.ReplicaOf != nil {
+		if err := r.Spec.ReplicaOf.Validate(); err != nil {
+			return fmt.Errorf("%s - %v", namespacedName, err)
+		}
+		if r.Spec.RestoreFrom != nil || r.Spec.DBClusterID != "" {
+			return fmt.Errorf("%s - replicaOf cannot be combined with restoreFrom or dbClusterID", namespacedName)
+		}
+	}
 	return nil
 }
 
@@ -61,6 +89,21 @@ func (r *DBInstance) ValidateUpdate(old runtime.Object) error {
 	if err := r.validateRequiredFieldsPerEngine(); err != nil {
 		return err
 	}
+	if err := r.Spec.Provider.Validate(); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateProviderFeatures(r.Spec.Provider, r.RequiredFeatures()); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateDBSubnetGroup(r.Spec.DBSubnetGroupName, r.Spec.DBSubnetGroupRef); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateNameOrRef("dbParameterGroup", r.Spec.DBParameterGroupName, r.Spec.DBParameterGroupRef); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
+	if err := validateNameOrRef("optionGroup", r.Spec.OptionGroupName, r.Spec.OptionGroupRef); err != nil {
+		return fmt.Errorf("%s - %v", namespacedName, err)
+	}
 	return nil
 }
 
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DBParameterGroupSpec defines the desired state of DBParameterGroup
+type DBParameterGroupSpec struct {
+	Provider ProviderConfig `json:"provider,required"`
+
+	// Region of the cloud resource, defaults to the region of the referenced Provider
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Name of the parameter group in the cloud provider, defaults to <namespace>-<name>.
+	// Changing it after the parameter group was created has no effect.
+	// +optional
+	DBParameterGroupNameOverride string `json:"dbParameterGroupNameOverride,omitempty"`
+
+	// Family the parameter group is compatible with, e.g postgres14. It cannot be changed once created.
+	Family string `json:"family"`
+
+	// Description of the parameter group, it cannot be changed once created.
+	// +kubebuilder:default="Managed by db-operator"
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Parameters to set, keyed by parameter name. Parameters removed from this map are reset to
+	// their default value.
+	// +optional
+	Parameters map[string]Parameter `json:"parameters,omitempty"`
+
+	// Tags to assign to the parameter group when it is created.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// DBParameterGroupStatus defines the observed state of DBParameterGroup
+type DBParameterGroupStatus struct {
+	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the parameter group, see Ready, Synced, CloudResourceExists,
+	// CredentialsValid and Deleting condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Name of the parameter group created in the cloud provider
+	// +optional
+	DBParameterGroupName string `json:"dbParameterGroupName,omitempty"`
+
+	// Provider identifier of the parameter group, for AWS this is the ARN
+	// +optional
+	Arn string `json:"arn,omitempty"`
+
+	// Parameters changed with the pending-reboot apply method, they take effect once the
+	// db instances referencing this parameter group are rebooted
+	// +optional
+	PendingRebootParameters []string `json:"pendingRebootParameters,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// DBParameterGroup is the Schema for the dbparametergroups API
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Family",type=string,JSONPath=`.spec.family`,priority=1
+type DBParameterGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBParameterGroupSpec   `json:"spec,omitempty"`
+	Status DBParameterGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBParameterGroupList contains a list of DBParameterGroup
+type DBParameterGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBParameterGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBParameterGroup{}, &DBParameterGroupList{})
+}
+
+// GetDBParameterGroupName returns the name of the parameter group in the cloud provider, the recorded name
+// wins so changing the override afterwards does not orphan the existing parameter group
+func (in *DBParameterGroup) GetDBParameterGroupName() string {
+	if in.Status.DBParameterGroupName != "" {
+		return in.Status.DBParameterGroupName
+	}
+	if in.Spec.DBParameterGroupNameOverride != "" {
+		return in.Spec.DBParameterGroupNameOverride
+	}
+	return fmt.Sprintf("%s-%s", in.GetNamespace(), in.GetName())
+}
+
+// GetOwnerTagValue returns the value of the owner tag put on the cloud resource
+func (in *DBParameterGroup) GetOwnerTagValue() string {
+	return ownerTagValue("DBParameterGroup", in.GetNamespace(), in.GetName())
+}
+
+// GetCloudTags returns the tags to put on the cloud resource, spec.tags plus the owner tag
+func (in *DBParameterGroup) GetCloudTags() map[string]string {
+	return tagsWithOwner(in.Spec.Tags, in.GetOwnerTagValue())
+}
+
+func (in *DBParameterGroup) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *DBParameterGroup) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *DBParameterGroup) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *DBParameterGroup) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
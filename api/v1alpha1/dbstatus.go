@@ -4,5 +4,87 @@ package v1alpha1
 type DBStatus struct {
 	Exists       bool
 	CurrentPhase string
-	Endpoint     string
+	// writer endpoint for db clusters
+	Endpoint         string
+	ReaderEndpoint   string
+	Arn              string
+	ResourceID       string
+	Port             int64
+	EngineVersion    string
+	InstanceClass    string
+	AllocatedStorage int64
+	MultiAZ          bool
+	Tags             map[string]string
+	// in-sync, applying or pending-reboot
+	ParameterApplyStatus string
+	// only set for read replicas
+	ReplicaSourceIdentifier string
+	ReplicationStatus       string
+	ReplicaLagSeconds       *int64
+}
+
+// CloudResourceStatus is the observed state of the cloud resource backing a DBInstance or DBCluster
+type CloudResourceStatus struct {
+	// Provider identifier of the resource, for AWS this is the ARN
+	// +optional
+	Arn string `json:"arn,omitempty"`
+
+	// Immutable provider resource id ( DbiResourceId / DbClusterResourceId for AWS )
+	// +optional
+	ResourceID string `json:"resourceID,omitempty"`
+
+	// Writer endpoint address
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Reader endpoint address, only set for db clusters
+	// +optional
+	ReaderEndpoint string `json:"readerEndpoint,omitempty"`
+
+	// +optional
+	Port int64 `json:"port,omitempty"`
+
+	// Engine version currently running, which can differ from spec while an upgrade is pending
+	// +optional
+	EngineVersion string `json:"engineVersion,omitempty"`
+
+	// Instance class, for db clusters only set when the cluster has a class of its own ( multi-AZ db clusters )
+	// +optional
+	InstanceClass string `json:"instanceClass,omitempty"`
+
+	// Allocated storage in gibibytes
+	// +optional
+	AllocatedStorage int64 `json:"allocatedStorage,omitempty"`
+
+	// +optional
+	MultiAZ bool `json:"multiAZ,omitempty"`
+
+	// Raw status reported by the cloud provider, e.g available, modifying, backing-up
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Whether the parameter group changes were applied, e.g in-sync, applying or pending-reboot
+	// +optional
+	ParameterApplyStatus string `json:"parameterApplyStatus,omitempty"`
+}
+
+// ToCloudResourceStatus returns the CR facing view of the describe result, nil when the resource does not exist
+func (in *DBStatus) ToCloudResourceStatus() *CloudResourceStatus {
+	if in == nil || !in.Exists {
+		return nil
+	}
+	return &CloudResourceStatus{
+		Arn:              in.Arn,
+		ResourceID:       in.ResourceID,
+		Endpoint:         in.Endpoint,
+		ReaderEndpoint:   in.ReaderEndpoint,
+		Port:             in.Port,
+		EngineVersion:    in.EngineVersion,
+		InstanceClass:    in.InstanceClass,
+		AllocatedStorage: in.AllocatedStorage,
+		MultiAZ:          in.MultiAZ,
+		Status:           in.CurrentPhase,
+
+		ParameterApplyStatus: in.ParameterApplyStatus,
+	}
 }
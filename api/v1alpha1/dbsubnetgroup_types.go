@@ -0,0 +1,203 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+)
+
+// DBSubnetGroupSpec defines the desired state of DBSubnetGroup
+type DBSubnetGroupSpec struct {
+	Provider ProviderConfig `json:"provider,required"`
+
+	// Region of the cloud resource, defaults to the region of the referenced Provider
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Name of the subnet group in the cloud provider, defaults to <namespace>-<name>.
+	// Changing it after the subnet group was created has no effect.
+	// +optional
+	DBSubnetGroupNameOverride string `json:"dbSubnetGroupNameOverride,omitempty"`
+
+	// Description of the subnet group
+	// +kubebuilder:default="Managed by db-operator"
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Subnets of the subnet group, they must cover at least two availability zones of the region
+	// +kubebuilder:validation:MinItems=2
+	SubnetIDs []string `json:"subnetIDs"`
+
+	// Tags to assign to the subnet group when it is created.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// DBSubnetGroupSubnet is a subnet of a subnet group as reported by the cloud provider
+type DBSubnetGroupSubnet struct {
+	SubnetID string `json:"subnetID"`
+
+	// +optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// +optional
+	Status string `json:"status,omitempty"`
+}
+
+// DBSubnetGroupStatus defines the observed state of DBSubnetGroup
+type DBSubnetGroupStatus struct {
+	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the subnet group, see Ready, Synced, CloudResourceExists,
+	// CredentialsValid and Deleting condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Name of the subnet group created in the cloud provider
+	// +optional
+	DBSubnetGroupName string `json:"dbSubnetGroupName,omitempty"`
+
+	// Provider identifier of the subnet group, for AWS this is the ARN
+	// +optional
+	Arn string `json:"arn,omitempty"`
+
+	// VPC of the subnets
+	// +optional
+	VpcID string `json:"vpcID,omitempty"`
+
+	// Status of the subnet group as reported by the cloud provider
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// +optional
+	Subnets []DBSubnetGroupSubnet `json:"subnets,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// DBSubnetGroup is the Schema for the dbsubnetgroups API
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="VPC",type=string,JSONPath=`.status.vpcID`,priority=1
+type DBSubnetGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBSubnetGroupSpec   `json:"spec,omitempty"`
+	Status DBSubnetGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DBSubnetGroupList contains a list of DBSubnetGroup
+type DBSubnetGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBSubnetGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBSubnetGroup{}, &DBSubnetGroupList{})
+}
+
+// DBSubnetGroupState is returned by factories when a subnet group is described
+// +kubebuilder:object:generate=false
+type DBSubnetGroupState struct {
+	Exists      bool
+	Status      string
+	Arn         string
+	VpcID       string
+	Description string
+	Subnets     []DBSubnetGroupSubnet
+}
+
+// GetDBSubnetGroupName returns the name of the subnet group in the cloud provider, the recorded name
+// wins so changing the override afterwards does not orphan the existing subnet group
+func (in *DBSubnetGroup) GetDBSubnetGroupName() string {
+	if in.Status.DBSubnetGroupName != "" {
+		return in.Status.DBSubnetGroupName
+	}
+	if in.Spec.DBSubnetGroupNameOverride != "" {
+		return in.Spec.DBSubnetGroupNameOverride
+	}
+	return fmt.Sprintf("%s-%s", in.GetNamespace(), in.GetName())
+}
+
+// GetOwnerTagValue returns the value of the owner tag put on the cloud resource
+func (in *DBSubnetGroup) GetOwnerTagValue() string {
+	return ownerTagValue("DBSubnetGroup", in.GetNamespace(), in.GetName())
+}
+
+// GetCloudTags returns the tags to put on the cloud resource, spec.tags plus the owner tag
+func (in *DBSubnetGroup) GetCloudTags() map[string]string {
+	return tagsWithOwner(in.Spec.Tags, in.GetOwnerTagValue())
+}
+
+// IsUpToDate is true when the description and the subnets of the subnet group match the spec
+func (in *DBSubnetGroup) IsUpToDate(state *DBSubnetGroupState) bool {
+	if state.Description != in.Spec.Description || len(state.Subnets) != len(in.Spec.SubnetIDs) {
+		return false
+	}
+	current := make([]string, 0, len(state.Subnets))
+	for _, subnet := range state.Subnets {
+		current = append(current, subnet.SubnetID)
+	}
+	desired := append([]string{}, in.Spec.SubnetIDs...)
+	sort.Strings(current)
+	sort.Strings(desired)
+	for i := range desired {
+		if current[i] != desired[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (in *DBSubnetGroup) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *DBSubnetGroup) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *DBSubnetGroup) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *DBSubnetGroup) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
+
+// validateDBSubnetGroup returns an error when both a subnet group name and a DBSubnetGroup reference are set
+func validateDBSubnetGroup(name string, ref *v1.LocalObjectReference) error {
+	if name != "" && ref != nil {
+		return errors.New("dbSubnetGroupName and dbSubnetGroupRef are mutually exclusive")
+	}
+	return nil
+}
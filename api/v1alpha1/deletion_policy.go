@@ -0,0 +1,34 @@
+package v1alpha1
+
+// DeletionPolicy controls what happens to the cloud resource when the object is deleted
+// +kubebuilder:validation:Enum=Delete;Snapshot;Retain;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the cloud resource without a final snapshot
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicySnapshot deletes the cloud resource after taking a final snapshot, the object
+	// is only released once the deletion finished
+	DeletionPolicySnapshot DeletionPolicy = "Snapshot"
+	// DeletionPolicyRetain releases the object and leaves the cloud resource and its tags untouched. The generated
+	// password secret and the connection secret are kept as well, they are no longer owned by the object
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyOrphan is the same as Retain
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// KeepsCloudResource is true when the cloud resource outlives the object
+func (p DeletionPolicy) KeepsCloudResource() bool {
+	return p == DeletionPolicyRetain || p == DeletionPolicyOrphan
+}
+
+// deletionPolicy falls back to skipFinalSnapshot for objects created before deletionPolicy existed
+func deletionPolicy(policy DeletionPolicy, skipFinalSnapshot bool) DeletionPolicy {
+	if policy != "" {
+		return policy
+	}
+	if skipFinalSnapshot {
+		return DeletionPolicyDelete
+	}
+	return DeletionPolicySnapshot
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GlobalClusterMemberRole is the role of a DB cluster in a global database
+type GlobalClusterMemberRole string
+
+const (
+	// GlobalClusterPrimary is the writer DB cluster of a global database
+	GlobalClusterPrimary GlobalClusterMemberRole = "Primary"
+	// GlobalClusterSecondary is a read-only DB cluster replicating the primary
+	GlobalClusterSecondary GlobalClusterMemberRole = "Secondary"
+)
+
+// GlobalClusterSpec defines the desired state of GlobalCluster
+type GlobalClusterSpec struct {
+	Provider ProviderConfig `json:"provider,required"`
+	// Region the global database API calls are made in, usually the region of the primary DBCluster,
+	// defaults to the region of the referenced Provider
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// The global cluster identifier. Defaults to <namespace>-<name>.
+	// +optional
+	GlobalClusterIdentifierOverride string `json:"globalClusterIdentifierOverride,omitempty"`
+
+	// Name of the DBCluster in the same namespace the global database is created from. It becomes
+	// the primary cluster and must be available before the global database is created.
+	PrimaryDBClusterName string `json:"primaryDBClusterName"`
+
+	// Names of DBClusters in the same namespace, usually in other regions, that are secondary clusters of the
+	// global database. Each one joins the global database on create through its spec.globalClusterIdentifier.
+	// +optional
+	SecondaryDBClusterNames []string `json:"secondaryDBClusterNames,omitempty"`
+
+	// Name of a DBCluster from secondaryDBClusterNames to promote to primary with a managed planned failover.
+	// The current primary becomes a secondary. Clearing it fails back to primaryDBClusterName.
+	// +optional
+	FailoverToDBClusterName string `json:"failoverToDBClusterName,omitempty"`
+
+	// A value that indicates whether the global database has deletion protection enabled.
+	// The global database can't be deleted when deletion protection is enabled.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+}
+
+// GlobalClusterMemberStatus is a DB cluster attached to the global database
+type GlobalClusterMemberStatus struct {
+	// Name of the DBCluster in the same namespace, empty when the member is not managed by this GlobalCluster
+	// +optional
+	DBClusterName string `json:"dbClusterName,omitempty"`
+
+	// Provider identifier of the DB cluster, for AWS this is the ARN
+	DBClusterArn string `json:"dbClusterArn"`
+
+	Role GlobalClusterMemberRole `json:"role"`
+}
+
+// GlobalClusterStatus defines the observed state of GlobalCluster
+type GlobalClusterStatus struct {
+	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the global database, see Ready, Synced, CloudResourceExists,
+	// CredentialsValid and Deleting condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Identifier of the global database in the cloud provider
+	// +optional
+	GlobalClusterIdentifier string `json:"globalClusterIdentifier,omitempty"`
+
+	// Provider identifier of the global database, for AWS this is the ARN
+	// +optional
+	Arn string `json:"arn,omitempty"`
+
+	// +optional
+	Engine string `json:"engine,omitempty"`
+
+	// +optional
+	EngineVersion string `json:"engineVersion,omitempty"`
+
+	// Status of the global database as reported by the cloud provider
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Status of the failover in progress, empty when there is none
+	// +optional
+	FailoverStatus string `json:"failoverStatus,omitempty"`
+
+	// DB clusters attached to the global database and their role
+	// +optional
+	Members []GlobalClusterMemberStatus `json:"members,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// GlobalCluster is the Schema for the globalclusters API
+// +kubebuilder:printcolumn:name="Primary",type=string,JSONPath=`.spec.primaryDBClusterName`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Failover",type=string,JSONPath=`.status.failoverStatus`,priority=1
+type GlobalCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GlobalClusterSpec   `json:"spec,omitempty"`
+	Status GlobalClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GlobalClusterList contains a list of GlobalCluster
+type GlobalClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GlobalCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GlobalCluster{}, &GlobalClusterList{})
+}
+
+// GlobalClusterState is returned by factories when a global database is described
+// +kubebuilder:object:generate=false
+type GlobalClusterState struct {
+	Exists             bool
+	Status             string
+	Arn                string
+	Engine             string
+	EngineVersion      string
+	DeletionProtection bool
+	FailoverStatus     string
+	Members            []GlobalClusterMemberState
+}
+
+// GlobalClusterMemberState is a DB cluster attached to a global database as described by the cloud provider
+// +kubebuilder:object:generate=false
+type GlobalClusterMemberState struct {
+	DBClusterArn string
+	IsWriter     bool
+}
+
+// Writer returns the arn of the primary DB cluster, empty when there is none
+func (in *GlobalClusterState) Writer() string {
+	for _, member := range in.Members {
+		if member.IsWriter {
+			return member.DBClusterArn
+		}
+	}
+	return ""
+}
+
+// GetGlobalClusterID returns the identifier of the global database in the cloud provider
+func (in *GlobalCluster) GetGlobalClusterID() string {
+	if in.Status.GlobalClusterIdentifier != "" {
+		return in.Status.GlobalClusterIdentifier
+	}
+	if in.Spec.GlobalClusterIdentifierOverride != "" {
+		return in.Spec.GlobalClusterIdentifierOverride
+	}
+	return fmt.Sprintf("%s-%s", in.GetNamespace(), in.GetName())
+}
+
+// GetWriterDBClusterName returns the name of the DBCluster that should be the primary cluster
+func (in *GlobalCluster) GetWriterDBClusterName() string {
+	if in.Spec.FailoverToDBClusterName != "" {
+		return in.Spec.FailoverToDBClusterName
+	}
+	return in.Spec.PrimaryDBClusterName
+}
+
+// GetMemberDBClusterNames returns the primary and secondary DBCluster names
+func (in *GlobalCluster) GetMemberDBClusterNames() []string {
+	return append([]string{in.Spec.PrimaryDBClusterName}, in.Spec.SecondaryDBClusterNames...)
+}
+
+// Validate returns an error when a DBCluster is listed twice or the failover target is not a secondary
+func (in *GlobalCluster) Validate() error {
+	seen := map[string]bool{}
+	for _, name := range in.GetMemberDBClusterNames() {
+		if seen[name] {
+			return fmt.Errorf("dbcluster %s is listed more than once", name)
+		}
+		seen[name] = true
+	}
+	if in.Spec.FailoverToDBClusterName != "" && !seen[in.Spec.FailoverToDBClusterName] {
+		return errors.New("failoverToDBClusterName must be one of secondaryDBClusterNames")
+	}
+	return nil
+}
+
+func (in *GlobalCluster) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *GlobalCluster) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *GlobalCluster) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *GlobalCluster) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
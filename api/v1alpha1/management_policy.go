@@ -0,0 +1,37 @@
+package v1alpha1
+
+import "fmt"
+
+// ManagementPolicy controls what the operator is allowed to do with the cloud resource
+// +kubebuilder:validation:Enum=Full;Adopt;ObserveOnly
+type ManagementPolicy string
+
+const (
+	// ManagementPolicyFull creates the cloud resource when it does not exist, keeps it in sync with the spec
+	// and deletes it with the object. An existing resource without owner tag is refused, it needs Adopt
+	ManagementPolicyFull ManagementPolicy = "Full"
+	// ManagementPolicyAdopt never creates the cloud resource. An existing resource is tagged as owned by the object
+	// and from then on managed like Full
+	ManagementPolicyAdopt ManagementPolicy = "Adopt"
+	// ManagementPolicyObserveOnly only reports the state of the cloud resource in status, it is never created,
+	// modified, tagged or deleted
+	ManagementPolicyObserveOnly ManagementPolicy = "ObserveOnly"
+)
+
+// OwnerTagKey is the cloud provider tag naming the object that owns a cloud resource.
+// The operator refuses to manage a resource owned by another object.
+const OwnerTagKey = "agill.apps.db-operator/owner"
+
+func ownerTagValue(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// tagsWithOwner returns the user tags plus the owner tag
+func tagsWithOwner(tags map[string]string, owner string) map[string]string {
+	out := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		out[k] = v
+	}
+	out[OwnerTagKey] = owner
+	return out
+}
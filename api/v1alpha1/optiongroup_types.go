@@ -0,0 +1,267 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+)
+
+// OptionGroupSpec defines the desired state of OptionGroup
+type OptionGroupSpec struct {
+	Provider ProviderConfig `json:"provider,required"`
+
+	// Region of the cloud resource, defaults to the region of the referenced Provider
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Name of the option group in the cloud provider, defaults to <namespace>-<name>.
+	// Changing it after the option group was created has no effect.
+	// +optional
+	OptionGroupNameOverride string `json:"optionGroupNameOverride,omitempty"`
+
+	// Engine the option group is for, e.g oracle-ee or sqlserver-se. It cannot be changed once created.
+	EngineName string `json:"engineName"`
+
+	// Major version of the engine, e.g 19 or 15.00. It cannot be changed once created.
+	MajorEngineVersion string `json:"majorEngineVersion"`
+
+	// Description of the option group, it cannot be changed once created.
+	// +kubebuilder:default="Managed by db-operator"
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Options to add to the option group. Options removed from this list are removed from the
+	// option group, except permanent options which the cloud provider never removes.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Options []Option `json:"options,omitempty"`
+
+	// Tags to assign to the option group when it is created.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Option is an option of an option group, e.g SQLSERVER_BACKUP_RESTORE, TDE or Timezone
+type Option struct {
+	Name string `json:"name"`
+
+	// Version of the option, defaults to the latest one
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Port the option listens on, only for options that use one
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// VPC security groups allowed to reach the option port
+	// +optional
+	VpcSecurityGroupIds []string `json:"vpcSecurityGroupIds,omitempty"`
+
+	// Option settings, keyed by setting name
+	// +optional
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// OptionStatus is an option of an option group as reported by the cloud provider
+type OptionStatus struct {
+	Name string `json:"name"`
+
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// Persistent options cannot be removed while the option group is used by a database
+	// +optional
+	Persistent bool `json:"persistent,omitempty"`
+
+	// Permanent options can never be removed from the option group
+	// +optional
+	Permanent bool `json:"permanent,omitempty"`
+}
+
+// OptionGroupStatus defines the observed state of OptionGroup
+type OptionGroupStatus struct {
+	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the option group, see Ready, Synced, CloudResourceExists,
+	// CredentialsValid and Deleting condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Name of the option group created in the cloud provider
+	// +optional
+	OptionGroupName string `json:"optionGroupName,omitempty"`
+
+	// Provider identifier of the option group, for AWS this is the ARN
+	// +optional
+	Arn string `json:"arn,omitempty"`
+
+	// +optional
+	Options []OptionStatus `json:"options,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// OptionGroup is the Schema for the optiongroups API
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Engine",type=string,JSONPath=`.spec.engineName`,priority=1
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.majorEngineVersion`,priority=1
+type OptionGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OptionGroupSpec   `json:"spec,omitempty"`
+	Status OptionGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OptionGroupList contains a list of OptionGroup
+type OptionGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OptionGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OptionGroup{}, &OptionGroupList{})
+}
+
+// OptionState is an option of an option group as reported by the cloud provider
+// +kubebuilder:object:generate=false
+type OptionState struct {
+	OptionStatus
+	VpcSecurityGroupIds []string
+	Settings            map[string]string
+}
+
+// OptionGroupState is returned by factories when an option group is described
+// +kubebuilder:object:generate=false
+type OptionGroupState struct {
+	Exists             bool
+	Arn                string
+	EngineName         string
+	MajorEngineVersion string
+	Description        string
+	Options            []OptionState
+}
+
+// GetOptionGroupName returns the name of the option group in the cloud provider, the recorded name
+// wins so changing the override afterwards does not orphan the existing option group
+func (in *OptionGroup) GetOptionGroupName() string {
+	if in.Status.OptionGroupName != "" {
+		return in.Status.OptionGroupName
+	}
+	if in.Spec.OptionGroupNameOverride != "" {
+		return in.Spec.OptionGroupNameOverride
+	}
+	return fmt.Sprintf("%s-%s", in.GetNamespace(), in.GetName())
+}
+
+// GetOwnerTagValue returns the value of the owner tag put on the cloud resource
+func (in *OptionGroup) GetOwnerTagValue() string {
+	return ownerTagValue("OptionGroup", in.GetNamespace(), in.GetName())
+}
+
+// GetCloudTags returns the tags to put on the cloud resource, spec.tags plus the owner tag
+func (in *OptionGroup) GetCloudTags() map[string]string {
+	return tagsWithOwner(in.Spec.Tags, in.GetOwnerTagValue())
+}
+
+// OptionGroupChanges returns the options to add or update so the option group matches the spec, and
+// the names of the options to remove. Only the version, port, security groups and settings set in
+// spec are compared, the cloud provider fills in the others.
+func (in *OptionGroup) OptionGroupChanges(state *OptionGroupState) ([]Option, []string) {
+	current := map[string]OptionState{}
+	for _, option := range state.Options {
+		current[option.Name] = option
+	}
+	var include []Option
+	desired := map[string]bool{}
+	for _, option := range in.Spec.Options {
+		desired[option.Name] = true
+		if currentOption, exists := current[option.Name]; !exists || !isOptionUpToDate(option, currentOption) {
+			include = append(include, option)
+		}
+	}
+	var remove []string
+	for _, option := range state.Options {
+		if !desired[option.Name] && !option.Permanent {
+			remove = append(remove, option.Name)
+		}
+	}
+	sort.Strings(remove)
+	return include, remove
+}
+
+func isOptionUpToDate(desired Option, current OptionState) bool {
+	if desired.Version != "" && desired.Version != current.Version {
+		return false
+	}
+	if desired.Port != nil && (current.Port == nil || *desired.Port != *current.Port) {
+		return false
+	}
+	if len(desired.VpcSecurityGroupIds) > 0 {
+		if len(desired.VpcSecurityGroupIds) != len(current.VpcSecurityGroupIds) {
+			return false
+		}
+		desiredIDs := append([]string{}, desired.VpcSecurityGroupIds...)
+		currentIDs := append([]string{}, current.VpcSecurityGroupIds...)
+		sort.Strings(desiredIDs)
+		sort.Strings(currentIDs)
+		for i := range desiredIDs {
+			if desiredIDs[i] != currentIDs[i] {
+				return false
+			}
+		}
+	}
+	for name, value := range desired.Settings {
+		if current.Settings[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (in *OptionGroup) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *OptionGroup) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *OptionGroup) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *OptionGroup) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
@@ -0,0 +1,116 @@
+package v1alpha1
+
+import (
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	"sort"
+)
+
+// ParameterApplyMethod controls when a parameter change takes effect on the databases using the group
+// +kubebuilder:validation:Enum=immediate;pending-reboot
+type ParameterApplyMethod string
+
+const (
+	// ParameterApplyImmediate applies the change right away, only dynamic parameters support it
+	ParameterApplyImmediate ParameterApplyMethod = "immediate"
+	// ParameterApplyPendingReboot applies the change the next time the databases using the group are rebooted
+	ParameterApplyPendingReboot ParameterApplyMethod = "pending-reboot"
+)
+
+// Parameter is the desired value of a parameter in a parameter group
+type Parameter struct {
+	Value string `json:"value"`
+
+	// When the change takes effect, defaults to immediate for dynamic parameters and
+	// pending-reboot for static parameters.
+	// +optional
+	ApplyMethod ParameterApplyMethod `json:"applyMethod,omitempty"`
+}
+
+// ParameterState is a parameter of a parameter group as reported by the cloud provider
+// +kubebuilder:object:generate=false
+type ParameterState struct {
+	Value string
+	// static or dynamic
+	ApplyType string
+	// user when the value was modified, otherwise engine-default or system
+	Source string
+}
+
+// used by factories to return when a describe parameter group per cloud is called
+// +kubebuilder:object:generate=false
+type ParameterGroupState struct {
+	Exists      bool
+	Arn         string
+	Family      string
+	Description string
+	Parameters  map[string]ParameterState
+}
+
+// ParameterGroupChanges returns the parameters to modify so the group matches desired, and the ones to
+// reset to their default because they were modified but are no longer desired, with their apply method
+func (in *ParameterGroupState) ParameterGroupChanges(desired map[string]Parameter) (map[string]Parameter, map[string]ParameterApplyMethod) {
+	modify := map[string]Parameter{}
+	for name, parameter := range desired {
+		current, exists := in.Parameters[name]
+		if exists && current.Value == parameter.Value {
+			continue
+		}
+		if parameter.ApplyMethod == "" {
+			parameter.ApplyMethod = defaultApplyMethod(current.ApplyType)
+		}
+		modify[name] = parameter
+	}
+	reset := map[string]ParameterApplyMethod{}
+	for name, current := range in.Parameters {
+		if _, isDesired := desired[name]; isDesired || current.Source != "user" {
+			continue
+		}
+		reset[name] = defaultApplyMethod(current.ApplyType)
+	}
+	return modify, reset
+}
+
+func defaultApplyMethod(applyType string) ParameterApplyMethod {
+	if applyType == "static" {
+		return ParameterApplyPendingReboot
+	}
+	return ParameterApplyImmediate
+}
+
+// PendingRebootParameters returns the sorted names of the changes that only take effect after a reboot,
+// merged with the ones already pending
+func PendingRebootParameters(pending []string, modify map[string]Parameter, reset map[string]ParameterApplyMethod) []string {
+	names := map[string]bool{}
+	for _, name := range pending {
+		names[name] = true
+	}
+	for name, parameter := range modify {
+		if parameter.ApplyMethod == ParameterApplyPendingReboot {
+			names[name] = true
+		}
+	}
+	for name, applyMethod := range reset {
+		if applyMethod == ParameterApplyPendingReboot {
+			names[name] = true
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(names))
+	for name := range names {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// validateNameOrRef returns an error when both the name of a cloud resource and a reference to its CR are set,
+// field is the prefix shared by both spec fields
+func validateNameOrRef(field, name string, ref *v1.LocalObjectReference) error {
+	if name != "" && ref != nil {
+		return fmt.Errorf("%sName and %sRef are mutually exclusive", field, field)
+	}
+	return nil
+}
@@ -2,7 +2,24 @@ package v1alpha1
 
 import v1 "k8s.io/api/core/v1"
 
+// GeneratedPasswordKey is the key in secret.data the generated master password is stored under
+const GeneratedPasswordKey = "password"
+
 type PasswordRef struct {
 	SecretRef   *v1.LocalObjectReference `json:"secretRef,required"`
 	PasswordKey string                   `json:"passwordKey,required"`
 }
+
+// passwordGenerated is true when passwordRef does not name a secret, the operator generates the password then
+func passwordGenerated(in *PasswordRef) bool {
+	return in == nil || in.SecretRef == nil || in.SecretRef.Name == ""
+}
+
+// passwordSecretNameAndKey returns the secret and key holding the master password. When passwordRef
+// is omitted the operator generates the password into <metadata.name>-master-password.
+func passwordSecretNameAndKey(in *PasswordRef, ownerName string) (string, string) {
+	if passwordGenerated(in) {
+		return ownerName + "-master-password", GeneratedPasswordKey
+	}
+	return in.SecretRef.Name, in.PasswordKey
+}
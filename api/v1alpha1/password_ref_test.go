@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"testing"
+)
+
+func TestPasswordSecretNameAndKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *PasswordRef
+		wantGenerated bool
+		wantName      string
+		wantKey       string
+	}{
+		{
+			name:          "omitted passwordRef generates the password",
+			wantGenerated: true,
+			wantName:      "db-master-password",
+			wantKey:       GeneratedPasswordKey,
+		},
+		{
+			name:          "passwordRef without secretRef generates the password",
+			in:            &PasswordRef{PasswordKey: "pass"},
+			wantGenerated: true,
+			wantName:      "db-master-password",
+			wantKey:       GeneratedPasswordKey,
+		},
+		{
+			name:          "secretRef without a name generates the password",
+			in:            &PasswordRef{SecretRef: &v1.LocalObjectReference{}, PasswordKey: "pass"},
+			wantGenerated: true,
+			wantName:      "db-master-password",
+			wantKey:       GeneratedPasswordKey,
+		},
+		{
+			name:     "named secretRef is used as is",
+			in:       &PasswordRef{SecretRef: &v1.LocalObjectReference{Name: "creds"}, PasswordKey: "pass"},
+			wantName: "creds",
+			wantKey:  "pass",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := passwordGenerated(tt.in); got != tt.wantGenerated {
+				t.Errorf("passwordGenerated() = %v, want %v", got, tt.wantGenerated)
+			}
+			gotName, gotKey := passwordSecretNameAndKey(tt.in, "db")
+			if gotName != tt.wantName || gotKey != tt.wantKey {
+				t.Errorf("passwordSecretNameAndKey() = %v, %v, want %v, %v", gotName, gotKey, tt.wantName, tt.wantKey)
+			}
+		})
+	}
+}
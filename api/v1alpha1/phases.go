@@ -7,4 +7,7 @@ const (
 	Updating  Phase = "updating"
 	Deleting  Phase = "deleting"
 	Available Phase = "available"
+	// terminal phases of a DBAction
+	Succeeded Phase = "succeeded"
+	Failed    Phase = "failed"
 )
@@ -0,0 +1,13 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PromoteAnnotation set to "true" on a DBInstance or DBCluster promotes its read replica to a standalone
+// database. It is ignored once the promotion is recorded in status.promotedAt, so it can be left in place.
+const PromoteAnnotation = "agill.apps.db-operator/promote"
+
+func promoteRequested(obj metav1.Object, promotedAt *metav1.Time) bool {
+	return obj.GetAnnotations()[PromoteAnnotation] == "true" && promotedAt == nil
+}
@@ -0,0 +1,71 @@
+package v1alpha1
+
+// ProviderFeature is an optional feature that not every provider supports
+type ProviderFeature string
+
+const (
+	// FeatureClusters is DBCluster and everything attached to it, cluster members, cluster snapshots,
+	// cluster parameter groups and global clusters
+	FeatureClusters ProviderFeature = "clusters"
+	// FeatureSnapshots is DBSnapshot, DBClusterSnapshot, restores from a snapshot and deletionPolicy Snapshot
+	FeatureSnapshots ProviderFeature = "snapshots"
+	// FeatureReplicas is replicaOf on a DBInstance and replicationSourceIdentifier on a DBCluster
+	FeatureReplicas ProviderFeature = "replicas"
+	// FeatureServerless is engineMode serverless and serverlessV2ScalingConfiguration on a DBCluster
+	FeatureServerless ProviderFeature = "serverless"
+	// FeatureSubnetGroups is DBSubnetGroup
+	FeatureSubnetGroups ProviderFeature = "subnetGroups"
+	// FeatureParameterGroups is DBParameterGroup, a DBClusterParameterGroup needs clusters as well
+	FeatureParameterGroups ProviderFeature = "parameterGroups"
+	// FeatureOptionGroups is OptionGroup
+	FeatureOptionGroups ProviderFeature = "optionGroups"
+)
+
+// ProviderFeatureValidator returns an error when a provider type is not registered or does not support one
+// of the features. main sets it from the factory registry, the webhook skips the check while it is nil.
+var ProviderFeatureValidator func(pType ProviderType, features ...ProviderFeature) error
+
+// RequiredFeatures returns the optional provider features the spec of the DB instance uses
+func (in *DBInstance) RequiredFeatures() []ProviderFeature {
+	var features []ProviderFeature
+	if in.Spec.DBClusterID != "" {
+		features = append(features, FeatureClusters)
+	}
+	if in.Spec.ReplicaOf != nil {
+		features = append(features, FeatureReplicas)
+	}
+	// instances of a db cluster and read replicas have no final snapshot of their own
+	finalSnapshot := in.GetDeletionPolicy() == DeletionPolicySnapshot && in.Spec.DBClusterID == "" && in.Spec.ReplicaOf == nil
+	if finalSnapshot || restoresFromSnapshot(in.Spec.RestoreFrom) {
+		features = append(features, FeatureSnapshots)
+	}
+	return features
+}
+
+// RequiredFeatures returns the optional provider features the spec of the DB cluster uses
+func (in *DBCluster) RequiredFeatures() []ProviderFeature {
+	features := []ProviderFeature{FeatureClusters}
+	if in.Spec.ReplicationSourceIdentifier != "" {
+		features = append(features, FeatureReplicas)
+	}
+	if in.Spec.EngineMode == "serverless" || in.Spec.ServerlessV2ScalingConfiguration != nil {
+		features = append(features, FeatureServerless)
+	}
+	if in.GetDeletionPolicy() == DeletionPolicySnapshot || restoresFromSnapshot(in.Spec.RestoreFrom) {
+		features = append(features, FeatureSnapshots)
+	}
+	return features
+}
+
+func restoresFromSnapshot(in *RestoreFrom) bool {
+	return in != nil && (in.SnapshotIdentifier != "" || in.SnapshotRef != nil)
+}
+
+// validateProviderFeatures checks the features against an inline provider type. The type of a referenced
+// Provider is only known to the controller, which runs the same check before calling the provider.
+func validateProviderFeatures(config ProviderConfig, features []ProviderFeature) error {
+	if config.Type == "" || ProviderFeatureValidator == nil {
+		return nil
+	}
+	return ProviderFeatureValidator(config.Type, features...)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CredentialsSource is where the credentials of a Provider come from
+// +kubebuilder:validation:Enum=Secret;InjectedIdentity
+type CredentialsSource string
+
+const (
+	// CredentialsSourceSecret reads the credentials from credentials.secretRef
+	CredentialsSourceSecret CredentialsSource = "Secret"
+	// CredentialsSourceInjectedIdentity uses the identity of the operator pod, for example an IAM role
+	// for service accounts on AWS
+	CredentialsSourceInjectedIdentity CredentialsSource = "InjectedIdentity"
+)
+
+// ProviderCredentials configures how the operator authenticates against the cloud provider
+type ProviderCredentials struct {
+	// +kubebuilder:default=Secret
+	// +optional
+	Source CredentialsSource `json:"source,omitempty"`
+
+	// Secret holding the credentials, required when source is Secret.
+	// For AWS it holds AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, or AWS_ROLE_ARN.
+	// +optional
+	SecretRef *v1.SecretReference `json:"secretRef,omitempty"`
+}
+
+// ProviderSpec defines the desired state of Provider
+type ProviderSpec struct {
+	// Type of the provider, aws, gcp, azure and local are built in and a provider registered with the factory
+	// from outside this repository has to be added to the enum as well
+	// +kubebuilder:validation:Enum=aws;gcp;azure;local
+	Type ProviderType `json:"type"`
+
+	// Region used by the cloud resources referencing this provider that do not set their own
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Credentials used to reach the provider, not used by the local provider
+	// +optional
+	Credentials ProviderCredentials `json:"credentials,omitempty"`
+
+	// Tags added to every cloud resource created through this provider, the tags of the
+	// resource win when both set the same key
+	// +optional
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+}
+
+// ProviderStatus defines the observed state of Provider
+type ProviderStatus struct {
+	Phase Phase `json:"phase"`
+
+	// The most recent metadata.generation that was reconciled by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the provider, see Ready, Synced and CredentialsValid condition types.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Account the credentials resolve to, for AWS this is the account ID
+	// +optional
+	AccountID string `json:"accountID,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+
+// Provider is the Schema for the providers API
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Account",type=string,JSONPath=`.status.accountID`
+type Provider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderSpec   `json:"spec,omitempty"`
+	Status ProviderStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ProviderList contains a list of Provider
+type ProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Provider `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Provider{}, &ProviderList{})
+}
+
+// UsesInjectedIdentity is true when the provider authenticates with the identity of the operator pod
+func (in *Provider) UsesInjectedIdentity() bool {
+	return in.Spec.Credentials.Source == CredentialsSourceInjectedIdentity
+}
+
+// Validate returns an error when the credentials source is Secret and no secret is referenced,
+// the local provider needs no credentials
+func (in *Provider) Validate() error {
+	if !in.UsesInjectedIdentity() && in.Spec.Type != Local && (in.Spec.Credentials.SecretRef == nil || in.Spec.Credentials.SecretRef.Name == "") {
+		return errors.New("credentials.secretRef is required when credentials.source is Secret")
+	}
+	return nil
+}
+
+func (in *Provider) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+func (in *Provider) GetPhase() Phase {
+	return in.Status.Phase
+}
+
+func (in *Provider) SetPhase(phase Phase) {
+	in.Status.Phase = phase
+}
+
+func (in *Provider) SetObservedGeneration(generation int64) {
+	in.Status.ObservedGeneration = generation
+}
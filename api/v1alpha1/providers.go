@@ -1,6 +1,9 @@
 package v1alpha1
 
-import v1 "k8s.io/api/core/v1"
+import (
+	"errors"
+	v1 "k8s.io/api/core/v1"
+)
 
 type ProviderType string
 
@@ -8,10 +11,39 @@ const (
 	AWS   ProviderType = "aws"
 	GCP   ProviderType = "gcp"
 	Azure ProviderType = "azure"
+	// Local runs databases inside the cluster, meant for development namespaces
+	Local ProviderType = "local"
 )
 
-type Provider struct {
-	// +kubebuilder:validation:Enum=aws;gcp;azure
-	Type      ProviderType       `json:"type,required"`
-	SecretRef v1.SecretReference `json:"secretRef,required"`
+// ProviderConfig selects the cloud provider and credentials used to manage a cloud resource, either by
+// referencing a Provider by name or inline with a type and a secret
+type ProviderConfig struct {
+	// Name of the cluster scoped Provider to use, mutually exclusive with type and secretRef
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Type of the provider, aws, gcp, azure and local are built in and a provider registered with the factory
+	// from outside this repository has to be added to the enum as well
+	// +kubebuilder:validation:Enum=aws;gcp;azure;local
+	// +optional
+	Type ProviderType `json:"type,omitempty"`
+
+	// Secret holding the provider credentials, not used by the local provider
+	// +optional
+	SecretRef v1.SecretReference `json:"secretRef,omitempty"`
+}
+
+// Validate returns an error unless exactly one of a Provider reference or an inline type and secret is set,
+// the local provider needs no secret
+func (in ProviderConfig) Validate() error {
+	if in.Name != "" {
+		if in.Type != "" || in.SecretRef.Name != "" {
+			return errors.New("provider.name is mutually exclusive with provider.type and provider.secretRef")
+		}
+		return nil
+	}
+	if in.Type == "" || (in.SecretRef.Name == "" && in.Type != Local) {
+		return errors.New("provider.name or provider.type and provider.secretRef are required")
+	}
+	return nil
 }
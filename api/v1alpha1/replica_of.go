@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	"errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// ReplicaOf configures a DB instance as a read replica of another DB instance. It only has an
+// effect when the DB instance is created.
+type ReplicaOf struct {
+	// Name of a DBInstance in the same namespace to replicate, it can be in another region
+	// +optional
+	DBInstanceRef *v1.LocalObjectReference `json:"dbInstanceRef,omitempty"`
+
+	// Identifier of the source db instance in the cloud provider, mutually exclusive with dbInstanceRef.
+	// Use the ARN when the source is in another region.
+	// +optional
+	SourceIdentifier string `json:"sourceIdentifier,omitempty"`
+
+	// Region of the source db instance when it differs from spec.region, only used with sourceIdentifier
+	// +optional
+	SourceRegion string `json:"sourceRegion,omitempty"`
+}
+
+// Validate returns an error unless exactly one source is set
+func (in *ReplicaOf) Validate() error {
+	if (in.DBInstanceRef == nil) == (in.SourceIdentifier == "") {
+		return errors.New("replicaOf requires exactly one of dbInstanceRef or sourceIdentifier")
+	}
+	if in.DBInstanceRef != nil && in.SourceRegion != "" {
+		return errors.New("replicaOf.sourceRegion is only used with sourceIdentifier")
+	}
+	return nil
+}
+
+// ReplicationStatus is the observed replication state of a read replica
+type ReplicationStatus struct {
+	// Identifier of the db instance being replicated
+	SourceIdentifier string `json:"sourceIdentifier"`
+
+	// Replication status reported by the cloud provider, e.g replicating, error, stopped
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// How far the replica is behind its source, in seconds
+	// +optional
+	LagSeconds *int64 `json:"lagSeconds,omitempty"`
+}
+
+// ToReplicationStatus returns the CR facing view of the replication state, nil unless the resource is a replica
+func (in *DBStatus) ToReplicationStatus() *ReplicationStatus {
+	if in == nil || !in.Exists || in.ReplicaSourceIdentifier == "" {
+		return nil
+	}
+	return &ReplicationStatus{
+		SourceIdentifier: in.ReplicaSourceIdentifier,
+		Status:           in.ReplicationStatus,
+		LagSeconds:       in.ReplicaLagSeconds,
+	}
+}
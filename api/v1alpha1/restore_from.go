@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	"errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreFrom configures the source a DB instance or DB cluster is restored from when it
+// does not exist yet. It has no effect once the database exists.
+type RestoreFrom struct {
+	// Identifier or ARN of an existing snapshot in the cloud provider
+	// +optional
+	SnapshotIdentifier string `json:"snapshotIdentifier,omitempty"`
+
+	// Name of an available DBSnapshot ( for a DBInstance ) or DBClusterSnapshot ( for a DBCluster )
+	// in the same namespace, mutually exclusive with snapshotIdentifier
+	// +optional
+	SnapshotRef *v1.LocalObjectReference `json:"snapshotRef,omitempty"`
+
+	// Restore from the automated backups of an existing db instance ( or db cluster ) to a point in time
+	// +optional
+	PointInTime *PointInTime `json:"pointInTime,omitempty"`
+}
+
+// PointInTime configures a point in time restore
+type PointInTime struct {
+	// Identifier of the source db instance ( for a DBInstance ) or db cluster ( for a DBCluster ) in the cloud provider
+	SourceIdentifier string `json:"sourceIdentifier"`
+
+	// Time to restore to, mutually exclusive with useLatestRestorableTime
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+
+	// Restore to the latest restorable time of the source
+	// +optional
+	UseLatestRestorableTime bool `json:"useLatestRestorableTime,omitempty"`
+}
+
+// Validate returns an error unless exactly one restore source is set
+func (in *RestoreFrom) Validate() error {
+	sources := 0
+	if in.SnapshotIdentifier != "" {
+		sources++
+	}
+	if in.SnapshotRef != nil {
+		sources++
+	}
+	if in.PointInTime != nil {
+		sources++
+	}
+	if sources != 1 {
+		return errors.New("restoreFrom requires exactly one of snapshotIdentifier, snapshotRef or pointInTime")
+	}
+	if in.PointInTime != nil {
+		if in.PointInTime.SourceIdentifier == "" {
+			return errors.New("restoreFrom.pointInTime.sourceIdentifier is required")
+		}
+		if (in.PointInTime.RestoreTime == nil) == !in.PointInTime.UseLatestRestorableTime {
+			return errors.New("restoreFrom.pointInTime requires exactly one of restoreTime or useLatestRestorableTime")
+		}
+	}
+	return nil
+}
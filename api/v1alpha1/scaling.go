@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ScalingConfiguration is the capacity range of an Aurora Serverless v1 DB cluster ( engineMode serverless )
+type ScalingConfiguration struct {
+	// Minimum capacity in Aurora capacity units ( ACUs )
+	// +optional
+	MinCapacity int64 `json:"minCapacity,omitempty"`
+
+	// Maximum capacity in Aurora capacity units ( ACUs )
+	// +optional
+	MaxCapacity int64 `json:"maxCapacity,omitempty"`
+
+	// Pause the DB cluster once it had no connections for secondsUntilAutoPause
+	// +optional
+	AutoPause bool `json:"autoPause,omitempty"`
+
+	// Time without connections before the DB cluster is paused
+	// +optional
+	// +kubebuilder:validation:Minimum=300
+	// +kubebuilder:validation:Maximum=86400
+	SecondsUntilAutoPause int64 `json:"secondsUntilAutoPause,omitempty"`
+
+	// What to do when no scaling point is found before secondsBeforeTimeout, one of
+	// ForceApplyCapacityChange or RollbackCapacityChange
+	// +optional
+	// +kubebuilder:validation:Enum=ForceApplyCapacityChange;RollbackCapacityChange
+	TimeoutAction string `json:"timeoutAction,omitempty"`
+
+	// Time to look for a scaling point before timeoutAction is applied
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=600
+	SecondsBeforeTimeout int64 `json:"secondsBeforeTimeout,omitempty"`
+}
+
+// ServerlessV2ScalingConfiguration is the capacity range of the db.serverless instances of an Aurora Serverless v2
+// DB cluster. Capacities are Aurora capacity units ( ACUs ) in steps of 0.5, e.g "0.5" or "16".
+type ServerlessV2ScalingConfiguration struct {
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[05])?$`
+	MinCapacity string `json:"minCapacity"`
+
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[05])?$`
+	MaxCapacity string `json:"maxCapacity"`
+}
+
+// Capacity returns the minimum and maximum capacity in ACUs
+func (in *ServerlessV2ScalingConfiguration) Capacity() (float64, float64, error) {
+	minCapacity, err := strconv.ParseFloat(in.MinCapacity, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("serverlessV2ScalingConfiguration.minCapacity %q is not a number", in.MinCapacity)
+	}
+	maxCapacity, err := strconv.ParseFloat(in.MaxCapacity, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("serverlessV2ScalingConfiguration.maxCapacity %q is not a number", in.MaxCapacity)
+	}
+	if minCapacity > maxCapacity {
+		return 0, 0, fmt.Errorf("serverlessV2ScalingConfiguration.minCapacity %s is greater than maxCapacity %s",
+			in.MinCapacity, in.MaxCapacity)
+	}
+	return minCapacity, maxCapacity, nil
+}
+
+func validateScaling(engineMode string, v1 *ScalingConfiguration, v2 *ServerlessV2ScalingConfiguration) error {
+	if v1 != nil {
+		if engineMode != "serverless" {
+			return fmt.Errorf("scalingConfiguration requires engineMode serverless, got %q", engineMode)
+		}
+		if v1.MinCapacity != 0 && v1.MaxCapacity != 0 && v1.MinCapacity > v1.MaxCapacity {
+			return fmt.Errorf("scalingConfiguration.minCapacity %d is greater than maxCapacity %d", v1.MinCapacity, v1.MaxCapacity)
+		}
+	}
+	if v2 != nil {
+		if engineMode != "provisioned" {
+			return fmt.Errorf("serverlessV2ScalingConfiguration requires engineMode provisioned, got %q", engineMode)
+		}
+		if _, _, err := v2.Capacity(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package v1alpha1
+
+import (
+	"fmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SnapshotDeletionPolicy controls what happens to the cloud snapshot when its CR is deleted
+// +kubebuilder:validation:Enum=Delete;Retain
+type SnapshotDeletionPolicy string
+
+const (
+	// SnapshotDeletionPolicyDelete deletes the cloud snapshot along with the CR
+	SnapshotDeletionPolicyDelete SnapshotDeletionPolicy = "Delete"
+	// SnapshotDeletionPolicyRetain keeps the cloud snapshot after the CR is deleted
+	SnapshotDeletionPolicyRetain SnapshotDeletionPolicy = "Retain"
+)
+
+// used by factories to return when a describe snapshot per cloud is called
+type SnapshotState struct {
+	Exists           bool
+	CurrentPhase     string
+	Arn              string
+	SourceIdentifier string
+	Engine           string
+	EngineVersion    string
+	PercentProgress  int64
+	AllocatedStorage int64
+	CreateTime       *metav1.Time
+}
+
+// CloudSnapshotStatus is the observed state of the cloud snapshot backing a DBSnapshot or DBClusterSnapshot
+type CloudSnapshotStatus struct {
+	// Identifier of the snapshot in the cloud provider
+	// +optional
+	SnapshotIdentifier string `json:"snapshotIdentifier,omitempty"`
+
+	// Provider identifier of the snapshot, for AWS this is the ARN
+	// +optional
+	Arn string `json:"arn,omitempty"`
+
+	// Identifier of the db instance or db cluster the snapshot was taken from
+	// +optional
+	SourceIdentifier string `json:"sourceIdentifier,omitempty"`
+
+	// +optional
+	Engine string `json:"engine,omitempty"`
+
+	// +optional
+	EngineVersion string `json:"engineVersion,omitempty"`
+
+	// Percentage of the snapshot data transferred so far
+	// +optional
+	PercentProgress int64 `json:"percentProgress,omitempty"`
+
+	// Size of the source storage in gibibytes when the snapshot was taken
+	// +optional
+	AllocatedStorage int64 `json:"allocatedStorage,omitempty"`
+
+	// +optional
+	SnapshotCreateTime *metav1.Time `json:"snapshotCreateTime,omitempty"`
+
+	// Raw status reported by the cloud provider, e.g creating, available
+	// +optional
+	Status string `json:"status,omitempty"`
+}
+
+// ToCloudSnapshotStatus returns the CR facing view of the describe result, nil when the snapshot does not exist
+func (in *SnapshotState) ToCloudSnapshotStatus(snapshotID string) *CloudSnapshotStatus {
+	if in == nil || !in.Exists {
+		return nil
+	}
+	return &CloudSnapshotStatus{
+		SnapshotIdentifier: snapshotID,
+		Arn:                in.Arn,
+		SourceIdentifier:   in.SourceIdentifier,
+		Engine:             in.Engine,
+		EngineVersion:      in.EngineVersion,
+		PercentProgress:    in.PercentProgress,
+		AllocatedStorage:   in.AllocatedStorage,
+		SnapshotCreateTime: in.CreateTime,
+		Status:             in.CurrentPhase,
+	}
+}
+
+// snapshotID returns the identifier recorded in status once the snapshot was requested, so changing
+// spec.snapshotIdentifier afterwards does not orphan the existing snapshot.
+func snapshotID(recorded, override, namespace, name string) string {
+	if recorded != "" {
+		return recorded
+	}
+	if override != "" {
+		return override
+	}
+	return fmt.Sprintf("%s-%s", namespace, name)
+}
@@ -21,31 +21,1365 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudResourceStatus) DeepCopyInto(out *CloudResourceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudResourceStatus.
+func (in *CloudResourceStatus) DeepCopy() *CloudResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSnapshotStatus) DeepCopyInto(out *CloudSnapshotStatus) {
+	*out = *in
+	if in.SnapshotCreateTime != nil {
+		in, out := &in.SnapshotCreateTime, &out.SnapshotCreateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSnapshotStatus.
+func (in *CloudSnapshotStatus) DeepCopy() *CloudSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionSecret) DeepCopyInto(out *ConnectionSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSecret.
+func (in *ConnectionSecret) DeepCopy() *ConnectionSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBAction) DeepCopyInto(out *DBAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBAction.
+func (in *DBAction) DeepCopy() *DBAction {
+	if in == nil {
+		return nil
+	}
+	out := new(DBAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBActionList) DeepCopyInto(out *DBActionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBActionList.
+func (in *DBActionList) DeepCopy() *DBActionList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBActionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBActionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBActionSpec) DeepCopyInto(out *DBActionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBActionSpec.
+func (in *DBActionSpec) DeepCopy() *DBActionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBActionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBActionStatus) DeepCopyInto(out *DBActionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBActionStatus.
+func (in *DBActionStatus) DeepCopy() *DBActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DBCluster) DeepCopyInto(out *DBCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBCluster.
+func (in *DBCluster) DeepCopy() *DBCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(DBCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterEndpoint) DeepCopyInto(out *DBClusterEndpoint) {
+	*out = *in
+	if in.StaticMembers != nil {
+		in, out := &in.StaticMembers, &out.StaticMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedMembers != nil {
+		in, out := &in.ExcludedMembers, &out.ExcludedMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterEndpoint.
+func (in *DBClusterEndpoint) DeepCopy() *DBClusterEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterEndpointStatus) DeepCopyInto(out *DBClusterEndpointStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterEndpointStatus.
+func (in *DBClusterEndpointStatus) DeepCopy() *DBClusterEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterList) DeepCopyInto(out *DBClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterList.
+func (in *DBClusterList) DeepCopy() *DBClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterParameterGroup) DeepCopyInto(out *DBClusterParameterGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterParameterGroup.
+func (in *DBClusterParameterGroup) DeepCopy() *DBClusterParameterGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterParameterGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBClusterParameterGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterParameterGroupList) DeepCopyInto(out *DBClusterParameterGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBClusterParameterGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterParameterGroupList.
+func (in *DBClusterParameterGroupList) DeepCopy() *DBClusterParameterGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterParameterGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBClusterParameterGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterParameterGroupSpec) DeepCopyInto(out *DBClusterParameterGroupSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]Parameter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterParameterGroupSpec.
+func (in *DBClusterParameterGroupSpec) DeepCopy() *DBClusterParameterGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterParameterGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterParameterGroupStatus) DeepCopyInto(out *DBClusterParameterGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingRebootParameters != nil {
+		in, out := &in.PendingRebootParameters, &out.PendingRebootParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterParameterGroupStatus.
+func (in *DBClusterParameterGroupStatus) DeepCopy() *DBClusterParameterGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterParameterGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterSnapshot) DeepCopyInto(out *DBClusterSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterSnapshot.
+func (in *DBClusterSnapshot) DeepCopy() *DBClusterSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBClusterSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterSnapshotList) DeepCopyInto(out *DBClusterSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBClusterSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterSnapshotList.
+func (in *DBClusterSnapshotList) DeepCopy() *DBClusterSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBClusterSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterSnapshotSpec) DeepCopyInto(out *DBClusterSnapshotSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterSnapshotSpec.
+func (in *DBClusterSnapshotSpec) DeepCopy() *DBClusterSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterSnapshotStatus) DeepCopyInto(out *DBClusterSnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(ProviderConfig)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(CloudSnapshotStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterSnapshotStatus.
+func (in *DBClusterSnapshotStatus) DeepCopy() *DBClusterSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterSpec) DeepCopyInto(out *DBClusterSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DBClusterParameterGroupRef != nil {
+		in, out := &in.DBClusterParameterGroupRef, &out.DBClusterParameterGroupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.DBSubnetGroupRef != nil {
+		in, out := &in.DBSubnetGroupRef, &out.DBSubnetGroupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.EnableCloudwatchLogsExports != nil {
+		in, out := &in.EnableCloudwatchLogsExports, &out.EnableCloudwatchLogsExports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(PasswordRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OptionGroupRef != nil {
+		in, out := &in.OptionGroupRef, &out.OptionGroupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VpcSecurityGroupIds != nil {
+		in, out := &in.VpcSecurityGroupIds, &out.VpcSecurityGroupIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionSecret != nil {
+		in, out := &in.ConnectionSecret, &out.ConnectionSecret
+		*out = new(ConnectionSecret)
+		**out = **in
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScalingConfiguration != nil {
+		in, out := &in.ScalingConfiguration, &out.ScalingConfiguration
+		*out = new(ScalingConfiguration)
+		**out = **in
+	}
+	if in.ServerlessV2ScalingConfiguration != nil {
+		in, out := &in.ServerlessV2ScalingConfiguration, &out.ServerlessV2ScalingConfiguration
+		*out = new(ServerlessV2ScalingConfiguration)
+		**out = **in
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]DBClusterEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterSpec.
+func (in *DBClusterSpec) DeepCopy() *DBClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBClusterStatus) DeepCopyInto(out *DBClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloudResource != nil {
+		in, out := &in.CloudResource, &out.CloudResource
+		*out = new(CloudResourceStatus)
+		**out = **in
+	}
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PromotedAt != nil {
+		in, out := &in.PromotedAt, &out.PromotedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]DBClusterEndpointStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterStatus.
+func (in *DBClusterStatus) DeepCopy() *DBClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBInstance) DeepCopyInto(out *DBInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstance.
+func (in *DBInstance) DeepCopy() *DBInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(DBInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBInstanceList) DeepCopyInto(out *DBInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstanceList.
+func (in *DBInstanceList) DeepCopy() *DBInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBInstanceSpec) DeepCopyInto(out *DBInstanceSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.DBParameterGroupRef != nil {
+		in, out := &in.DBParameterGroupRef, &out.DBParameterGroupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.DBSecurityGroups != nil {
+		in, out := &in.DBSecurityGroups, &out.DBSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DBSubnetGroupRef != nil {
+		in, out := &in.DBSubnetGroupRef, &out.DBSubnetGroupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CloudwatchLogsExports != nil {
+		in, out := &in.CloudwatchLogsExports, &out.CloudwatchLogsExports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordRef != nil {
+		in, out := &in.PasswordRef, &out.PasswordRef
+		*out = new(PasswordRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OptionGroupRef != nil {
+		in, out := &in.OptionGroupRef, &out.OptionGroupRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VpcSecurityGroupIds != nil {
+		in, out := &in.VpcSecurityGroupIds, &out.VpcSecurityGroupIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionSecret != nil {
+		in, out := &in.ConnectionSecret, &out.ConnectionSecret
+		*out = new(ConnectionSecret)
+		**out = **in
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreFrom)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaOf != nil {
+		in, out := &in.ReplicaOf, &out.ReplicaOf
+		*out = new(ReplicaOf)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstanceSpec.
+func (in *DBInstanceSpec) DeepCopy() *DBInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBInstanceStatus) DeepCopyInto(out *DBInstanceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloudResource != nil {
+		in, out := &in.CloudResource, &out.CloudResource
+		*out = new(CloudResourceStatus)
+		**out = **in
+	}
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = new(ReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromotedAt != nil {
+		in, out := &in.PromotedAt, &out.PromotedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstanceStatus.
+func (in *DBInstanceStatus) DeepCopy() *DBInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBParameterGroup) DeepCopyInto(out *DBParameterGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBParameterGroup.
+func (in *DBParameterGroup) DeepCopy() *DBParameterGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DBParameterGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBParameterGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBParameterGroupList) DeepCopyInto(out *DBParameterGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBParameterGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBParameterGroupList.
+func (in *DBParameterGroupList) DeepCopy() *DBParameterGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBParameterGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBParameterGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBParameterGroupSpec) DeepCopyInto(out *DBParameterGroupSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]Parameter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBParameterGroupSpec.
+func (in *DBParameterGroupSpec) DeepCopy() *DBParameterGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBParameterGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBParameterGroupStatus) DeepCopyInto(out *DBParameterGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingRebootParameters != nil {
+		in, out := &in.PendingRebootParameters, &out.PendingRebootParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBParameterGroupStatus.
+func (in *DBParameterGroupStatus) DeepCopy() *DBParameterGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBParameterGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSnapshot) DeepCopyInto(out *DBSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSnapshot.
+func (in *DBSnapshot) DeepCopy() *DBSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSnapshotList) DeepCopyInto(out *DBSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSnapshotList.
+func (in *DBSnapshotList) DeepCopy() *DBSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSnapshotSpec) DeepCopyInto(out *DBSnapshotSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSnapshotSpec.
+func (in *DBSnapshotSpec) DeepCopy() *DBSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSnapshotStatus) DeepCopyInto(out *DBSnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(ProviderConfig)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(CloudSnapshotStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSnapshotStatus.
+func (in *DBSnapshotStatus) DeepCopy() *DBSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBStatus) DeepCopyInto(out *DBStatus) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReplicaLagSeconds != nil {
+		in, out := &in.ReplicaLagSeconds, &out.ReplicaLagSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBStatus.
+func (in *DBStatus) DeepCopy() *DBStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSubnetGroup) DeepCopyInto(out *DBSubnetGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSubnetGroup.
+func (in *DBSubnetGroup) DeepCopy() *DBSubnetGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSubnetGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBSubnetGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSubnetGroupList) DeepCopyInto(out *DBSubnetGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBSubnetGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSubnetGroupList.
+func (in *DBSubnetGroupList) DeepCopy() *DBSubnetGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSubnetGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBSubnetGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSubnetGroupSpec) DeepCopyInto(out *DBSubnetGroupSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSubnetGroupSpec.
+func (in *DBSubnetGroupSpec) DeepCopy() *DBSubnetGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSubnetGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSubnetGroupStatus) DeepCopyInto(out *DBSubnetGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]DBSubnetGroupSubnet, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSubnetGroupStatus.
+func (in *DBSubnetGroupStatus) DeepCopy() *DBSubnetGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSubnetGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBSubnetGroupSubnet) DeepCopyInto(out *DBSubnetGroupSubnet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBSubnetGroupSubnet.
+func (in *DBSubnetGroupSubnet) DeepCopy() *DBSubnetGroupSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(DBSubnetGroupSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalCluster) DeepCopyInto(out *GlobalCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalCluster.
+func (in *GlobalCluster) DeepCopy() *GlobalCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalClusterList) DeepCopyInto(out *GlobalClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalClusterList.
+func (in *GlobalClusterList) DeepCopy() *GlobalClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalClusterMemberStatus) DeepCopyInto(out *GlobalClusterMemberStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalClusterMemberStatus.
+func (in *GlobalClusterMemberStatus) DeepCopy() *GlobalClusterMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalClusterMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalClusterSpec) DeepCopyInto(out *GlobalClusterSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.SecondaryDBClusterNames != nil {
+		in, out := &in.SecondaryDBClusterNames, &out.SecondaryDBClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalClusterSpec.
+func (in *GlobalClusterSpec) DeepCopy() *GlobalClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalClusterStatus) DeepCopyInto(out *GlobalClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]GlobalClusterMemberStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalClusterStatus.
+func (in *GlobalClusterStatus) DeepCopy() *GlobalClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MasterUserPasswordSecretRef) DeepCopyInto(out *MasterUserPasswordSecretRef) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterUserPasswordSecretRef.
+func (in *MasterUserPasswordSecretRef) DeepCopy() *MasterUserPasswordSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(MasterUserPasswordSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Option) DeepCopyInto(out *Option) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+	if in.VpcSecurityGroupIds != nil {
+		in, out := &in.VpcSecurityGroupIds, &out.VpcSecurityGroupIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Option.
+func (in *Option) DeepCopy() *Option {
+	if in == nil {
+		return nil
+	}
+	out := new(Option)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionGroup) DeepCopyInto(out *OptionGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBCluster.
-func (in *DBCluster) DeepCopy() *DBCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionGroup.
+func (in *OptionGroup) DeepCopy() *OptionGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(DBCluster)
+	out := new(OptionGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBCluster) DeepCopyObject() runtime.Object {
+func (in *OptionGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,31 +1387,31 @@ func (in *DBCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBClusterList) DeepCopyInto(out *DBClusterList) {
+func (in *OptionGroupList) DeepCopyInto(out *OptionGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBCluster, len(*in))
+		*out = make([]OptionGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterList.
-func (in *DBClusterList) DeepCopy() *DBClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionGroupList.
+func (in *OptionGroupList) DeepCopy() *OptionGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBClusterList)
+	out := new(OptionGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBClusterList) DeepCopyObject() runtime.Object {
+func (in *OptionGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -85,20 +1419,16 @@ func (in *DBClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBClusterSpec) DeepCopyInto(out *DBClusterSpec) {
+func (in *OptionGroupSpec) DeepCopyInto(out *OptionGroupSpec) {
 	*out = *in
 	out.Provider = in.Provider
-	if in.AvailabilityZones != nil {
-		in, out := &in.AvailabilityZones, &out.AvailabilityZones
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.EnableCloudwatchLogsExports != nil {
-		in, out := &in.EnableCloudwatchLogsExports, &out.EnableCloudwatchLogsExports
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]Option, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	in.PasswordRef.DeepCopyInto(&out.PasswordRef)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -106,59 +1436,142 @@ func (in *DBClusterSpec) DeepCopyInto(out *DBClusterSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.VpcSecurityGroupIds != nil {
-		in, out := &in.VpcSecurityGroupIds, &out.VpcSecurityGroupIds
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionGroupSpec.
+func (in *OptionGroupSpec) DeepCopy() *OptionGroupSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(OptionGroupSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterSpec.
-func (in *DBClusterSpec) DeepCopy() *DBClusterSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionGroupStatus) DeepCopyInto(out *OptionGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]OptionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionGroupStatus.
+func (in *OptionGroupStatus) DeepCopy() *OptionGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBClusterSpec)
+	out := new(OptionGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBClusterStatus) DeepCopyInto(out *DBClusterStatus) {
+func (in *OptionStatus) DeepCopyInto(out *OptionStatus) {
 	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBClusterStatus.
-func (in *DBClusterStatus) DeepCopy() *DBClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionStatus.
+func (in *OptionStatus) DeepCopy() *OptionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBClusterStatus)
+	out := new(OptionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBInstance) DeepCopyInto(out *DBInstance) {
+func (in *Parameter) DeepCopyInto(out *Parameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Parameter.
+func (in *Parameter) DeepCopy() *Parameter {
+	if in == nil {
+		return nil
+	}
+	out := new(Parameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRef) DeepCopyInto(out *PasswordRef) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRef.
+func (in *PasswordRef) DeepCopy() *PasswordRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PointInTime) DeepCopyInto(out *PointInTime) {
+	*out = *in
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointInTime.
+func (in *PointInTime) DeepCopy() *PointInTime {
+	if in == nil {
+		return nil
+	}
+	out := new(PointInTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstance.
-func (in *DBInstance) DeepCopy() *DBInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provider.
+func (in *Provider) DeepCopy() *Provider {
 	if in == nil {
 		return nil
 	}
-	out := new(DBInstance)
+	out := new(Provider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBInstance) DeepCopyObject() runtime.Object {
+func (in *Provider) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -166,31 +1579,67 @@ func (in *DBInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBInstanceList) DeepCopyInto(out *DBInstanceList) {
+func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfig.
+func (in *ProviderConfig) DeepCopy() *ProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderCredentials) DeepCopyInto(out *ProviderCredentials) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredentials.
+func (in *ProviderCredentials) DeepCopy() *ProviderCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderList) DeepCopyInto(out *ProviderList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBInstance, len(*in))
+		*out = make([]Provider, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstanceList.
-func (in *DBInstanceList) DeepCopy() *DBInstanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderList.
+func (in *ProviderList) DeepCopy() *ProviderList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBInstanceList)
+	out := new(ProviderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBInstanceList) DeepCopyObject() runtime.Object {
+func (in *ProviderList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -198,122 +1647,160 @@ func (in *DBInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBInstanceSpec) DeepCopyInto(out *DBInstanceSpec) {
+func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
 	*out = *in
-	out.Provider = in.Provider
-	if in.DBSecurityGroups != nil {
-		in, out := &in.DBSecurityGroups, &out.DBSecurityGroups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.CloudwatchLogsExports != nil {
-		in, out := &in.CloudwatchLogsExports, &out.CloudwatchLogsExports
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.PasswordRef.DeepCopyInto(&out.PasswordRef)
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
+	in.Credentials.DeepCopyInto(&out.Credentials)
+	if in.DefaultTags != nil {
+		in, out := &in.DefaultTags, &out.DefaultTags
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.VpcSecurityGroupIds != nil {
-		in, out := &in.VpcSecurityGroupIds, &out.VpcSecurityGroupIds
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstanceSpec.
-func (in *DBInstanceSpec) DeepCopy() *DBInstanceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
+func (in *ProviderSpec) DeepCopy() *ProviderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBInstanceSpec)
+	out := new(ProviderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBInstanceStatus) DeepCopyInto(out *DBInstanceStatus) {
+func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBInstanceStatus.
-func (in *DBInstanceStatus) DeepCopy() *DBInstanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
+func (in *ProviderStatus) DeepCopy() *ProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBInstanceStatus)
+	out := new(ProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBStatus) DeepCopyInto(out *DBStatus) {
+func (in *ReplicaOf) DeepCopyInto(out *ReplicaOf) {
 	*out = *in
+	if in.DBInstanceRef != nil {
+		in, out := &in.DBInstanceRef, &out.DBInstanceRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBStatus.
-func (in *DBStatus) DeepCopy() *DBStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaOf.
+func (in *ReplicaOf) DeepCopy() *ReplicaOf {
 	if in == nil {
 		return nil
 	}
-	out := new(DBStatus)
+	out := new(ReplicaOf)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MasterUserPasswordSecretRef) DeepCopyInto(out *MasterUserPasswordSecretRef) {
+func (in *ReplicationStatus) DeepCopyInto(out *ReplicationStatus) {
 	*out = *in
-	out.SecretRef = in.SecretRef
+	if in.LagSeconds != nil {
+		in, out := &in.LagSeconds, &out.LagSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MasterUserPasswordSecretRef.
-func (in *MasterUserPasswordSecretRef) DeepCopy() *MasterUserPasswordSecretRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationStatus.
+func (in *ReplicationStatus) DeepCopy() *ReplicationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MasterUserPasswordSecretRef)
+	out := new(ReplicationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PasswordRef) DeepCopyInto(out *PasswordRef) {
+func (in *RestoreFrom) DeepCopyInto(out *RestoreFrom) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.LocalObjectReference)
+	if in.SnapshotRef != nil {
+		in, out := &in.SnapshotRef, &out.SnapshotRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = new(PointInTime)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRef.
-func (in *PasswordRef) DeepCopy() *PasswordRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreFrom.
+func (in *RestoreFrom) DeepCopy() *RestoreFrom {
 	if in == nil {
 		return nil
 	}
-	out := new(PasswordRef)
+	out := new(RestoreFrom)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Provider) DeepCopyInto(out *Provider) {
+func (in *ScalingConfiguration) DeepCopyInto(out *ScalingConfiguration) {
 	*out = *in
-	out.SecretRef = in.SecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provider.
-func (in *Provider) DeepCopy() *Provider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingConfiguration.
+func (in *ScalingConfiguration) DeepCopy() *ScalingConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(Provider)
+	out := new(ScalingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerlessV2ScalingConfiguration) DeepCopyInto(out *ServerlessV2ScalingConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerlessV2ScalingConfiguration.
+func (in *ServerlessV2ScalingConfiguration) DeepCopy() *ServerlessV2ScalingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerlessV2ScalingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotState) DeepCopyInto(out *SnapshotState) {
+	*out = *in
+	if in.CreateTime != nil {
+		in, out := &in.CreateTime, &out.CreateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotState.
+func (in *SnapshotState) DeepCopy() *SnapshotState {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotState)
 	in.DeepCopyInto(out)
 	return out
 }
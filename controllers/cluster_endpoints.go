@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reconcileDBClusterEndpoints creates, modifies and deletes the custom endpoints of spec.endpoints and keeps an
+// ExternalName Service per available endpoint. It returns true while an endpoint is not available yet.
+func (r *DBClusterReconciler) reconcileDBClusterEndpoints(cr *v1alpha1.DBCluster, cloudDB factory.CloudDB) (bool, error) {
+	if len(cr.Spec.Endpoints) == 0 && len(cr.Status.Endpoints) == 0 {
+		return false, nil
+	}
+	states, err := cloudDB.DBClusterEndpoints(cr.GetDBClusterID())
+	if err != nil {
+		return false, err
+	}
+	current := map[string]v1alpha1.DBClusterEndpointState{}
+	for _, state := range states {
+		current[state.Identifier] = state
+	}
+
+	pending := false
+	var statuses []v1alpha1.DBClusterEndpointStatus
+	for _, endpoint := range cr.Spec.Endpoints {
+		endpointID := cr.GetDBClusterEndpointID(endpoint.Name)
+		state, exists := current[endpointID]
+		status := v1alpha1.DBClusterEndpointStatus{Name: endpoint.Name, Identifier: endpointID,
+			Endpoint: state.Endpoint, Status: state.Status}
+		switch {
+		case !exists:
+			r.Log.Info(fmt.Sprintf("%s/%s - creating endpoint %s", cr.GetNamespace(), cr.GetName(), endpointID))
+			if err := cloudDB.CreateDBClusterEndpoint(cr, endpoint); err != nil {
+				return false, err
+			}
+			pending = true
+		case state.Status != string(v1alpha1.Available):
+			pending = true
+		case !endpoint.IsUpToDate(state):
+			r.Log.Info(fmt.Sprintf("%s/%s - modifying endpoint %s", cr.GetNamespace(), cr.GetName(), endpointID))
+			if err := cloudDB.ModifyDBClusterEndpoint(cr, endpoint); err != nil {
+				return false, err
+			}
+			pending = true
+		default:
+			if _, err := createOrUpdateNamedExternalNameSvc(cr, cr.GetEndpointServiceName(endpoint.Name),
+				state.Endpoint, r.Client, r.Scheme); err != nil {
+				return false, err
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	// only endpoints created by the operator are deleted, they are the ones recorded in status
+	for _, previous := range cr.Status.Endpoints {
+		if specHasEndpoint(cr.Spec.Endpoints, previous.Name) {
+			continue
+		}
+		r.Log.Info(fmt.Sprintf("%s/%s - deleting endpoint %s", cr.GetNamespace(), cr.GetName(), previous.Identifier))
+		if err := cloudDB.DeleteDBClusterEndpoint(previous.Identifier); err != nil {
+			return false, err
+		}
+		svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: cr.GetEndpointServiceName(previous.Name), Namespace: cr.GetNamespace()}}
+		if err := r.Client.Delete(context.TODO(), svc); err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	cr.Status.Endpoints = statuses
+	return pending, nil
+}
+
+func specHasEndpoint(endpoints []v1alpha1.DBClusterEndpoint, name string) bool {
+	for _, endpoint := range endpoints {
+		if endpoint.Name == name {
+			return true
+		}
+	}
+	return false
+}
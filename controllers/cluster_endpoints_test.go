@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func TestDBClusterReconciler_reconcileDBClusterEndpoints(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	cluster := func(endpoints []v1alpha1.DBClusterEndpoint, statuses []v1alpha1.DBClusterEndpointStatus) *v1alpha1.DBCluster {
+		return &v1alpha1.DBCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "aurora", Namespace: "default", UID: "aurora-uid"},
+			Spec:       v1alpha1.DBClusterSpec{Region: "us-east-1", Engine: "aurora-mysql", Endpoints: endpoints},
+			Status:     v1alpha1.DBClusterStatus{Endpoints: statuses},
+		}
+	}
+	analytics := v1alpha1.DBClusterEndpoint{Name: "analytics", Type: "READER", StaticMembers: []string{"aurora-2"}}
+	analyticsState := func(status string, members ...string) v1alpha1.DBClusterEndpointState {
+		return v1alpha1.DBClusterEndpointState{
+			Identifier:    "default-aurora-analytics",
+			Type:          "READER",
+			Endpoint:      "default-aurora-analytics.cluster-custom-abc.us-east-1.rds.amazonaws.com",
+			Status:        status,
+			StaticMembers: members,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		cr          *v1alpha1.DBCluster
+		mock        *factory.MockCloudDB
+		wantPending bool
+		wantSvc     string
+		wantStatus  int
+	}{
+		{
+			name:        "when the endpoint does not exist, should create it and wait",
+			cr:          cluster([]v1alpha1.DBClusterEndpoint{analytics}, nil),
+			mock:        &factory.MockCloudDB{},
+			wantPending: true,
+			wantStatus:  1,
+		},
+		{
+			name:        "when the endpoint is being created, should wait",
+			cr:          cluster([]v1alpha1.DBClusterEndpoint{analytics}, nil),
+			mock:        &factory.MockCloudDB{DBClusterEndpointsResp: []v1alpha1.DBClusterEndpointState{analyticsState("creating")}},
+			wantPending: true,
+			wantStatus:  1,
+		},
+		{
+			name:        "when the endpoint members changed, should modify it and wait",
+			cr:          cluster([]v1alpha1.DBClusterEndpoint{analytics}, nil),
+			mock:        &factory.MockCloudDB{DBClusterEndpointsResp: []v1alpha1.DBClusterEndpointState{analyticsState("available", "aurora-3")}},
+			wantPending: true,
+			wantStatus:  1,
+		},
+		{
+			name:       "when the endpoint is available and up to date, should create its service",
+			cr:         cluster([]v1alpha1.DBClusterEndpoint{analytics}, nil),
+			mock:       &factory.MockCloudDB{DBClusterEndpointsResp: []v1alpha1.DBClusterEndpointState{analyticsState("available", "aurora-2")}},
+			wantSvc:    "aurora-analytics",
+			wantStatus: 1,
+		},
+		{
+			name: "when the endpoint was removed from spec, should delete it",
+			cr: cluster(nil, []v1alpha1.DBClusterEndpointStatus{{
+				Name: "analytics", Identifier: "default-aurora-analytics", Status: "available",
+			}}),
+			mock:       &factory.MockCloudDB{DBClusterEndpointsResp: []v1alpha1.DBClusterEndpointState{analyticsState("available", "aurora-2")}},
+			wantStatus: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DBClusterReconciler{
+				Client:           fake.NewFakeClientWithScheme(testScheme, tt.cr),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: tt.mock,
+			}
+			pending, err := r.reconcileDBClusterEndpoints(tt.cr, tt.mock)
+			if err != nil {
+				t.Fatalf("reconcileDBClusterEndpoints() error = %v", err)
+			}
+			if pending != tt.wantPending {
+				t.Errorf("reconcileDBClusterEndpoints() pending = %v, want %v", pending, tt.wantPending)
+			}
+			if len(tt.cr.Status.Endpoints) != tt.wantStatus {
+				t.Errorf("reconcileDBClusterEndpoints() status endpoints = %v, want %d", tt.cr.Status.Endpoints, tt.wantStatus)
+			}
+			svc := &v1.Service{}
+			errGettingSvc := r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "aurora-analytics"}, svc)
+			if tt.wantSvc == "" {
+				if !errors.IsNotFound(errGettingSvc) {
+					t.Errorf("reconcileDBClusterEndpoints() service should not exist, got err = %v", errGettingSvc)
+				}
+				return
+			}
+			if errGettingSvc != nil {
+				t.Fatalf("failed to get service: %v", errGettingSvc)
+			}
+			if svc.Spec.ExternalName != analyticsState("").Endpoint {
+				t.Errorf("reconcileDBClusterEndpoints() externalName = %v, want %v", svc.Spec.ExternalName, analyticsState("").Endpoint)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setCloudResourceConditions records the result of a successful describe call against the cloud provider
+func setCloudResourceConditions(exists bool, currentPhase string, object v1alpha1.ConditionedObject) {
+	utils.SetCondition(v1alpha1.ConditionCredentialsValid, metav1.ConditionTrue,
+		v1alpha1.ReasonCredentialsAccepted, "cloud provider accepted the credentials", object)
+	if !exists {
+		utils.SetCondition(v1alpha1.ConditionCloudResourceExists, metav1.ConditionFalse,
+			v1alpha1.ReasonNotFound, "cloud resource does not exist", object)
+		return
+	}
+	utils.SetCondition(v1alpha1.ConditionCloudResourceExists, metav1.ConditionTrue,
+		v1alpha1.ReasonFound, fmt.Sprintf("cloud resource status is %s", currentPhase), object)
+	if currentPhase != string(v1alpha1.Available) {
+		utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse,
+			currentPhase, fmt.Sprintf("cloud resource is %s", currentPhase), object)
+	}
+}
+
+func setDeletingConditions(object v1alpha1.ConditionedObject) {
+	utils.SetCondition(v1alpha1.ConditionDeleting, metav1.ConditionTrue,
+		v1alpha1.ReasonDeletionRequested, "object is marked for deletion", object)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse,
+		v1alpha1.ReasonDeletionRequested, "object is marked for deletion", object)
+}
+
+// setInProgressConditions is used after a create/modify call was accepted by the cloud provider
+func setInProgressConditions(reason, message string, object v1alpha1.ConditionedObject) {
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue, reason, message, object)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, reason, message, object)
+}
+
+func setAvailableConditions(dbStatus *v1alpha1.DBStatus, object v1alpha1.ConditionedObject) {
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue,
+		v1alpha1.ReasonUpToDate, "cloud resource matches the spec", object)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue,
+		v1alpha1.ReasonAvailable, fmt.Sprintf("available at %s", dbStatus.Endpoint), object)
+}
+
+func setSnapshotAvailableConditions(snapshotID string, object v1alpha1.ConditionedObject) {
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue,
+		v1alpha1.ReasonUpToDate, "snapshot was taken", object)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue,
+		v1alpha1.ReasonAvailable, fmt.Sprintf("snapshot %s is available", snapshotID), object)
+}
+
+// setPasswordRotatedCondition re-adds PasswordRotated so its lastTransitionTime is the time of this rotation
+func setPasswordRotatedCondition(secretName string, object v1alpha1.ConditionedObject) {
+	meta.RemoveStatusCondition(object.GetConditions(), v1alpha1.ConditionPasswordRotated)
+	utils.SetCondition(v1alpha1.ConditionPasswordRotated, metav1.ConditionTrue, v1alpha1.ReasonPasswordRotated,
+		fmt.Sprintf("master password updated from secret %s", secretName), object)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1 "github.com/agill17/db-operator/api/v1alpha1"
+)
+
+// DBActionReconciler reconciles a DBAction object
+type DBActionReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbactions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbactions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbactions/finalizers,verbs=update
+// Reconcile hands the action to the target DBInstance or DBCluster, which runs it against the cloud
+// provider, and reports the action as succeeded once the target recorded it.
+func (r *DBActionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("dbaction", req.NamespacedName)
+	namespacedName := req.NamespacedName.String()
+	cr := &v1alpha1.DBAction{}
+	if errGettingCr := r.Client.Get(context.TODO(), req.NamespacedName, cr); errGettingCr != nil {
+		if errors.IsNotFound(errGettingCr) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errGettingCr
+	}
+
+	// actions run once
+	if cr.IsDone() {
+		return ctrl.Result{}, nil
+	}
+	if errValidating := cr.Validate(); errValidating != nil {
+		utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonInvalidSpec, errValidating.Error(), cr)
+		return ctrl.Result{}, utils.UpdateStatusPhase(v1alpha1.Failed, cr, r.Client)
+	}
+
+	target, promotedAt, errGettingTarget := r.getPromoteTarget(cr)
+	if errGettingTarget != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound,
+			errGettingTarget, cr, r.Client)
+	}
+	targetName := fmt.Sprintf("%s %s", target.GetObjectKind().GroupVersionKind().Kind, target.GetName())
+
+	if promotedAt != nil {
+		r.Log.Info(fmt.Sprintf("%s - %s was promoted", namespacedName, targetName))
+		cr.Status.CompletedAt = promotedAt
+		utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue, v1alpha1.ReasonPromoted,
+			fmt.Sprintf("%s was promoted", targetName), cr)
+		utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonPromoted,
+			fmt.Sprintf("%s was promoted at %s", targetName, promotedAt.UTC().Format(time.RFC3339)), cr)
+		return ctrl.Result{}, utils.UpdateStatusPhase(v1alpha1.Succeeded, cr, r.Client)
+	}
+
+	// the target controller promotes and records it in its status, this keeps a single code path
+	// for the annotation and the DBAction
+	if target.GetAnnotations()[v1alpha1.PromoteAnnotation] != "true" {
+		patch := client.MergeFrom(target.DeepCopyObject().(client.Object))
+		annotations := target.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[v1alpha1.PromoteAnnotation] = "true"
+		target.SetAnnotations(annotations)
+		if errPatching := r.Client.Patch(context.TODO(), target, patch); errPatching != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonPromoteFailed,
+				errPatching, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - promote requested on %s", namespacedName, targetName))
+	}
+	if cr.Status.StartedAt == nil {
+		cr.Status.StartedAt = &metav1.Time{Time: time.Now()}
+	}
+	setInProgressConditions(v1alpha1.ReasonPromoting, fmt.Sprintf("waiting for %s to be promoted", targetName), cr)
+	return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+}
+
+// getPromoteTarget returns the DBInstance or DBCluster to promote and the time it was promoted, if it was
+func (r *DBActionReconciler) getPromoteTarget(cr *v1alpha1.DBAction) (client.Object, *metav1.Time, error) {
+	if cr.Spec.DBInstanceName != "" {
+		target := &v1alpha1.DBInstance{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.Spec.DBInstanceName}, target)
+		target.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind("DBInstance"))
+		return target, target.Status.PromotedAt, err
+	}
+	target := &v1alpha1.DBCluster{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.Spec.DBClusterName}, target)
+	target.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind("DBCluster"))
+	return target, target.Status.PromotedAt, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBActionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBAction{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
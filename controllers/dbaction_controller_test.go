@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"context"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func TestDBActionReconciler_Reconcile(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+	promotedAt := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	replica := func(promotedAt *metav1.Time) *v1alpha1.DBInstance {
+		return &v1alpha1.DBInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysql-replica", Namespace: "default"},
+			Spec: v1alpha1.DBInstanceSpec{
+				Region:    "us-east-1",
+				Engine:    "mysql",
+				ReplicaOf: &v1alpha1.ReplicaOf{SourceIdentifier: "mysql"},
+			},
+			Status: v1alpha1.DBInstanceStatus{Phase: v1alpha1.Available, PromotedAt: promotedAt},
+		}
+	}
+	promote := func(spec v1alpha1.DBActionSpec, phase v1alpha1.Phase) *v1alpha1.DBAction {
+		return &v1alpha1.DBAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "promote", Namespace: "default"},
+			Spec:       spec,
+			Status:     v1alpha1.DBActionStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		objects            []runtime.Object
+		want               controllerruntime.Result
+		wantErr            bool
+		wantPhase          v1alpha1.Phase
+		wantTargetAnnotate bool
+	}{
+		{
+			name: "when the dbinstance was not promoted, should annotate it and requeue",
+			objects: []runtime.Object{replica(nil), promote(v1alpha1.DBActionSpec{
+				Action: v1alpha1.DBActionPromote, DBInstanceName: "mysql-replica",
+			}, "")},
+			want:               controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantPhase:          v1alpha1.Updating,
+			wantTargetAnnotate: true,
+		},
+		{
+			name: "when the dbinstance was promoted, should succeed",
+			objects: []runtime.Object{replica(&promotedAt), promote(v1alpha1.DBActionSpec{
+				Action: v1alpha1.DBActionPromote, DBInstanceName: "mysql-replica",
+			}, v1alpha1.Updating)},
+			want:      controllerruntime.Result{},
+			wantPhase: v1alpha1.Succeeded,
+		},
+		{
+			name: "when both targets are set, should fail without requeue",
+			objects: []runtime.Object{replica(nil), promote(v1alpha1.DBActionSpec{
+				Action: v1alpha1.DBActionPromote, DBInstanceName: "mysql-replica", DBClusterName: "aurora",
+			}, "")},
+			want:      controllerruntime.Result{},
+			wantPhase: v1alpha1.Failed,
+		},
+		{
+			name: "when the target does not exist, should error",
+			objects: []runtime.Object{promote(v1alpha1.DBActionSpec{
+				Action: v1alpha1.DBActionPromote, DBClusterName: "aurora",
+			}, "")},
+			want:    controllerruntime.Result{},
+			wantErr: true,
+		},
+		{
+			name: "when the action already succeeded, should not run it again",
+			objects: []runtime.Object{replica(nil), promote(v1alpha1.DBActionSpec{
+				Action: v1alpha1.DBActionPromote, DBInstanceName: "mysql-replica",
+			}, v1alpha1.Succeeded)},
+			want:      controllerruntime.Result{},
+			wantPhase: v1alpha1.Succeeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DBActionReconciler{
+				Client: fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:    logf.Log,
+				Scheme: testScheme,
+			}
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "promote"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			cr := &v1alpha1.DBAction{}
+			if err := r.Client.Get(context.Background(), req.NamespacedName, cr); err != nil {
+				t.Fatalf("failed to get DBAction: %v", err)
+			}
+			if cr.Status.Phase != tt.wantPhase {
+				t.Errorf("Reconcile() phase = %v, want %v", cr.Status.Phase, tt.wantPhase)
+			}
+			target := &v1alpha1.DBInstance{}
+			if err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "mysql-replica"}, target); err != nil {
+				t.Fatalf("failed to get DBInstance: %v", err)
+			}
+			if annotated := target.GetAnnotations()[v1alpha1.PromoteAnnotation] == "true"; annotated != tt.wantTargetAnnotate {
+				t.Errorf("Reconcile() promote annotation = %v, want %v", annotated, tt.wantTargetAnnotate)
+			}
+		})
+	}
+}
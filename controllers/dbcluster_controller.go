@@ -45,8 +45,9 @@ import (
 // DBClusterReconciler reconciles a DBCluster object
 type DBClusterReconciler struct {
 	client.Client
-	Log              logr.Logger
-	Scheme           *runtime.Scheme
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// CloudDBInterface replaces the client built from the provider of every object, only tests set it
 	CloudDBInterface factory.CloudDB
 }
 
@@ -75,46 +76,116 @@ func (r *DBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, errAddingFinalizer
 	}
 
-	// get provider secret
-	providerSecret, errGettingSecret := utils.GetSecret(cr.Spec.Provider.SecretRef.Name,
-		cr.Spec.Provider.SecretRef.Namespace, r.Client)
-	if errGettingSecret != nil {
-		r.Log.Error(errGettingSecret, "Failed to get provider secret")
-		return ctrl.Result{}, errGettingSecret
+	// resolve the provider, its region and default tags are passed to the client
+	providerOptions, errResolvingProvider := resolveProvider(cr.Spec.Provider, cr.Spec.Region, r.Client)
+	if errResolvingProvider != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderSecretError, errResolvingProvider, cr, r.Client)
 	}
 
-	// setup cloud clients
-	if r.CloudDBInterface == nil {
-		cloudDBInterface, err := factory.NewCloudDB(r.Log, cr.Spec.Provider.Type, providerSecret, cr.Spec.Region)
-		if err != nil {
-			r.Log.Error(err, "Failed to create a NewCloudDB client interface")
-			return ctrl.Result{}, err
+	// reject what the provider does not support before calling it, a delete still goes through
+	if cr.GetDeletionTimestamp() == nil {
+		if errValidating := factory.ValidateFeatures(providerOptions.Type, cr.RequiredFeatures()...); errValidating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUnsupportedFeature,
+				errValidating, cr, r.Client)
 		}
-		r.CloudDBInterface = cloudDBInterface
 	}
 
-	dbStatus, errCheckingExistence := r.CloudDBInterface.DBClusterExists(cr.GetDBClusterID())
+	// create the cloud client of the resolved provider, objects of one kind can use different providers
+	cloudDB, errCreatingClient := cloudDBFor(r.CloudDBInterface, r.Log, providerOptions)
+	if errCreatingClient != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderClientError, errCreatingClient, cr, r.Client)
+	}
+
+	dbStatus, errCheckingExistence := cloudDB.DBClusterExists(cr.GetDBClusterID())
 	if errCheckingExistence != nil {
-		return ctrl.Result{}, errCheckingExistence
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError,
+			errCheckingExistence, cr, r.Client)
+	}
+	cr.Status.CloudResource = dbStatus.ToCloudResourceStatus()
+	cr.Status.ReplicationSourceIdentifier = dbStatus.ReplicaSourceIdentifier
+	setCloudResourceConditions(dbStatus.Exists, dbStatus.CurrentPhase, cr)
+	if cr.IsObserveOnly() {
+		return observeOnly(dbStatus, dbClusterFinalizer, cr, r.Client)
+	}
+
+	// never touch a cloud resource owned by another object, not even on delete
+	owner := cloudResourceOwner(dbStatus)
+	if owner != "" && owner != cr.GetOwnerTagValue() {
+		if cr.GetDeletionTimestamp() != nil {
+			return ctrl.Result{}, utils.RemoveFinalizer(dbClusterFinalizer, r.Client, cr)
+		}
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonOwnershipConflict,
+			ownershipConflictError(cr.GetDBClusterID(), owner), cr, r.Client)
+	}
+	// an existing cluster nobody owns is only taken over with managementPolicy Adopt
+	if dbStatus.Exists && owner == "" && cr.Spec.ManagementPolicy != v1alpha1.ManagementPolicyAdopt {
+		if cr.GetDeletionTimestamp() != nil {
+			return ctrl.Result{}, utils.RemoveFinalizer(dbClusterFinalizer, r.Client, cr)
+		}
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonOwnershipConflict,
+			untaggedResourceError(cr.GetDBClusterID()), cr, r.Client)
+	}
+	if cr.GetDeletionTimestamp() != nil && cr.GetDeletionPolicy().KeepsCloudResource() {
+		r.Log.Info(fmt.Sprintf("%v - deletionPolicy is %v, leaving the dbcluster in place", namespacedName, cr.GetDeletionPolicy()))
+		if errReleasing := releaseSecrets(cr, r.Client); errReleasing != nil {
+			return ctrl.Result{}, errReleasing
+		}
+		return ctrl.Result{}, utils.RemoveFinalizer(dbClusterFinalizer, r.Client, cr)
 	}
 	if dbStatus.Exists && dbStatus.CurrentPhase != string(v1alpha1.Available) {
 		r.Log.Info(fmt.Sprintf("%v - DBCluster exists, but is not yet ready. Current status: %v", namespacedName, dbStatus.CurrentPhase))
-		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
 	}
 
 	// if cr is marked for deletion, handle delete and remove finalizer
 	if cr.GetDeletionTimestamp() != nil {
 		r.Log.Info(fmt.Sprintf("%v - is marked for deletion", namespacedName))
+		setDeletingConditions(cr)
 		if errUpdatingPhase := utils.UpdateStatusPhase(
 			v1alpha1.Deleting, cr, r.Client); errUpdatingPhase != nil {
 			return ctrl.Result{}, errUpdatingPhase
 		}
-		if dbStatus.Exists {
-			if errDeleting := r.CloudDBInterface.DeleteDBCluster(cr); errDeleting != nil {
+		// a cluster that was never adopted is left alone
+		if dbStatus.Exists && (owner != "" || cr.Spec.ManagementPolicy != v1alpha1.ManagementPolicyAdopt) {
+			if cr.GetDeletionPolicy() == v1alpha1.DeletionPolicySnapshot && cr.Status.FinalSnapshotIdentifier == "" {
+				cr.Status.FinalSnapshotIdentifier = finalSnapshotID(cr.GetDBClusterID())
+				if errUpdatingStatus := utils.UpdateStatus(cr, r.Client); errUpdatingStatus != nil {
+					return ctrl.Result{}, errUpdatingStatus
+				}
+			}
+			if errDeleting := cloudDB.DeleteDBCluster(cr); errDeleting != nil {
 				if _, ok := errDeleting.(aws.ErrRequeueNeeded); ok {
 					return ctrl.Result{Requeue: true}, nil
 				}
-				return ctrl.Result{}, errDeleting
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed,
+					errDeleting, cr, r.Client)
+			}
+			if cr.Status.FinalSnapshotIdentifier != "" {
+				r.Log.Info(fmt.Sprintf("%v - delete requested with final snapshot %v", namespacedName,
+					cr.Status.FinalSnapshotIdentifier))
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+			}
+		}
+
+		// release the object only once the final snapshot is available
+		if finalSnapshotID := cr.Status.FinalSnapshotIdentifier; finalSnapshotID != "" && !dbStatus.Exists {
+			snapshotState, errGettingSnapshot := cloudDB.DBClusterSnapshotExists(finalSnapshotID)
+			if errGettingSnapshot != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError,
+					errGettingSnapshot, cr, r.Client)
+			}
+			if snapshotState.CurrentPhase != string(v1alpha1.Available) {
+				r.Log.Info(fmt.Sprintf("%v - waiting for final snapshot %v to become available", namespacedName, finalSnapshotID))
+				setFinalSnapshotPendingCondition(finalSnapshotID, snapshotState, cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			if cr.Spec.FinalSnapshotRecord {
+				if errRecording := r.recordFinalDBClusterSnapshot(cr, providerOptions.Region); errRecording != nil {
+					return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed,
+						errRecording, cr, r.Client)
+				}
 			}
 		}
 		if errDeletingFinalizer := utils.RemoveFinalizer(dbClusterFinalizer, r.Client, cr); errDeletingFinalizer != nil {
@@ -125,46 +196,213 @@ func (r *DBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
-	// get masterPassword
-	// TODO: Generate password and make masterUserPassword optional
-	passSecretName := cr.Spec.PasswordRef.SecretRef.Name
-	passSecretNs := cr.GetNamespace()
-	passwordKey := cr.Spec.PasswordRef.PasswordKey
-	dbPass, _, errFetchingKey := utils.GetSecretValue(passSecretName,
-		passSecretNs, passwordKey, r.Client)
+	// adopt policy never creates, existing clusters are tagged as owned by this object
+	if !dbStatus.Exists && cr.Spec.ManagementPolicy == v1alpha1.ManagementPolicyAdopt {
+		r.Log.Info(fmt.Sprintf("%v - managementPolicy is Adopt and %v does not exist", namespacedName, cr.GetDBClusterID()))
+		utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionFalse, v1alpha1.ReasonNotFound,
+			fmt.Sprintf("managementPolicy is Adopt and %s does not exist", cr.GetDBClusterID()), cr)
+		return ctrl.Result{RequeueAfter: time.Minute}, utils.UpdateStatus(cr, r.Client)
+	}
+	if dbStatus.Exists && owner == "" {
+		backfilled, errAdopting := r.adoptDBCluster(cr, dbStatus, cloudDB)
+		if errAdopting != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonAdoptFailed,
+				errAdopting, cr, r.Client)
+		}
+		if backfilled {
+			return ctrl.Result{Requeue: true}, utils.UpdateStatus(cr, r.Client)
+		}
+	}
+
+	// promote a replica cluster when requested through the promote annotation or a DBAction
+	if dbStatus.Exists && cr.IsPromoteRequested() {
+		return r.promoteDBCluster(cr, dbStatus, cloudDB)
+	}
+
+	if errValidating := cr.Validate(); errValidating != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonInvalidSpec,
+			errValidating, cr, r.Client)
+	}
+
+	// get masterPassword, generating it when passwordRef is omitted
+	passSecretName, passwordKey := cr.GetPasswordSecretNameAndKey()
+	var dbPass string
+	var errFetchingKey error
+	if cr.IsPasswordGenerated() {
+		dbPass, _, errFetchingKey = utils.GetOrCreateGeneratedPassword(cr, passSecretName, passwordKey,
+			cr.Spec.Engine, dbStatus.Exists, r.Client, r.Scheme)
+	} else {
+		dbPass, _, errFetchingKey = utils.GetSecretValue(passSecretName,
+			cr.GetNamespace(), passwordKey, r.Client)
+	}
 	if errFetchingKey != nil {
-		return ctrl.Result{}, errFetchingKey
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonPasswordSecretError,
+			errFetchingKey, cr, r.Client)
+	}
+	passHash := utils.PasswordHash(cr, dbPass)
+
+	// the referenced subnet group must be available before the cluster is created
+	if !dbStatus.Exists && cr.Spec.DBSubnetGroupRef != nil {
+		subnetGroupName, errResolving := resolveDBSubnetGroupRef(cr.GetNamespace(), cr.Spec.DBSubnetGroupRef, r.Client)
+		if errResolving != nil {
+			if _, ok := errResolving.(utils.ErrDBSubnetGroupNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%v - waiting for the subnet group to become available", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable,
+					errResolving.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound,
+				errResolving, cr, r.Client)
+		}
+		// read by the create and restore inputs, only the status is written back
+		cr.Spec.DBSubnetGroupName = subnetGroupName
+	}
+
+	// the referenced cluster parameter group must exist before the cluster is created or switched to it
+	if cr.Spec.DBClusterParameterGroupRef != nil {
+		parameterGroupName, errResolving := resolveDBClusterParameterGroupRef(cr.GetNamespace(), cr.Spec.DBClusterParameterGroupRef, r.Client)
+		if errResolving != nil {
+			if _, ok := errResolving.(utils.ErrParameterGroupNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%v - waiting for the cluster parameter group to be created", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable,
+					errResolving.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound,
+				errResolving, cr, r.Client)
+		}
+		// read by the create, restore and modify inputs, only the status is written back
+		cr.Spec.DBClusterParameterGroupName = parameterGroupName
+	}
+
+	// the referenced option group must exist before the cluster is created or switched to it
+	if cr.Spec.OptionGroupRef != nil {
+		optionGroupName, errResolving := resolveOptionGroupRef(cr.GetNamespace(), cr.Spec.OptionGroupRef, r.Client)
+		if errResolving != nil {
+			if _, ok := errResolving.(utils.ErrOptionGroupNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%v - waiting for the option group to be created", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable,
+					errResolving.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound,
+				errResolving, cr, r.Client)
+		}
+		// read by the create, restore and modify inputs, only the status is written back
+		cr.Spec.OptionGroupName = optionGroupName
+	}
+
+	if !dbStatus.Exists && cr.Spec.RestoreFrom != nil {
+		restoreMessage, errRestoring := r.restoreDBCluster(cr, cloudDB)
+		if errRestoring != nil {
+			if _, ok := errRestoring.(utils.ErrSnapshotNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%v - waiting for snapshot to become available before restoring", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable,
+					errRestoring.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonRestoreFailed,
+				errRestoring, cr, r.Client)
+		}
+		// the restored cluster keeps the master password of its source, it is rotated
+		// to the password secret once the cluster is available
+		r.Log.Info(fmt.Sprintf("%v - does not exist in cloud, %v", namespacedName, restoreMessage))
+		setInProgressConditions(v1alpha1.ReasonRestoring, restoreMessage, cr)
+		return ctrl.Result{Requeue: true}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
 	}
 
 	if !dbStatus.Exists {
 		r.Log.Info(fmt.Sprintf("%v - does not exist in cloud, creating now", namespacedName))
-		if errCreatingDBCluster := r.CloudDBInterface.CreateDBCluster(cr, dbPass); errCreatingDBCluster != nil {
+		if errCreatingDBCluster := cloudDB.CreateDBCluster(cr, dbPass); errCreatingDBCluster != nil {
 			r.Log.Error(errCreatingDBCluster, fmt.Sprintf("%v - failed to create dbcluster", namespacedName))
-			return ctrl.Result{}, errCreatingDBCluster
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonCreateFailed,
+				errCreatingDBCluster, cr, r.Client)
 		}
+		cr.Status.PasswordHash = passHash
+		setInProgressConditions(v1alpha1.ReasonCreating, "create requested", cr)
 		return ctrl.Result{Requeue: true}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
 	}
 
-	isUpToDate, modifyIn, errChecking := r.CloudDBInterface.IsDBClusterUpToDate(cr)
+	// rotate the master password when the password in the secret changed since it was last applied, or
+	// after a restore. clusters created before this was tracked only record the current hash, so do replica
+	// clusters and global database secondaries whose master password cannot be changed.
+	if cr.Status.PasswordHash != passHash {
+		if (cr.Status.PasswordHash != "" || cr.Status.RestoredFrom != "") && !cr.IsReplica() {
+			r.Log.Info(fmt.Sprintf("%v - password secret changed, rotating master password", namespacedName))
+			if errRotating := cloudDB.UpdateDBClusterPassword(cr, dbPass); errRotating != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonRotationFailed,
+					errRotating, cr, r.Client)
+			}
+			setPasswordRotatedCondition(passSecretName, cr)
+			cr.Status.PasswordHash = passHash
+			setInProgressConditions(v1alpha1.ReasonUpdating, "master password rotation requested", cr)
+			return ctrl.Result{Requeue: true}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+		}
+		cr.Status.PasswordHash = passHash
+	}
+
+	isUpToDate, modifyIn, errChecking := cloudDB.IsDBClusterUpToDate(cr)
 	if errChecking != nil {
-		return ctrl.Result{}, errChecking
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError,
+			errChecking, cr, r.Client)
 	}
 
 	if !isUpToDate {
-		errUpdating := r.CloudDBInterface.ModifyDBCluster(modifyIn)
+		errUpdating := cloudDB.ModifyDBCluster(modifyIn)
 		if errUpdating != nil {
-			return ctrl.Result{}, errUpdating
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUpdateFailed,
+				errUpdating, cr, r.Client)
 		}
 		r.Log.Info(fmt.Sprintf("%v - updating", namespacedName))
+		setInProgressConditions(v1alpha1.ReasonUpdating, "modify requested", cr)
 		return ctrl.Result{Requeue: true}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
 	}
 
 	svcResult, svcName, errReconcilingSvc := createOrUpdateExternalNameSvc(cr, dbStatus.Endpoint, r.Client, r.Scheme)
 	if errReconcilingSvc != nil {
-		return ctrl.Result{}, errReconcilingSvc
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionReady, v1alpha1.ReasonReconcileError,
+			errReconcilingSvc, cr, r.Client)
 	}
 	r.Log.Info(fmt.Sprintf("%s - ExternalName service %s", svcName, svcResult))
+	if dbStatus.ReaderEndpoint != "" {
+		roResult, errReconcilingRoSvc := createOrUpdateNamedExternalNameSvc(cr, cr.GetReaderServiceName(),
+			dbStatus.ReaderEndpoint, r.Client, r.Scheme)
+		if errReconcilingRoSvc != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionReady, v1alpha1.ReasonReconcileError,
+				errReconcilingRoSvc, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - ExternalName service %s", cr.GetReaderServiceName(), roResult))
+	}
+
+	// custom endpoints
+	endpointsPending, errReconcilingEndpoints := r.reconcileDBClusterEndpoints(cr, cloudDB)
+	if errReconcilingEndpoints != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUpdateFailed,
+			errReconcilingEndpoints, cr, r.Client)
+	}
+	if endpointsPending {
+		r.Log.Info(fmt.Sprintf("%v - waiting for custom endpoints to become available", namespacedName))
+		setInProgressConditions(v1alpha1.ReasonUpdating, "custom endpoints are not available yet", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+	}
+
+	if cr.Spec.ConnectionSecret != nil {
+		secretResult, errReconcilingSecret := createOrUpdateConnectionSecret(cr, cr.GetConnectionSecretName(), utils.ConnectionInfo{
+			Engine:   cr.Spec.Engine,
+			Host:     dbStatus.Endpoint,
+			Port:     dbStatus.Port,
+			Username: cr.Spec.MasterUsername,
+			Password: dbPass,
+			DBName:   cr.Spec.DatabaseName,
+		}, r.Client, r.Scheme)
+		if errReconcilingSecret != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionReady, v1alpha1.ReasonReconcileError,
+				errReconcilingSecret, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - connection secret %s", cr.GetConnectionSecretName(), secretResult))
+	}
 
+	setAvailableConditions(dbStatus, cr)
 	if err := utils.UpdateStatusPhase(v1alpha1.Available, cr, r.Client); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -172,16 +410,76 @@ func (r *DBClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	return ctrl.Result{}, nil
 }
 
+// adoptDBCluster tags an existing cluster as owned by cr and, when requested, fills the spec from the
+// live cluster first. The spec is saved before the tag is written, a failed update leaves the cluster
+// untagged so the next reconcile backfills again instead of modifying the cluster towards a sparse spec.
+// It returns true when the spec was updated.
+func (r *DBClusterReconciler) adoptDBCluster(cr *v1alpha1.DBCluster, dbStatus *v1alpha1.DBStatus, cloudDB factory.CloudDB) (bool, error) {
+	backfill := cr.Spec.ManagementPolicy == v1alpha1.ManagementPolicyAdopt && cr.Spec.BackfillSpec
+	if backfill {
+		status := cr.Status.DeepCopy()
+		if err := cloudDB.BackfillDBClusterSpec(cr); err != nil {
+			return false, err
+		}
+		if err := r.Client.Update(context.TODO(), cr); err != nil {
+			return false, err
+		}
+		cr.Status = *status
+	}
+	r.Log.Info(fmt.Sprintf("%s/%s - tagging dbcluster %s as owned", cr.GetNamespace(), cr.GetName(), cr.GetDBClusterID()))
+	if err := tagOwner(dbStatus, cr.GetOwnerTagValue(), cloudDB); err != nil {
+		return false, err
+	}
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue, v1alpha1.ReasonAdopted,
+		fmt.Sprintf("%s is owned by %s", cr.GetDBClusterID(), cr.GetOwnerTagValue()), cr)
+	return backfill, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexSecretRefs(mgr, &v1alpha1.DBCluster{}, dbClusterSecretRefs); err != nil {
+		return err
+	}
+	if err := indexProviderName(mgr, &v1alpha1.DBCluster{}, dbClusterProviderName); err != nil {
+		return err
+	}
+	if err := indexParameterGroupRefs(mgr, &v1alpha1.DBCluster{}, dbClusterParameterGroupRefIndexKey, dbClusterParameterGroupRef); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBCluster{}, builder.WithPredicates(r.dbClusterPredicates())).
 		Owns(&v1.Service{}, builder.WithPredicates(predicate.Funcs{
 			CreateFunc: func(event event.CreateEvent) bool { return false },
 		})).
+		Owns(&v1.Secret{}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(event event.CreateEvent) bool { return false },
+		})).
 		Watches(
 			&source.Kind{Type: &v1.Secret{}},
-			handler.EnqueueRequestsFromMapFunc(r.dbClusterSecretsEventHandlerFunc()),
+			handler.EnqueueRequestsFromMapFunc(secretsEventHandlerFunc(r.Client, r.Log, func() client.ObjectList {
+				return &v1alpha1.DBClusterList{}
+			})),
+		).
+		Watches(
+			&source.Kind{Type: &v1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(providerSecretsEventHandlerFunc(r.Client, r.Log, func() client.ObjectList {
+				return &v1alpha1.DBClusterList{}
+			})),
+		).
+		Watches(
+			&source.Kind{Type: &v1alpha1.Provider{}},
+			handler.EnqueueRequestsFromMapFunc(providerEventHandlerFunc(r.Client, r.Log, func() client.ObjectList {
+				return &v1alpha1.DBClusterList{}
+			})),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{Type: &v1alpha1.DBClusterParameterGroup{}},
+			handler.EnqueueRequestsFromMapFunc(parameterGroupEventHandlerFunc(r.Client, r.Log, dbClusterParameterGroupRefIndexKey,
+				func() client.ObjectList {
+					return &v1alpha1.DBClusterList{}
+				})),
+			builder.WithPredicates(parameterGroupChangedPredicate()),
 		).
 		WithOptions(controller.Options{MaxConcurrentReconciles: 10}).
 		Complete(r)
@@ -189,19 +487,63 @@ func (r *DBClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 func createOrUpdateExternalNameSvc(owner metav1.Object, endpoint string, client client.Client, scheme *runtime.Scheme) (string, string, error) {
 	svcName := owner.GetName()
+	res, err := createOrUpdateNamedExternalNameSvc(owner, svcName, endpoint, client, scheme)
+	return res, svcName, err
+}
+
+func createOrUpdateNamedExternalNameSvc(owner metav1.Object, svcName, endpoint string, client client.Client, scheme *runtime.Scheme) (string, error) {
 	svc := &v1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      svcName,
 			Namespace: owner.GetNamespace(),
 		},
-		Spec: v1.ServiceSpec{
-			Type:         v1.ServiceTypeExternalName,
-			ExternalName: endpoint,
-		},
 	}
 
+	// the endpoint is set in the mutate func, so a changed endpoint also updates an existing service
 	res, err := controllerutil.CreateOrUpdate(context.TODO(), client, svc, func() error {
+		svc.Spec.Type = v1.ServiceTypeExternalName
+		svc.Spec.ExternalName = endpoint
 		return controllerutil.SetControllerReference(owner, svc, scheme)
 	})
-	return string(res), svcName, err
+	return string(res), err
+}
+
+func createOrUpdateConnectionSecret(owner metav1.Object, secretName string, info utils.ConnectionInfo, client client.Client, scheme *runtime.Scheme) (string, error) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: owner.GetNamespace(),
+		},
+	}
+
+	res, err := controllerutil.CreateOrUpdate(context.TODO(), client, secret, func() error {
+		secret.Type = v1.SecretTypeOpaque
+		secret.Data = info.SecretData()
+		return controllerutil.SetControllerReference(owner, secret, scheme)
+	})
+	return string(res), err
+}
+
+// secretOwner is an object the operator generates a password secret and a connection secret for
+type secretOwner interface {
+	metav1.Object
+	IsPasswordGenerated() bool
+	GetPasswordSecretNameAndKey() (string, string)
+	GetConnectionSecretName() string
+}
+
+// releaseSecrets removes the owner reference of owner from its generated password secret and its connection
+// secret. Used when the deletionPolicy keeps the cloud resource, which is unreachable without them.
+func releaseSecrets(owner secretOwner, client client.Client) error {
+	secretNames := []string{owner.GetConnectionSecretName()}
+	if owner.IsPasswordGenerated() {
+		passwordSecretName, _ := owner.GetPasswordSecretNameAndKey()
+		secretNames = append(secretNames, passwordSecretName)
+	}
+	for _, secretName := range secretNames {
+		if err := utils.RemoveSecretOwnerReference(owner, secretName, client); err != nil {
+			return err
+		}
+	}
+	return nil
 }
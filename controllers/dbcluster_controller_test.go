@@ -5,10 +5,12 @@ import (
 	"github.com/agill17/db-operator/api/v1alpha1"
 	"github.com/agill17/db-operator/pkg/factory"
 	aws2 "github.com/agill17/db-operator/pkg/factory/aws"
+	"github.com/agill17/db-operator/pkg/utils"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -63,7 +65,7 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						Namespace: "default",
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
@@ -77,7 +79,7 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						EngineMode:        "provisioned",
 						EngineVersion:     "5.7.12",
 						MasterUsername:    "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
@@ -117,8 +119,8 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when cluster does exist and is up to date - it should not requeue",
-			want:    controllerruntime.Result{},
+			name:    "Test-AWS DBluster - when the referenced subnet group is not available, should requeue without creating",
+			want:    controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
 			wantErr: false,
 			args: args{
 				ctx: context.Background(),
@@ -134,7 +136,7 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						Namespace: "default",
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
@@ -148,17 +150,18 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						EngineMode:        "provisioned",
 						EngineVersion:     "5.7.12",
 						MasterUsername:    "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
 							},
 						},
 						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						DBSubnetGroupRef:            &v1.LocalObjectReference{Name: "aurora-subnets"},
 					},
-					Status: v1alpha1.DBClusterStatus{
-						Phase: v1alpha1.Available,
-					},
+				}, &v1alpha1.DBSubnetGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "aurora-subnets", Namespace: "default"},
+					Status:     v1alpha1.DBSubnetGroupStatus{Phase: v1alpha1.Creating},
 				}, &v1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
@@ -182,18 +185,18 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 				Log:    logf.Log,
 				Scheme: testScheme,
 				CloudDBInterface: &factory.MockCloudDB{
+					CreateDBClusterErr:          aws2.ErrRequeueNeeded{Message: "should not be called"},
 					IsDBClusterUpToDateResp:     true,
 					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
 					DBStatusResp: &v1alpha1.DBStatus{
-						Exists:       true,
-						CurrentPhase: string(v1alpha1.Available),
+						Exists: false,
 					},
 				},
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when cluster does exist and is NOT up to date - it should modify and requeue",
-			want:    controllerruntime.Result{Requeue: true},
+			name:    "Test-AWS DBluster - when the referenced cluster parameter group is not created yet, should requeue without creating",
+			want:    controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
 			wantErr: false,
 			args: args{
 				ctx: context.Background(),
@@ -209,7 +212,7 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						Namespace: "default",
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
@@ -223,17 +226,16 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						EngineMode:        "provisioned",
 						EngineVersion:     "5.7.12",
 						MasterUsername:    "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
 							},
 						},
-						DBClusterParameterGroupName: "default-aurora-mysql5.7",
-					},
-					Status: v1alpha1.DBClusterStatus{
-						Phase: v1alpha1.Available,
+						DBClusterParameterGroupRef: &v1.LocalObjectReference{Name: "aurora-params"},
 					},
+				}, &v1alpha1.DBClusterParameterGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "aurora-params", Namespace: "default"},
 				}, &v1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: "default",
@@ -257,21 +259,18 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 				Log:    logf.Log,
 				Scheme: testScheme,
 				CloudDBInterface: &factory.MockCloudDB{
-					IsDBClusterUpToDateResp: false,
-					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{
-						DeletionProtection:  aws.Bool(true),
-						DBClusterIdentifier: aws.String("default-aws-db-cluster"),
-					},
+					CreateDBClusterErr:          aws2.ErrRequeueNeeded{Message: "should not be called"},
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
 					DBStatusResp: &v1alpha1.DBStatus{
-						Exists:       true,
-						CurrentPhase: string(v1alpha1.Available),
+						Exists: false,
 					},
 				},
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when dbcluster object does not exist - do not requeue, no error",
-			want:    controllerruntime.Result{Requeue: false},
+			name:    "Test-AWS DBluster - when cluster does exist and is up to date - it should not requeue",
+			want:    controllerruntime.Result{},
 			wantErr: false,
 			args: args{
 				ctx: context.Background(),
@@ -281,16 +280,64 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 				}},
 			},
 			fields: fields{
-				Client: fake.NewFakeClientWithScheme(testScheme),
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
 				Log:    logf.Log,
 				Scheme: testScheme,
 				CloudDBInterface: &factory.MockCloudDB{
-					IsDBClusterUpToDateResp: false,
-					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{
-						DeletionProtection:  aws.Bool(true),
-						DBClusterIdentifier: aws.String("default-aws-db-cluster"),
-					},
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
 					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
 						Exists:       true,
 						CurrentPhase: string(v1alpha1.Available),
 					},
@@ -298,7 +345,7 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when provider secrets do not exist",
+			name:    "Test-AWS DBluster - when serverlessV2ScalingConfiguration is set with engineMode serverless - it should error",
 			want:    controllerruntime.Result{},
 			wantErr: true,
 			args: args{
@@ -315,7 +362,7 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						Namespace: "default",
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
@@ -326,30 +373,61 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
 						DatabaseName:      "test",
 						Engine:            "aurora-mysql",
-						EngineMode:        "provisioned",
+						EngineMode:        "serverless",
 						EngineVersion:     "5.7.12",
 						MasterUsername:    "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
 							},
 						},
 						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						ServerlessV2ScalingConfiguration: &v1alpha1.ServerlessV2ScalingConfiguration{
+							MinCapacity: "0.5",
+							MaxCapacity: "16",
+						},
 					},
 					Status: v1alpha1.DBClusterStatus{
 						Phase: v1alpha1.Available,
 					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
 				}),
-				Log:              logf.Log,
-				Scheme:           testScheme,
-				CloudDBInterface: &factory.MockCloudDB{},
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when master user password secret does not exist",
-			want:    controllerruntime.Result{},
-			wantErr: true,
+			name:    "Test-AWS DBluster - when the promote annotation is set on a replica cluster - it should promote and requeue",
+			want:    controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantErr: false,
 			args: args{
 				ctx: context.Background(),
 				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
@@ -360,11 +438,12 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 			fields: fields{
 				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "aws-db-cluster",
-						Namespace: "default",
+						Name:        "aws-db-cluster",
+						Namespace:   "default",
+						Annotations: map[string]string{v1alpha1.PromoteAnnotation: "true"},
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
@@ -378,13 +457,14 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						EngineMode:        "provisioned",
 						EngineVersion:     "5.7.12",
 						MasterUsername:    "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
 							},
 						},
 						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						ReplicationSourceIdentifier: "arn:aws:rds:us-west-2:123456789012:cluster:primary",
 					},
 					Status: v1alpha1.DBClusterStatus{
 						Phase: v1alpha1.Available,
@@ -399,21 +479,33 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
 					},
 					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
 				}),
 				Log:    logf.Log,
 				Scheme: testScheme,
 				CloudDBInterface: &factory.MockCloudDB{
-					DBClusterExistsErr: nil,
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
 					DBStatusResp: &v1alpha1.DBStatus{
-						Exists:       false,
-						CurrentPhase: "",
+						Tags:                    map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:                  true,
+						CurrentPhase:            string(v1alpha1.Available),
+						ReplicaSourceIdentifier: "arn:aws:rds:us-west-2:123456789012:cluster:primary",
 					},
 				},
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when dbCluster CR has a deletion timestamp with deletionProtection disabled",
-			want:    controllerruntime.Result{Requeue: false},
+			name:    "Test-AWS DBluster - when managementPolicy is ObserveOnly - it should only report status",
+			want:    controllerruntime.Result{RequeueAfter: observeOnlyRequeueAfter},
 			wantErr: false,
 			args: args{
 				ctx: context.Background(),
@@ -425,33 +517,35 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 			fields: fields{
 				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:              "aws-db-cluster",
-						Namespace:         "default",
-						DeletionTimestamp: &metav1.Time{Time: timeNow},
+						Name:      "aws-db-cluster",
+						Namespace: "default",
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
 								Namespace: "default",
 							},
 						},
-						Region:             "us-east-1",
-						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
-						DeletionProtection: false,
-						DatabaseName:       "test",
-						Engine:             "aurora-mysql",
-						EngineMode:         "provisioned",
-						EngineVersion:      "5.7.12",
-						MasterUsername:     "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
 							},
 						},
 						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						ManagementPolicy:            v1alpha1.ManagementPolicyObserveOnly,
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
 					},
 				}, &v1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
@@ -476,8 +570,8 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 				Log:    logf.Log,
 				Scheme: testScheme,
 				CloudDBInterface: &factory.MockCloudDB{
-					DeleteDBClusterErr:      nil,
-					IsDBClusterUpToDateResp: true,
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
 					DBStatusResp: &v1alpha1.DBStatus{
 						Exists:       true,
 						CurrentPhase: string(v1alpha1.Available),
@@ -486,9 +580,9 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when dbCluster CR has a deletion timestamp with deletionProtection enabled",
-			want:    controllerruntime.Result{},
-			wantErr: true,
+			name:    "Test-AWS DBluster - when managementPolicy is Adopt and cluster does not exist - it should not create",
+			want:    controllerruntime.Result{RequeueAfter: time.Minute},
+			wantErr: false,
 			args: args{
 				ctx: context.Background(),
 				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
@@ -499,33 +593,35 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 			fields: fields{
 				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:              "aws-db-cluster",
-						Namespace:         "default",
-						DeletionTimestamp: &metav1.Time{Time: timeNow},
+						Name:      "aws-db-cluster",
+						Namespace: "default",
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
 								Namespace: "default",
 							},
 						},
-						Region:             "us-east-1",
-						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
-						DeletionProtection: false,
-						DatabaseName:       "test",
-						Engine:             "aurora-mysql",
-						EngineMode:         "provisioned",
-						EngineVersion:      "5.7.12",
-						MasterUsername:     "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
 							},
 						},
 						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						ManagementPolicy:            v1alpha1.ManagementPolicyAdopt,
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
 					},
 				}, &v1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
@@ -550,18 +646,17 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 				Log:    logf.Log,
 				Scheme: testScheme,
 				CloudDBInterface: &factory.MockCloudDB{
-					DeleteDBClusterErr:      aws2.ErrDBClusterDeletionProtectionEnabled{Message: "errDeletionProtectionIsEnabled"},
-					IsDBClusterUpToDateResp: true,
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
 					DBStatusResp: &v1alpha1.DBStatus{
-						Exists:       true,
-						CurrentPhase: string(v1alpha1.Available),
+						Exists: false,
 					},
 				},
 			},
 		},
 		{
-			name:    "Test-AWS DBluster - when cluster exists but is not yet available",
-			want:    controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			name:    "Test-AWS DBluster - when managementPolicy is Adopt with backfillSpec - it should tag, backfill and requeue",
+			want:    controllerruntime.Result{Requeue: true},
 			wantErr: false,
 			args: args{
 				ctx: context.Background(),
@@ -573,33 +668,36 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 			fields: fields{
 				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:              "aws-db-cluster",
-						Namespace:         "default",
-						DeletionTimestamp: &metav1.Time{Time: timeNow},
+						Name:      "aws-db-cluster",
+						Namespace: "default",
 					},
 					Spec: v1alpha1.DBClusterSpec{
-						Provider: v1alpha1.Provider{
+						Provider: v1alpha1.ProviderConfig{
 							Type: "aws",
 							SecretRef: v1.SecretReference{
 								Name:      "aws-provider-secret",
 								Namespace: "default",
 							},
 						},
-						Region:             "us-east-1",
-						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
-						DeletionProtection: false,
-						DatabaseName:       "test",
-						Engine:             "aurora-mysql",
-						EngineMode:         "provisioned",
-						EngineVersion:      "5.7.12",
-						MasterUsername:     "test",
-						PasswordRef: v1alpha1.PasswordRef{
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
 							PasswordKey: "password",
 							SecretRef: &v1.LocalObjectReference{
 								Name: "dbcluster-password",
 							},
 						},
 						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						ManagementPolicy:            v1alpha1.ManagementPolicyAdopt,
+						BackfillSpec:                true,
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
 					},
 				}, &v1.Secret{
 					ObjectMeta: metav1.ObjectMeta{
@@ -624,29 +722,1620 @@ func TestDBClusterReconciler_Reconcile(t *testing.T) {
 				Log:    logf.Log,
 				Scheme: testScheme,
 				CloudDBInterface: &factory.MockCloudDB{
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
 					DBStatusResp: &v1alpha1.DBStatus{
 						Exists:       true,
-						CurrentPhase: string(v1alpha1.Creating),
+						CurrentPhase: string(v1alpha1.Available),
 					},
 				},
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			r := &DBClusterReconciler{
-				Client:           tt.fields.Client,
-				Log:              tt.fields.Log,
-				Scheme:           tt.fields.Scheme,
-				CloudDBInterface: tt.fields.CloudDBInterface,
-			}
-			got, err := r.Reconcile(tt.args.ctx, tt.args.req)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+		{
+			name:    "Test-AWS DBluster - when cluster is owned by another object - it should refuse to manage it",
+			want:    controllerruntime.Result{},
+			wantErr: true,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					IsDBClusterUpToDateResp:     true,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{},
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/other/aws-db-cluster"},
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when cluster does exist and is NOT up to date - it should modify and requeue",
+			want:    controllerruntime.Result{Requeue: true},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					IsDBClusterUpToDateResp: false,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{
+						DeletionProtection:  aws.Bool(true),
+						DBClusterIdentifier: aws.String("default-aws-db-cluster"),
+					},
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when dbcluster object does not exist - do not requeue, no error",
+			want:    controllerruntime.Result{Requeue: false},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					IsDBClusterUpToDateResp: false,
+					IsDBClusterUpToDateModifyIn: &rds.ModifyDBClusterInput{
+						DeletionProtection:  aws.Bool(true),
+						DBClusterIdentifier: aws.String("default-aws-db-cluster"),
+					},
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when provider secrets do not exist",
+			want:    controllerruntime.Result{},
+			wantErr: true,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
+					},
+				}),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: &factory.MockCloudDB{},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when master user password secret does not exist",
+			want:    controllerruntime.Result{},
+			wantErr: true,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase: v1alpha1.Available,
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBClusterExistsErr: nil,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists:       false,
+						CurrentPhase: "",
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when passwordRef is omitted, should generate a password and requeue after creating",
+			want:    controllerruntime.Result{Requeue: true},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:                      "us-east-1",
+						AvailabilityZones:           []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:                "test",
+						Engine:                      "aurora-mysql",
+						EngineMode:                  "provisioned",
+						EngineVersion:               "5.7.12",
+						MasterUsername:              "test",
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists:       false,
+						CurrentPhase: "",
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when passwordRef is omitted and cluster exists without the generated password secret",
+			want:    controllerruntime.Result{},
+			wantErr: true,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:                      "us-east-1",
+						AvailabilityZones:           []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:                "test",
+						Engine:                      "aurora-mysql",
+						EngineMode:                  "provisioned",
+						EngineVersion:               "5.7.12",
+						MasterUsername:              "test",
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: "available",
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when password secret changed since it was last applied, should rotate and requeue",
+			want:    controllerruntime.Result{Requeue: true},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase:        v1alpha1.Available,
+						PasswordHash: "hash-of-the-previous-password",
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("rotated"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					IsDBClusterUpToDateResp: true,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: "available",
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when cluster does not exist and restoreFrom has a snapshot identifier, should requeue after restoring",
+			want:    controllerruntime.Result{Requeue: true},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						RestoreFrom: &v1alpha1.RestoreFrom{
+							SnapshotIdentifier: "prod-snapshot",
+						},
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists: false,
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when cluster does not exist and restoreFrom has a point in time, should requeue after restoring",
+			want:    controllerruntime.Result{Requeue: true},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						RestoreFrom: &v1alpha1.RestoreFrom{
+							PointInTime: &v1alpha1.PointInTime{
+								SourceIdentifier:        "prod",
+								UseLatestRestorableTime: true,
+							},
+						},
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists: false,
+					},
+					RestoreTimeResp: &metav1.Time{Time: timeNow},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when restoreFrom references a DBClusterSnapshot that is not available, should wait",
+			want:    controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "aws-db-cluster",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:            "us-east-1",
+						AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+						DatabaseName:      "test",
+						Engine:            "aurora-mysql",
+						EngineMode:        "provisioned",
+						EngineVersion:     "5.7.12",
+						MasterUsername:    "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						RestoreFrom: &v1alpha1.RestoreFrom{
+							SnapshotRef: &v1.LocalObjectReference{Name: "prod-snapshot"},
+						},
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1alpha1.DBClusterSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "prod-snapshot",
+					},
+					Spec: v1alpha1.DBClusterSnapshotSpec{DBClusterName: "prod"},
+					Status: v1alpha1.DBClusterSnapshotStatus{
+						Phase: v1alpha1.Creating,
+					},
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists: false,
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when dbCluster CR has a deletion timestamp with deletionProtection disabled",
+			want:    controllerruntime.Result{Requeue: false},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "aws-db-cluster",
+						Namespace:         "default",
+						DeletionTimestamp: &metav1.Time{Time: timeNow},
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:             "us-east-1",
+						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
+						DeletionProtection: false,
+						DatabaseName:       "test",
+						Engine:             "aurora-mysql",
+						EngineMode:         "provisioned",
+						EngineVersion:      "5.7.12",
+						MasterUsername:     "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						DeletionPolicy:              v1alpha1.DeletionPolicyDelete,
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DeleteDBClusterErr:      nil,
+					IsDBClusterUpToDateResp: true,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when dbCluster CR has a deletion timestamp with deletionPolicy Snapshot, should requeue after requesting the final snapshot",
+			want:    controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "aws-db-cluster",
+						Namespace:         "default",
+						DeletionTimestamp: &metav1.Time{Time: timeNow},
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:             "us-east-1",
+						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
+						DeletionProtection: false,
+						DatabaseName:       "test",
+						Engine:             "aurora-mysql",
+						EngineMode:         "provisioned",
+						EngineVersion:      "5.7.12",
+						MasterUsername:     "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						DeletionPolicy:              v1alpha1.DeletionPolicySnapshot,
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DeleteDBClusterErr:      nil,
+					IsDBClusterUpToDateResp: true,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when dbCluster CR has a deletion timestamp when the cluster is gone and the final snapshot is available, should record it and release",
+			want:    controllerruntime.Result{Requeue: false},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "aws-db-cluster",
+						Namespace:         "default",
+						DeletionTimestamp: &metav1.Time{Time: timeNow},
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:             "us-east-1",
+						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
+						DeletionProtection: false,
+						DatabaseName:       "test",
+						Engine:             "aurora-mysql",
+						EngineMode:         "provisioned",
+						EngineVersion:      "5.7.12",
+						MasterUsername:     "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						DeletionPolicy:              v1alpha1.DeletionPolicySnapshot,
+						FinalSnapshotRecord:         true,
+					},
+					Status: v1alpha1.DBClusterStatus{
+						Phase:                   v1alpha1.Deleting,
+						FinalSnapshotIdentifier: "aws-db-cluster-final-1600000000",
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DeleteDBClusterErr:      nil,
+					IsDBClusterUpToDateResp: true,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists: false,
+					},
+					SnapshotStateResp: &v1alpha1.SnapshotState{
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when dbCluster CR has a deletion timestamp with deletionPolicy Retain, should release it without deleting",
+			want:    controllerruntime.Result{Requeue: false},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "aws-db-cluster",
+						Namespace:         "default",
+						DeletionTimestamp: &metav1.Time{Time: timeNow},
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:             "us-east-1",
+						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
+						DeletionProtection: false,
+						DatabaseName:       "test",
+						Engine:             "aurora-mysql",
+						EngineMode:         "provisioned",
+						EngineVersion:      "5.7.12",
+						MasterUsername:     "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+						DeletionPolicy:              v1alpha1.DeletionPolicyRetain,
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DeleteDBClusterErr:      aws2.ErrDBClusterDeletionProtectionEnabled{Message: "must not be called"},
+					IsDBClusterUpToDateResp: true,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when dbCluster CR has a deletion timestamp with deletionProtection enabled",
+			want:    controllerruntime.Result{},
+			wantErr: true,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "aws-db-cluster",
+						Namespace:         "default",
+						DeletionTimestamp: &metav1.Time{Time: timeNow},
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:             "us-east-1",
+						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
+						DeletionProtection: false,
+						DatabaseName:       "test",
+						Engine:             "aurora-mysql",
+						EngineMode:         "provisioned",
+						EngineVersion:      "5.7.12",
+						MasterUsername:     "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DeleteDBClusterErr:      aws2.ErrDBClusterDeletionProtectionEnabled{Message: "errDeletionProtectionIsEnabled"},
+					IsDBClusterUpToDateResp: true,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+					},
+				},
+			},
+		},
+		{
+			name:    "Test-AWS DBluster - when cluster exists but is not yet available",
+			want:    controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantErr: false,
+			args: args{
+				ctx: context.Background(),
+				req: controllerruntime.Request{NamespacedName: types.NamespacedName{
+					Namespace: "default",
+					Name:      "aws-db-cluster",
+				}},
+			},
+			fields: fields{
+				Client: fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "aws-db-cluster",
+						Namespace:         "default",
+						DeletionTimestamp: &metav1.Time{Time: timeNow},
+					},
+					Spec: v1alpha1.DBClusterSpec{
+						Provider: v1alpha1.ProviderConfig{
+							Type: "aws",
+							SecretRef: v1.SecretReference{
+								Name:      "aws-provider-secret",
+								Namespace: "default",
+							},
+						},
+						Region:             "us-east-1",
+						AvailabilityZones:  []string{"us-east-1a", "us-east-1b"},
+						DeletionProtection: false,
+						DatabaseName:       "test",
+						Engine:             "aurora-mysql",
+						EngineMode:         "provisioned",
+						EngineVersion:      "5.7.12",
+						MasterUsername:     "test",
+						PasswordRef: &v1alpha1.PasswordRef{
+							PasswordKey: "password",
+							SecretRef: &v1.LocalObjectReference{
+								Name: "dbcluster-password",
+							},
+						},
+						DBClusterParameterGroupName: "default-aurora-mysql5.7",
+					},
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "aws-provider-secret",
+					},
+					Data: map[string][]byte{
+						"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+						"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}, &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "dbcluster-password",
+					},
+					Data: map[string][]byte{
+						"password": []byte("test"),
+					},
+					Type: v1.SecretTypeOpaque,
+				}),
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Creating),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DBClusterReconciler{
+				Client:           tt.fields.Client,
+				Log:              tt.fields.Log,
+				Scheme:           tt.fields.Scheme,
+				CloudDBInterface: tt.fields.CloudDBInterface,
+			}
+			got, err := r.Reconcile(tt.args.ctx, tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDBClusterReconciler_ReleasesSecretsWhenKept(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	for _, policy := range []v1alpha1.DeletionPolicy{v1alpha1.DeletionPolicyRetain, v1alpha1.DeletionPolicyOrphan} {
+		t.Run(string(policy), func(t *testing.T) {
+			ownerRef := metav1.OwnerReference{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       "DBCluster",
+				Name:       "aws-db-cluster",
+				UID:        "db-cluster-uid",
+				Controller: aws.Bool(true),
+			}
+			ownedSecret := func(name string) *v1.Secret {
+				return &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, OwnerReferences: []metav1.OwnerReference{ownerRef}},
+					Data:       map[string][]byte{"password": []byte("test")},
+				}
+			}
+			fakeClient := fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "aws-db-cluster",
+					Namespace:         "default",
+					UID:               "db-cluster-uid",
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					Finalizers:        []string{dbClusterFinalizer},
+				},
+				Spec: v1alpha1.DBClusterSpec{
+					Provider: v1alpha1.ProviderConfig{
+						Type:      "aws",
+						SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+					},
+					Region:           "us-east-1",
+					Engine:           "aurora-mysql",
+					MasterUsername:   "test",
+					ConnectionSecret: &v1alpha1.ConnectionSecret{},
+					DeletionPolicy:   policy,
+				},
+			}, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "aws-provider-secret"},
+				Data: map[string][]byte{
+					"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+					"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+				},
+			}, ownedSecret("aws-db-cluster-master-password"), ownedSecret("aws-db-cluster-connection"))
+			r := &DBClusterReconciler{
+				Client: fakeClient,
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DeleteDBClusterErr: aws2.ErrDBClusterDeletionProtectionEnabled{Message: "must not be called"},
+					DBStatusResp: &v1alpha1.DBStatus{
+						Exists:       true,
+						CurrentPhase: string(v1alpha1.Available),
+						Tags:         map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+					},
+				},
+			}
+			if _, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"},
+			}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			for _, name := range []string{"aws-db-cluster-master-password", "aws-db-cluster-connection"} {
+				secret := &v1.Secret{}
+				if err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, secret); err != nil {
+					t.Fatalf("Get() of %s error = %v", name, err)
+				}
+				if len(secret.GetOwnerReferences()) != 0 {
+					t.Errorf("%s owner references = %v, want none", name, secret.GetOwnerReferences())
+				}
+			}
+		})
+	}
+}
+
+func TestDBClusterReconciler_UntaggedExistingCluster(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	dbCluster := func(policy v1alpha1.ManagementPolicy, phase v1alpha1.Phase, deletionTimestamp *metav1.Time) *v1alpha1.DBCluster {
+		return &v1alpha1.DBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "aws-db-cluster",
+				Namespace:         "default",
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{dbClusterFinalizer},
+			},
+			Spec: v1alpha1.DBClusterSpec{
+				Provider: v1alpha1.ProviderConfig{
+					Type:      "aws",
+					SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+				},
+				Region:         "us-east-1",
+				Engine:         "aurora-mysql",
+				MasterUsername: "test",
+				PasswordRef: &v1alpha1.PasswordRef{
+					PasswordKey: "password",
+					SecretRef:   &v1.LocalObjectReference{Name: "dbcluster-password"},
+				},
+				DeletionPolicy:   v1alpha1.DeletionPolicyDelete,
+				ManagementPolicy: policy,
+			},
+			Status: v1alpha1.DBClusterStatus{Phase: phase},
+		}
+	}
+	tests := []struct {
+		name          string
+		cr            *v1alpha1.DBCluster
+		wantErr       bool
+		wantReason    string
+		wantFinalizer bool
+	}{
+		{
+			name:          "Full refuses a cluster it did not create",
+			cr:            dbCluster(v1alpha1.ManagementPolicyFull, "", nil),
+			wantErr:       true,
+			wantReason:    v1alpha1.ReasonOwnershipConflict,
+			wantFinalizer: true,
+		},
+		{
+			name:          "Adopt takes the cluster over",
+			cr:            dbCluster(v1alpha1.ManagementPolicyAdopt, "", nil),
+			wantReason:    v1alpha1.ReasonUpToDate,
+			wantFinalizer: true,
+		},
+		{
+			name:          "Full refuses a cluster it only observed before",
+			cr:            dbCluster(v1alpha1.ManagementPolicyFull, v1alpha1.Available, nil),
+			wantErr:       true,
+			wantReason:    v1alpha1.ReasonOwnershipConflict,
+			wantFinalizer: true,
+		},
+		{
+			name: "Full releases the object on delete without deleting the cluster",
+			cr:   dbCluster(v1alpha1.ManagementPolicyFull, "", &metav1.Time{Time: time.Now()}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(testScheme, tt.cr, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "aws-provider-secret"},
+				Data: map[string][]byte{
+					"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+					"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+				},
+			}, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dbcluster-password"},
+				Data:       map[string][]byte{"password": []byte("test")},
+			})
+			r := &DBClusterReconciler{
+				Client: fakeClient,
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DeleteDBClusterErr:      aws2.ErrDBClusterDeletionProtectionEnabled{Message: "must not be called"},
+					IsDBClusterUpToDateResp: true,
+					DBStatusResp:            &v1alpha1.DBStatus{Exists: true, CurrentPhase: string(v1alpha1.Available)},
+				},
+			}
+			_, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := &v1alpha1.DBCluster{}
+			if errGetting := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"}, got); errGetting != nil {
+				t.Fatalf("Get() error = %v", errGetting)
+			}
+			if gotFinalizer := len(got.GetFinalizers()) > 0; gotFinalizer != tt.wantFinalizer {
+				t.Errorf("finalizers = %v, wantFinalizer %v", got.GetFinalizers(), tt.wantFinalizer)
+			}
+			if tt.wantReason == "" {
+				return
+			}
+			if synced := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionSynced); synced == nil || synced.Reason != tt.wantReason {
+				t.Errorf("Synced condition = %+v, want reason %v", synced, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDBClusterReconciler_KeepsProviderDefaultsOutOfSpec(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	fakeClient := fake.NewFakeClientWithScheme(testScheme, &v1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-prod"},
+		Spec: v1alpha1.ProviderSpec{
+			Type:        "aws",
+			Region:      "us-east-1",
+			Credentials: v1alpha1.ProviderCredentials{Source: v1alpha1.CredentialsSourceInjectedIdentity},
+			DefaultTags: map[string]string{"environment": "prod"},
+		},
+	}, &v1alpha1.DBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-db-cluster", Namespace: "default"},
+		Spec: v1alpha1.DBClusterSpec{
+			Provider:       v1alpha1.ProviderConfig{Name: "aws-prod"},
+			Engine:         "aurora-mysql",
+			MasterUsername: "test",
+			PasswordRef: &v1alpha1.PasswordRef{
+				PasswordKey: "password",
+				SecretRef:   &v1.LocalObjectReference{Name: "dbcluster-password"},
+			},
+			ManagementPolicy: v1alpha1.ManagementPolicyAdopt,
+			BackfillSpec:     true,
+		},
+	}, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dbcluster-password"},
+		Data:       map[string][]byte{"password": []byte("test")},
+	})
+	r := &DBClusterReconciler{
+		Client: fakeClient,
+		Log:    logf.Log,
+		Scheme: testScheme,
+		CloudDBInterface: &factory.MockCloudDB{
+			IsDBClusterUpToDateResp: true,
+			DBStatusResp:            &v1alpha1.DBStatus{Exists: true, CurrentPhase: string(v1alpha1.Available)},
+		},
+	}
+	// adopting with backfillSpec writes the spec back, it must not carry the region and tags of the provider
+	req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"}}
+	if _, err := r.Reconcile(context.TODO(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &v1alpha1.DBCluster{}
+	if err := fakeClient.Get(context.TODO(), req.NamespacedName, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Spec.Region != "" || len(got.Spec.Tags) != 0 {
+		t.Errorf("spec region = %q, tags = %v, want the provider defaults left out of the spec", got.Spec.Region, got.Spec.Tags)
+	}
+}
+
+func TestDBClusterReconciler_ExistingFinalSnapshotRecord(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	tests := []struct {
+		name          string
+		recordedID    string
+		wantErr       bool
+		wantFinalizer bool
+	}{
+		{
+			name:       "a record of the final snapshot is kept and the object released",
+			recordedID: "aws-db-cluster-final-1600000000",
+		},
+		{
+			name:          "a record of another snapshot is a conflict",
+			recordedID:    "nightly",
+			wantErr:       true,
+			wantFinalizer: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "aws-db-cluster",
+					Namespace:         "default",
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					Finalizers:        []string{dbClusterFinalizer},
+				},
+				Spec: v1alpha1.DBClusterSpec{
+					Provider: v1alpha1.ProviderConfig{
+						Type:      "aws",
+						SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+					},
+					Region:              "us-east-1",
+					Engine:              "aurora-mysql",
+					MasterUsername:      "test",
+					DeletionPolicy:      v1alpha1.DeletionPolicySnapshot,
+					FinalSnapshotRecord: true,
+				},
+				Status: v1alpha1.DBClusterStatus{
+					Phase:                   v1alpha1.Deleting,
+					FinalSnapshotIdentifier: "aws-db-cluster-final-1600000000",
+				},
+			}, &v1alpha1.DBClusterSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-db-cluster-final", Namespace: "default"},
+				Spec:       v1alpha1.DBClusterSnapshotSpec{SnapshotIdentifierOverride: tt.recordedID},
+				Status:     v1alpha1.DBClusterSnapshotStatus{Phase: v1alpha1.Available, SnapshotIdentifier: tt.recordedID},
+			}, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "aws-provider-secret"},
+				Data: map[string][]byte{
+					"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+					"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+				},
+			})
+			r := &DBClusterReconciler{
+				Client: fakeClient,
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					DBStatusResp:      &v1alpha1.DBStatus{},
+					SnapshotStateResp: &v1alpha1.SnapshotState{Exists: true, CurrentPhase: string(v1alpha1.Available)},
+				},
+			}
+			_, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := &v1alpha1.DBCluster{}
+			if errGetting := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"}, got); errGetting != nil {
+				t.Fatalf("Get() error = %v", errGetting)
+			}
+			if gotFinalizer := len(got.GetFinalizers()) > 0; gotFinalizer != tt.wantFinalizer {
+				t.Errorf("finalizers = %v, wantFinalizer %v", got.GetFinalizers(), tt.wantFinalizer)
+			}
+			record := &v1alpha1.DBClusterSnapshot{}
+			if errGetting := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "aws-db-cluster-final"}, record); errGetting != nil {
+				t.Fatalf("Get() error = %v", errGetting)
+			}
+			if record.GetDBClusterSnapshotID() != tt.recordedID {
+				t.Errorf("record points at %v, want it untouched at %v", record.GetDBClusterSnapshotID(), tt.recordedID)
+			}
+		})
+	}
+}
+
+func TestDBClusterReconciler_ReplicaKeepsPassword(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	tests := []struct {
+		name                    string
+		globalClusterIdentifier string
+		replicaSourceIdentifier string
+	}{
+		{
+			name:                    "a replica cluster",
+			replicaSourceIdentifier: "arn:aws:rds:us-west-2:123456789012:cluster:primary",
+		},
+		{
+			name:                    "a global database secondary",
+			globalClusterIdentifier: "global-db",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewFakeClientWithScheme(testScheme, &v1alpha1.DBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "aws-db-cluster",
+					Namespace:  "default",
+					Finalizers: []string{dbClusterFinalizer},
+				},
+				Spec: v1alpha1.DBClusterSpec{
+					Provider: v1alpha1.ProviderConfig{
+						Type:      "aws",
+						SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+					},
+					Region:         "us-east-1",
+					Engine:         "aurora-mysql",
+					MasterUsername: "test",
+					PasswordRef: &v1alpha1.PasswordRef{
+						PasswordKey: "password",
+						SecretRef:   &v1.LocalObjectReference{Name: "dbcluster-password"},
+					},
+					GlobalClusterIdentifier: tt.globalClusterIdentifier,
+				},
+				Status: v1alpha1.DBClusterStatus{
+					Phase:        v1alpha1.Available,
+					PasswordHash: "hash-of-the-previous-password",
+				},
+			}, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "aws-provider-secret"},
+				Data: map[string][]byte{
+					"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+					"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+				},
+			}, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dbcluster-password"},
+				Data:       map[string][]byte{"password": []byte("rotated")},
+			})
+			r := &DBClusterReconciler{
+				Client: fakeClient,
+				Log:    logf.Log,
+				Scheme: testScheme,
+				CloudDBInterface: &factory.MockCloudDB{
+					UpdateDBClusterPasswordErr: aws2.ErrRequeueNeeded{Message: "must not be called"},
+					IsDBClusterUpToDateResp:    true,
+					DBStatusResp: &v1alpha1.DBStatus{
+						Tags:                    map[string]string{v1alpha1.OwnerTagKey: "DBCluster/default/aws-db-cluster"},
+						Exists:                  true,
+						CurrentPhase:            string(v1alpha1.Available),
+						ReplicaSourceIdentifier: tt.replicaSourceIdentifier,
+					},
+				},
+			}
+			if _, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"},
+			}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			got := &v1alpha1.DBCluster{}
+			if errGetting := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "aws-db-cluster"}, got); errGetting != nil {
+				t.Fatalf("Get() error = %v", errGetting)
+			}
+			if want := utils.PasswordHash(got, "rotated"); got.Status.PasswordHash != want {
+				t.Errorf("status.passwordHash = %v, want the hash of the current password %v", got.Status.PasswordHash, want)
+			}
+			if rotated := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionPasswordRotated); rotated != nil {
+				t.Errorf("PasswordRotated condition = %v, want none", rotated)
 			}
 		})
 	}
@@ -0,0 +1,211 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1 "github.com/agill17/db-operator/api/v1alpha1"
+)
+
+// DBClusterParameterGroupReconciler reconciles a DBClusterParameterGroup object
+type DBClusterParameterGroupReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// CloudDBInterface replaces the client built from the provider of every object, only tests set it
+	CloudDBInterface factory.CloudDB
+}
+
+var (
+	dbClusterParameterGroupFinalizer = fmt.Sprintf("%s/%s-dbclusterparametergroup", groupName, groupVersion)
+)
+
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbclusterparametergroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbclusterparametergroups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbclusterparametergroups/finalizers,verbs=update
+// Reconcile creates the cluster parameter group, keeps its parameters in sync with the spec and reports the
+// parameters waiting on a reboot of the instances of the db clusters using it.
+func (r *DBClusterParameterGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("dbclusterparametergroup", req.NamespacedName)
+	namespacedName := req.NamespacedName.String()
+	cr := &v1alpha1.DBClusterParameterGroup{}
+	if errGettingCr := r.Client.Get(context.TODO(), req.NamespacedName, cr); errGettingCr != nil {
+		if errors.IsNotFound(errGettingCr) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errGettingCr
+	}
+
+	// add finalizer
+	if errAddingFinalizer := utils.AddFinalizer(dbClusterParameterGroupFinalizer, r.Client, cr); errAddingFinalizer != nil {
+		return ctrl.Result{}, errAddingFinalizer
+	}
+
+	// resolve the provider, its region and default tags are passed to the client
+	providerOptions, errResolvingProvider := resolveProvider(cr.Spec.Provider, cr.Spec.Region, r.Client)
+	if errResolvingProvider != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderSecretError, errResolvingProvider, cr, r.Client)
+	}
+
+	// reject what the provider does not support before calling it, a delete still goes through
+	if cr.GetDeletionTimestamp() == nil {
+		if errValidating := factory.ValidateFeatures(providerOptions.Type, v1alpha1.FeatureClusters,
+			v1alpha1.FeatureParameterGroups); errValidating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUnsupportedFeature,
+				errValidating, cr, r.Client)
+		}
+	}
+
+	// create the cloud client of the resolved provider, objects of one kind can use different providers
+	cloudDB, errCreatingClient := cloudDBFor(r.CloudDBInterface, r.Log, providerOptions)
+	if errCreatingClient != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderClientError, errCreatingClient, cr, r.Client)
+	}
+
+	// get cluster parameter group status
+	parameterGroupName := cr.GetDBClusterParameterGroupName()
+	state, err := cloudDB.DBClusterParameterGroupExists(parameterGroupName)
+	if err != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, err, cr, r.Client)
+	}
+	setCloudResourceConditions(state.Exists, string(v1alpha1.Available), cr)
+	cr.Status.Arn = state.Arn
+
+	// handle delete, the cloud provider refuses it while a db cluster still uses the cluster parameter group
+	if cr.GetDeletionTimestamp() != nil {
+		r.Log.Info(fmt.Sprintf("%v - is marked for deletion", namespacedName))
+		setDeletingConditions(cr)
+		if errUpdatingPhase := utils.UpdateStatusPhase(v1alpha1.Deleting, cr, r.Client); errUpdatingPhase != nil {
+			return ctrl.Result{}, errUpdatingPhase
+		}
+		if state.Exists {
+			if errDeleting := cloudDB.DeleteDBClusterParameterGroup(parameterGroupName); errDeleting != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errDeleting, cr, r.Client)
+			}
+		}
+		if errRemovingFinalizer := utils.RemoveFinalizer(dbClusterParameterGroupFinalizer, r.Client, cr); errRemovingFinalizer != nil {
+			return ctrl.Result{}, errRemovingFinalizer
+		}
+		r.Log.Info(fmt.Sprintf("%v - deleted successfully", namespacedName))
+		return ctrl.Result{}, nil
+	}
+
+	// create, the parameters are set by the next reconcile
+	if !state.Exists {
+		if errCreating := cloudDB.CreateDBClusterParameterGroup(cr); errCreating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonCreateFailed, errCreating, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - cluster parameter group does not exist, creating now.", namespacedName))
+		cr.Status.DBClusterParameterGroupName = parameterGroupName
+		setInProgressConditions(v1alpha1.ReasonCreating, "create requested", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
+	}
+	cr.Status.DBClusterParameterGroupName = parameterGroupName
+
+	if state.Family != cr.Spec.Family {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonInvalidSpec,
+			fmt.Errorf("family cannot be changed from %s to %s", state.Family, cr.Spec.Family), cr, r.Client)
+	}
+
+	// update
+	modify, reset := state.ParameterGroupChanges(cr.Spec.Parameters)
+	if len(modify) > 0 || len(reset) > 0 {
+		if len(modify) > 0 {
+			if errModifying := cloudDB.ModifyDBClusterParameterGroup(parameterGroupName, modify); errModifying != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUpdateFailed, errModifying, cr, r.Client)
+			}
+		}
+		if len(reset) > 0 {
+			if errResetting := cloudDB.ResetDBClusterParameterGroup(parameterGroupName, reset); errResetting != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUpdateFailed, errResetting, cr, r.Client)
+			}
+		}
+		r.Log.Info(fmt.Sprintf("%s - %d parameters modified and %d reset.", namespacedName, len(modify), len(reset)))
+		cr.Status.PendingRebootParameters = v1alpha1.PendingRebootParameters(cr.Status.PendingRebootParameters, modify, reset)
+		setInProgressConditions(v1alpha1.ReasonUpdating, "parameters update requested", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+	}
+
+	// the referencing db clusters report the new apply status only after they are requeued by the phase
+	// going back to available, so pending parameters are cleared from the reconciles after that one
+	if len(cr.Status.PendingRebootParameters) > 0 && cr.Status.Phase == v1alpha1.Available {
+		applyStatuses, errListing := r.referencingApplyStatuses(cr)
+		if errListing != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, errListing, cr, r.Client)
+		}
+		if parameterGroupInSync(applyStatuses) {
+			cr.Status.PendingRebootParameters = nil
+		}
+	}
+
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue,
+		v1alpha1.ReasonUpToDate, "cloud resource matches the spec", cr)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonAvailable,
+		fmt.Sprintf("cluster parameter group %s is available, %d parameters pending reboot", parameterGroupName,
+			len(cr.Status.PendingRebootParameters)), cr)
+	if errUpdatingStatus := utils.UpdateStatusPhase(v1alpha1.Available, cr, r.Client); errUpdatingStatus != nil {
+		return ctrl.Result{}, errUpdatingStatus
+	}
+	r.Log.Info(fmt.Sprintf("%s - reconciled", namespacedName))
+	return ctrl.Result{}, nil
+}
+
+// referencingApplyStatuses returns the cluster parameter group apply status of every db cluster referencing cr
+func (r *DBClusterParameterGroupReconciler) referencingApplyStatuses(cr *v1alpha1.DBClusterParameterGroup) ([]string, error) {
+	dbClusters := &v1alpha1.DBClusterList{}
+	if err := r.Client.List(context.TODO(), dbClusters, client.InNamespace(cr.GetNamespace())); err != nil {
+		return nil, err
+	}
+	var applyStatuses []string
+	for i := range dbClusters.Items {
+		dbCluster := &dbClusters.Items[i]
+		if ref := dbCluster.Spec.DBClusterParameterGroupRef; ref != nil && ref.Name == cr.GetName() {
+			applyStatuses = append(applyStatuses, dbClusterParameterApplyStatus(dbCluster))
+		}
+	}
+	return applyStatuses, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBClusterParameterGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBClusterParameterGroup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&source.Kind{Type: &v1alpha1.DBCluster{}},
+			handler.EnqueueRequestsFromMapFunc(referencedParameterGroupRequest(dbClusterParameterGroupRef)),
+			builder.WithPredicates(parameterApplyStatusChangedPredicate(dbClusterParameterApplyStatus)),
+		).
+		Complete(r)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1 "github.com/agill17/db-operator/api/v1alpha1"
+)
+
+// DBClusterSnapshotReconciler reconciles a DBSnapshot object
+type DBClusterSnapshotReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// CloudDBInterface replaces the client built from the provider of every object, only tests set it
+	CloudDBInterface factory.CloudDB
+}
+
+var (
+	dbClusterSnapshotFinalizer = fmt.Sprintf("%s/%s-dbclustersnapshot", groupName, groupVersion)
+)
+
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbclustersnapshots,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbclustersnapshots/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbclustersnapshots/finalizers,verbs=update
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *DBClusterSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("dbclustersnapshot", req.NamespacedName)
+	namespacedName := req.NamespacedName.String()
+	cr := &v1alpha1.DBClusterSnapshot{}
+	if errGettingCr := r.Client.Get(context.TODO(), req.NamespacedName, cr); errGettingCr != nil {
+		if errors.IsNotFound(errGettingCr) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errGettingCr
+	}
+
+	// add finalizer
+	if errAddingFinalizer := utils.AddFinalizer(dbClusterSnapshotFinalizer, r.Client, cr); errAddingFinalizer != nil {
+		return ctrl.Result{}, errAddingFinalizer
+	}
+
+	// copy provider and region from the source dbcluster, it may be gone by the time this snapshot is deleted
+	if cr.Status.Provider == nil {
+		source, errGettingSource := r.getSourceDBCluster(cr)
+		if errGettingSource != nil {
+			if errors.IsNotFound(errGettingSource) && cr.GetDeletionTimestamp() != nil {
+				// nothing was ever requested in the cloud
+				return ctrl.Result{}, utils.RemoveFinalizer(dbClusterSnapshotFinalizer, r.Client, cr)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound,
+				errGettingSource, cr, r.Client)
+		}
+		cr.Status.Provider = source.Spec.Provider.DeepCopy()
+		cr.Status.Region = source.Spec.Region
+	}
+
+	// resolve the provider, the region is recorded so the snapshot keeps it if the provider default changes
+	providerOptions, errResolvingProvider := resolveProvider(*cr.Status.Provider, cr.Status.Region, r.Client)
+	if errResolvingProvider != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderSecretError, errResolvingProvider, cr, r.Client)
+	}
+	cr.Status.Region = providerOptions.Region
+
+	// reject what the provider does not support before calling it, a delete still goes through
+	if cr.GetDeletionTimestamp() == nil {
+		if errValidating := factory.ValidateFeatures(providerOptions.Type, v1alpha1.FeatureClusters, v1alpha1.FeatureSnapshots); errValidating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUnsupportedFeature,
+				errValidating, cr, r.Client)
+		}
+	}
+
+	// create the cloud client of the resolved provider, objects of one kind can use different providers
+	cloudDB, errCreatingClient := cloudDBFor(r.CloudDBInterface, r.Log, providerOptions)
+	if errCreatingClient != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderClientError, errCreatingClient, cr, r.Client)
+	}
+
+	// get snapshot status
+	snapshotID := cr.GetDBClusterSnapshotID()
+	snapshotState, err := cloudDB.DBClusterSnapshotExists(snapshotID)
+	if err != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, err, cr, r.Client)
+	}
+	cr.Status.Snapshot = snapshotState.ToCloudSnapshotStatus(snapshotID)
+	setCloudResourceConditions(snapshotState.Exists, snapshotState.CurrentPhase, cr)
+
+	// a snapshot cannot be deleted while it is being created, failed snapshots can be deleted right away
+	if snapshotState.Exists && snapshotState.CurrentPhase != string(v1alpha1.Available) &&
+		snapshotState.CurrentPhase != snapshotFailedPhase {
+		r.Log.Info(fmt.Sprintf("%s - cluster snapshot exists but not yet available. Current status: %s, progress: %d%%",
+			namespacedName, snapshotState.CurrentPhase, snapshotState.PercentProgress))
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+	}
+
+	// handle delete
+	if cr.GetDeletionTimestamp() != nil {
+		r.Log.Info(fmt.Sprintf("%v - is marked for deletion", namespacedName))
+		setDeletingConditions(cr)
+		if errUpdatingPhase := utils.UpdateStatusPhase(v1alpha1.Deleting, cr, r.Client); errUpdatingPhase != nil {
+			return ctrl.Result{}, errUpdatingPhase
+		}
+		if snapshotState.Exists && cr.Spec.DeletionPolicy != v1alpha1.SnapshotDeletionPolicyRetain {
+			if errDeleting := cloudDB.DeleteDBClusterSnapshot(snapshotID); errDeleting != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errDeleting, cr, r.Client)
+			}
+		}
+		if errRemovingFinalizer := utils.RemoveFinalizer(dbClusterSnapshotFinalizer, r.Client, cr); errRemovingFinalizer != nil {
+			return ctrl.Result{}, errRemovingFinalizer
+		}
+		r.Log.Info(fmt.Sprintf("%v - deleted successfully", namespacedName))
+		return ctrl.Result{}, nil
+	}
+
+	// create
+	if !snapshotState.Exists {
+		// a snapshot is a point in time copy, do not take a new one when it was deleted outside of the operator
+		if cr.Status.SnapshotIdentifier != "" && cr.Status.Phase == v1alpha1.Available {
+			r.Log.Info(fmt.Sprintf("%s - snapshot %s no longer exists in the cloud provider", namespacedName, snapshotID))
+			return ctrl.Result{}, utils.UpdateStatus(cr, r.Client)
+		}
+		source, errGettingSource := r.getSourceDBCluster(cr)
+		if errGettingSource != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound,
+				errGettingSource, cr, r.Client)
+		}
+		if source.Status.Phase != v1alpha1.Available {
+			r.Log.Info(fmt.Sprintf("%s - waiting for dbcluster %s to become available", namespacedName, source.GetName()))
+			utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable,
+				fmt.Sprintf("dbcluster %s is not available", source.GetName()), cr)
+			return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+		}
+		if errCreating := cloudDB.CreateDBClusterSnapshot(cr, source.GetDBClusterID()); errCreating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonCreateFailed, errCreating, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - cluster snapshot does not exist, creating now.", namespacedName))
+		cr.Status.SnapshotIdentifier = snapshotID
+		setInProgressConditions(v1alpha1.ReasonCreating, "create requested", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
+	}
+
+	if snapshotState.CurrentPhase == snapshotFailedPhase {
+		utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, snapshotFailedPhase,
+			fmt.Sprintf("snapshot %s failed", snapshotID), cr)
+		return ctrl.Result{}, utils.UpdateStatus(cr, r.Client)
+	}
+
+	cr.Status.SnapshotIdentifier = snapshotID
+	setSnapshotAvailableConditions(snapshotID, cr)
+	if errUpdatingStatus := utils.UpdateStatusPhase(v1alpha1.Available, cr, r.Client); errUpdatingStatus != nil {
+		return ctrl.Result{}, errUpdatingStatus
+	}
+	r.Log.Info(fmt.Sprintf("%s - reconciled", namespacedName))
+	return ctrl.Result{}, nil
+}
+
+func (r *DBClusterSnapshotReconciler) getSourceDBCluster(cr *v1alpha1.DBClusterSnapshot) (*v1alpha1.DBCluster, error) {
+	source := &v1alpha1.DBCluster{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.Spec.DBClusterName}, source)
+	return source, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBClusterSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBClusterSnapshot{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -23,9 +23,12 @@ import (
 	"github.com/agill17/db-operator/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -39,8 +42,9 @@ import (
 // DBInstanceReconciler reconciles a DBInstance object
 type DBInstanceReconciler struct {
 	client.Client
-	Log              logr.Logger
-	Scheme           *runtime.Scheme
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// CloudDBInterface replaces the client built from the provider of every object, only tests set it
 	CloudDBInterface factory.CloudDB
 }
 
@@ -51,6 +55,10 @@ var (
 //+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbinstances,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbinstances/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbinstances/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;delete
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *DBInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -69,46 +77,119 @@ func (r *DBInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, errAddingFinalizer
 	}
 
-	// get provider secret
-	providerSecret, errGettngSecret := utils.GetSecret(cr.Spec.Provider.SecretRef.Name, cr.Spec.Provider.SecretRef.Namespace, r.Client)
-	if errGettngSecret != nil {
-		return ctrl.Result{}, errGettngSecret
+	// resolve the provider, its region and default tags are passed to the client
+	providerOptions, errResolvingProvider := resolveProvider(cr.Spec.Provider, cr.Spec.Region, r.Client)
+	if errResolvingProvider != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderSecretError, errResolvingProvider, cr, r.Client)
 	}
 
-	// create cloud client(s)
-	if r.CloudDBInterface == nil {
-		cloudDBInterface, err := factory.NewCloudDB(r.Log, cr.Spec.Provider.Type, providerSecret, cr.Spec.Region)
-		if err != nil {
-			return ctrl.Result{}, err
+	// reject what the provider does not support before calling it, a delete still goes through
+	if cr.GetDeletionTimestamp() == nil {
+		if errValidating := factory.ValidateFeatures(providerOptions.Type, cr.RequiredFeatures()...); errValidating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUnsupportedFeature,
+				errValidating, cr, r.Client)
 		}
-		r.CloudDBInterface = cloudDBInterface
+	}
+
+	// create the cloud client of the resolved provider, objects of one kind can use different providers
+	cloudDB, errCreatingClient := cloudDBFor(r.CloudDBInterface, r.Log, providerOptions)
+	if errCreatingClient != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderClientError, errCreatingClient, cr, r.Client)
 	}
 
 	// get instance status
-	instanceStatus, err := r.CloudDBInterface.DBInstanceExists(cr)
+	instanceStatus, err := cloudDB.DBInstanceExists(cr)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, err, cr, r.Client)
+	}
+	cr.Status.CloudResource = instanceStatus.ToCloudResourceStatus()
+	cr.Status.Replication = instanceStatus.ToReplicationStatus()
+	setCloudResourceConditions(instanceStatus.Exists, instanceStatus.CurrentPhase, cr)
+	if cr.IsObserveOnly() {
+		return observeOnly(instanceStatus, dbInstanceFinalizer, cr, r.Client)
+	}
+
+	// never touch an instance owned by another object, not even on delete
+	owner := cloudResourceOwner(instanceStatus)
+	if owner != "" && owner != cr.GetOwnerTagValue() {
+		if cr.GetDeletionTimestamp() != nil {
+			return ctrl.Result{}, utils.RemoveFinalizer(dbInstanceFinalizer, r.Client, cr)
+		}
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonOwnershipConflict,
+			ownershipConflictError(cr.GetDBInstanceID(), owner), cr, r.Client)
+	}
+	// an existing instance nobody owns is only taken over with managementPolicy Adopt
+	if instanceStatus.Exists && owner == "" && cr.Spec.ManagementPolicy != v1alpha1.ManagementPolicyAdopt {
+		if cr.GetDeletionTimestamp() != nil {
+			return ctrl.Result{}, utils.RemoveFinalizer(dbInstanceFinalizer, r.Client, cr)
+		}
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonOwnershipConflict,
+			untaggedResourceError(cr.GetDBInstanceID()), cr, r.Client)
+	}
+	if cr.GetDeletionTimestamp() != nil && cr.GetDeletionPolicy().KeepsCloudResource() {
+		r.Log.Info(fmt.Sprintf("%s - deletionPolicy is %s, leaving the instance in place", namespacedName, cr.GetDeletionPolicy()))
+		if errReleasing := releaseSecrets(cr, r.Client); errReleasing != nil {
+			return ctrl.Result{}, errReleasing
+		}
+		return ctrl.Result{}, utils.RemoveFinalizer(dbInstanceFinalizer, r.Client, cr)
 	}
 
 	if instanceStatus.Exists && instanceStatus.CurrentPhase != string(v1alpha1.Available) {
 		r.Log.Info(fmt.Sprintf("%s - exists but not yet available. Current status: %s", namespacedName, instanceStatus.CurrentPhase))
-		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
 	}
 
 	// handle delete
 	if cr.GetDeletionTimestamp() != nil {
 		r.Log.Info(fmt.Sprintf("%v - is marked for deletion", namespacedName))
+		setDeletingConditions(cr)
+		if errUpdatingPhase := utils.UpdateStatusPhase(v1alpha1.Deleting, cr, r.Client); errUpdatingPhase != nil {
+			return ctrl.Result{}, errUpdatingPhase
+		}
 
-		if instanceStatus.Exists {
+		// an instance that was never adopted is left alone
+		if instanceStatus.Exists && (owner != "" || cr.Spec.ManagementPolicy != v1alpha1.ManagementPolicyAdopt) {
 			// if part of dbcluster, wait for dbcluster to delete first
 			hasDBClusterFinalizer, _ := utils.ListContainsString(cr.GetFinalizers(), dbClusterFinalizer)
 			if hasDBClusterFinalizer {
 				r.Log.Info(fmt.Sprintf("%s - is part of DBCluster, waiting for dbcluster to get deleted first", namespacedName))
 				return ctrl.Result{RequeueAfter: 30 * time.Second, Requeue: true}, nil
 			}
-			errDeleting := r.CloudDBInterface.DeleteDBInstance(cr)
+			// instances of a db cluster and read replicas have no final snapshot of their own
+			if cr.GetDeletionPolicy() == v1alpha1.DeletionPolicySnapshot && cr.Spec.DBClusterID == "" &&
+				!cr.IsReadReplica() && cr.Status.FinalSnapshotIdentifier == "" {
+				cr.Status.FinalSnapshotIdentifier = finalSnapshotID(cr.GetDBInstanceID())
+				if errUpdatingStatus := utils.UpdateStatus(cr, r.Client); errUpdatingStatus != nil {
+					return ctrl.Result{}, errUpdatingStatus
+				}
+			}
+			errDeleting := cloudDB.DeleteDBInstance(cr)
 			if errDeleting != nil {
-				return ctrl.Result{}, errDeleting
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errDeleting, cr, r.Client)
+			}
+			if cr.Status.FinalSnapshotIdentifier != "" {
+				r.Log.Info(fmt.Sprintf("%s - delete requested with final snapshot %s", namespacedName, cr.Status.FinalSnapshotIdentifier))
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+			}
+		}
+
+		// release the object only once the final snapshot is available
+		if finalSnapshotID := cr.Status.FinalSnapshotIdentifier; finalSnapshotID != "" && !instanceStatus.Exists {
+			snapshotState, errGettingSnapshot := cloudDB.DBSnapshotExists(finalSnapshotID)
+			if errGettingSnapshot != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, errGettingSnapshot, cr, r.Client)
+			}
+			if snapshotState.CurrentPhase != string(v1alpha1.Available) {
+				r.Log.Info(fmt.Sprintf("%s - waiting for final snapshot %s to become available", namespacedName, finalSnapshotID))
+				setFinalSnapshotPendingCondition(finalSnapshotID, snapshotState, cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			if cr.Spec.FinalSnapshotRecord {
+				if errRecording := r.recordFinalDBSnapshot(cr, providerOptions.Region); errRecording != nil {
+					return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errRecording, cr, r.Client)
+				}
 			}
 		}
 		if errRemovingFinalizer := utils.RemoveFinalizer(dbInstanceFinalizer, r.Client, cr); errRemovingFinalizer != nil {
@@ -118,48 +199,202 @@ func (r *DBInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	// get password
-	insPass := ""
-	if cr.Spec.DBClusterID == "" {
-		secretValue, _, err := utils.GetSecretValue(cr.Spec.PasswordRef.SecretRef.Name, cr.GetNamespace(), cr.Spec.PasswordRef.PasswordKey, r.Client)
+	// adopt policy never creates, existing instances are tagged as owned by this object
+	if !instanceStatus.Exists && cr.Spec.ManagementPolicy == v1alpha1.ManagementPolicyAdopt {
+		r.Log.Info(fmt.Sprintf("%s - managementPolicy is Adopt and %s does not exist", namespacedName, cr.GetDBInstanceID()))
+		utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionFalse, v1alpha1.ReasonNotFound,
+			fmt.Sprintf("managementPolicy is Adopt and %s does not exist", cr.GetDBInstanceID()), cr)
+		return ctrl.Result{RequeueAfter: time.Minute}, utils.UpdateStatus(cr, r.Client)
+	}
+	if instanceStatus.Exists && owner == "" {
+		backfilled, errAdopting := r.adoptDBInstance(cr, instanceStatus, cloudDB)
+		if errAdopting != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonAdoptFailed, errAdopting, cr, r.Client)
+		}
+		if backfilled {
+			return ctrl.Result{Requeue: true}, utils.UpdateStatus(cr, r.Client)
+		}
+	}
+
+	// promote a read replica when requested through the promote annotation or a DBAction
+	if instanceStatus.Exists && cr.IsPromoteRequested() {
+		return r.promoteDBInstance(cr, instanceStatus, cloudDB)
+	}
+
+	// get password, promoted replicas keep the password of their source
+	insPass, passHash := "", ""
+	passSecretName, passwordKey := cr.GetPasswordSecretNameAndKey()
+	if cr.Spec.ReplicaOf != nil {
+		replicaPass, err := r.replicaPassword(cr)
+		if err != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonPasswordSecretError, err, cr, r.Client)
+		}
+		insPass = replicaPass
+	} else if cr.Spec.DBClusterID == "" {
+		var secretValue string
+		var err error
+		if cr.IsPasswordGenerated() {
+			secretValue, _, err = utils.GetOrCreateGeneratedPassword(cr, passSecretName, passwordKey, cr.Spec.Engine, instanceStatus.Exists, r.Client, r.Scheme)
+		} else {
+			secretValue, _, err = utils.GetSecretValue(passSecretName, cr.GetNamespace(), passwordKey, r.Client)
+		}
 		if err != nil {
 			r.Log.Error(err, fmt.Sprintf("%s - could not get instance password from secret", namespacedName))
-			return ctrl.Result{}, err
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonPasswordSecretError, err, cr, r.Client)
 		}
 		insPass = secretValue
+		passHash = utils.PasswordHash(cr, secretValue)
+	}
+
+	// the referenced subnet group must be available before the instance is created
+	if !instanceStatus.Exists && cr.Spec.DBSubnetGroupRef != nil {
+		subnetGroupName, errResolving := resolveDBSubnetGroupRef(cr.GetNamespace(), cr.Spec.DBSubnetGroupRef, r.Client)
+		if errResolving != nil {
+			if _, ok := errResolving.(utils.ErrDBSubnetGroupNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%s - waiting for the subnet group to become available", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable, errResolving.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound, errResolving, cr, r.Client)
+		}
+		// read by the create, restore and read replica inputs, only the status is written back
+		cr.Spec.DBSubnetGroupName = subnetGroupName
+	}
+
+	// the referenced parameter group must exist before the instance is created or switched to it
+	if cr.Spec.DBParameterGroupRef != nil {
+		parameterGroupName, errResolving := resolveDBParameterGroupRef(cr.GetNamespace(), cr.Spec.DBParameterGroupRef, r.Client)
+		if errResolving != nil {
+			if _, ok := errResolving.(utils.ErrParameterGroupNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%s - waiting for the parameter group to be created", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable, errResolving.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound, errResolving, cr, r.Client)
+		}
+		// read by the create, restore, read replica and modify inputs, only the status is written back
+		cr.Spec.DBParameterGroupName = parameterGroupName
+	}
+
+	// the referenced option group must exist before the instance is created or switched to it
+	if cr.Spec.OptionGroupRef != nil {
+		optionGroupName, errResolving := resolveOptionGroupRef(cr.GetNamespace(), cr.Spec.OptionGroupRef, r.Client)
+		if errResolving != nil {
+			if _, ok := errResolving.(utils.ErrOptionGroupNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%s - waiting for the option group to be created", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable, errResolving.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound, errResolving, cr, r.Client)
+		}
+		// read by the create, restore, read replica and modify inputs, only the status is written back
+		cr.Spec.OptionGroupName = optionGroupName
+	}
+
+	// restore
+	if !instanceStatus.Exists && cr.Spec.RestoreFrom != nil && cr.Spec.DBClusterID == "" {
+		restoreMessage, errRestoring := r.restoreDBInstance(cr, cloudDB)
+		if errRestoring != nil {
+			if _, ok := errRestoring.(utils.ErrSnapshotNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%s - waiting for snapshot to become available before restoring", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable, errRestoring.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonRestoreFailed, errRestoring, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - instance does not exist, %s.", namespacedName, restoreMessage))
+		// the restored instance keeps the master password of its source, it is rotated
+		// to the password secret once the instance is available
+		setInProgressConditions(v1alpha1.ReasonRestoring, restoreMessage, cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
+	}
+
+	// create read replica
+	if !instanceStatus.Exists && cr.IsReadReplica() {
+		sourceID, sourceRegion, errResolving := r.resolveReplicaSource(cr, providerOptions.Region)
+		if errResolving != nil {
+			if _, ok := errResolving.(utils.ErrReplicaSourceNotAvailable); ok {
+				r.Log.Info(fmt.Sprintf("%s - waiting for the replica source to become available", namespacedName))
+				utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable, errResolving.Error(), cr)
+				return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+			}
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound, errResolving, cr, r.Client)
+		}
+		if errCreating := cloudDB.CreateDBInstanceReadReplica(cr, sourceID, sourceRegion); errCreating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonCreateFailed, errCreating, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - instance does not exist, creating read replica of %s.", namespacedName, sourceID))
+		setInProgressConditions(v1alpha1.ReasonCreating, fmt.Sprintf("read replica of %s requested", sourceID), cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
 	}
 
 	// create
 	if !instanceStatus.Exists {
-		errCreating := r.CloudDBInterface.CreateDBInstance(cr, insPass)
+		errCreating := cloudDB.CreateDBInstance(cr, insPass)
 		if errCreating != nil {
-			return ctrl.Result{}, errCreating
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonCreateFailed, errCreating, cr, r.Client)
 		}
 		r.Log.Info(fmt.Sprintf("%s - instance does not exist, creating now.", namespacedName))
+		cr.Status.PasswordHash = passHash
+		setInProgressConditions(v1alpha1.ReasonCreating, "create requested", cr)
 		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
 	}
 
+	// rotate the master password when the password in the secret changed since it was last applied, or
+	// after a restore. instances created before this was tracked only record the current hash.
+	if cr.Status.PasswordHash != passHash {
+		if (cr.Status.PasswordHash != "" || cr.Status.RestoredFrom != "") && cr.Spec.DBClusterID == "" &&
+			!cr.IsReadReplica() {
+			r.Log.Info(fmt.Sprintf("%s - password secret changed, rotating master password", namespacedName))
+			if errRotating := cloudDB.UpdateDBInstancePassword(cr, insPass); errRotating != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonRotationFailed, errRotating, cr, r.Client)
+			}
+			setPasswordRotatedCondition(passSecretName, cr)
+			cr.Status.PasswordHash = passHash
+			setInProgressConditions(v1alpha1.ReasonUpdating, "master password rotation requested", cr)
+			return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+		}
+		cr.Status.PasswordHash = passHash
+	}
+
 	// update
-	isUpToDate, modifyIn, errChecking := r.CloudDBInterface.IsDBInstanceUpToDate(cr)
+	isUpToDate, modifyIn, errChecking := cloudDB.IsDBInstanceUpToDate(cr)
 	if errChecking != nil {
 		r.Log.Error(errChecking, "Failed to check if dbinstance is up to date")
-		return ctrl.Result{}, errChecking
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, errChecking, cr, r.Client)
 	}
 	if !isUpToDate {
-		errUpdating := r.CloudDBInterface.ModifyDBInstance(modifyIn)
+		errUpdating := cloudDB.ModifyDBInstance(modifyIn)
 		if errUpdating != nil {
-			return ctrl.Result{}, errUpdating
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUpdateFailed, errUpdating, cr, r.Client)
 		}
 		r.Log.Info(fmt.Sprintf("%s - is not up to date, updating now.", namespacedName))
+		setInProgressConditions(v1alpha1.ReasonUpdating, "modify requested", cr)
 		return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
 	}
 
 	// create external name service
 	svcResult, svcName, errReconcilingSvc := createOrUpdateExternalNameSvc(cr, instanceStatus.Endpoint, r.Client, r.Scheme)
 	if errReconcilingSvc != nil {
-		return ctrl.Result{}, errReconcilingSvc
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionReady, v1alpha1.ReasonReconcileError, errReconcilingSvc, cr, r.Client)
 	}
 
+	if cr.Spec.ConnectionSecret != nil {
+		secretResult, errReconcilingSecret := createOrUpdateConnectionSecret(cr, cr.GetConnectionSecretName(), utils.ConnectionInfo{
+			Engine:   cr.Spec.Engine,
+			Host:     instanceStatus.Endpoint,
+			Port:     instanceStatus.Port,
+			Username: cr.Spec.MasterUsername,
+			Password: insPass,
+			DBName:   cr.Spec.DBName,
+		}, r.Client, r.Scheme)
+		if errReconcilingSecret != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionReady, v1alpha1.ReasonReconcileError, errReconcilingSecret, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - connection secret %s", cr.GetConnectionSecretName(), secretResult))
+	}
+
+	setAvailableConditions(instanceStatus, cr)
 	errUpdatingStatus := utils.UpdateStatusPhase(v1alpha1.Available, cr, r.Client)
 	if errUpdatingStatus != nil {
 		return ctrl.Result{}, errUpdatingStatus
@@ -169,12 +404,76 @@ func (r *DBInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// adoptDBInstance tags an existing instance as owned by cr and, when requested, fills the spec from the
+// live instance first. The spec is saved before the tag is written, a failed update leaves the instance
+// untagged so the next reconcile backfills again instead of modifying the instance towards a sparse spec.
+// It returns true when the spec was updated.
+func (r *DBInstanceReconciler) adoptDBInstance(cr *v1alpha1.DBInstance, instanceStatus *v1alpha1.DBStatus, cloudDB factory.CloudDB) (bool, error) {
+	backfill := cr.Spec.ManagementPolicy == v1alpha1.ManagementPolicyAdopt && cr.Spec.BackfillSpec
+	if backfill {
+		status := cr.Status.DeepCopy()
+		if err := cloudDB.BackfillDBInstanceSpec(cr); err != nil {
+			return false, err
+		}
+		if err := r.Client.Update(context.TODO(), cr); err != nil {
+			return false, err
+		}
+		cr.Status = *status
+	}
+	r.Log.Info(fmt.Sprintf("%s/%s - tagging dbinstance %s as owned", cr.GetNamespace(), cr.GetName(), cr.GetDBInstanceID()))
+	if err := tagOwner(instanceStatus, cr.GetOwnerTagValue(), cloudDB); err != nil {
+		return false, err
+	}
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue, v1alpha1.ReasonAdopted,
+		fmt.Sprintf("%s is owned by %s", cr.GetDBInstanceID(), cr.GetOwnerTagValue()), cr)
+	return backfill, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexSecretRefs(mgr, &v1alpha1.DBInstance{}, dbInstanceSecretRefs); err != nil {
+		return err
+	}
+	if err := indexProviderName(mgr, &v1alpha1.DBInstance{}, dbInstanceProviderName); err != nil {
+		return err
+	}
+	if err := indexParameterGroupRefs(mgr, &v1alpha1.DBInstance{}, dbParameterGroupRefIndexKey, dbInstanceParameterGroupRef); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.DBInstance{}, builder.WithPredicates(r.dbInstancePredicates())).
 		Owns(&v1.Service{}, builder.WithPredicates(predicate.Funcs{
 			CreateFunc: func(event event.CreateEvent) bool { return false },
 		})).
+		Owns(&v1.Secret{}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(event event.CreateEvent) bool { return false },
+		})).
+		Watches(
+			&source.Kind{Type: &v1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretsEventHandlerFunc(r.Client, r.Log, func() client.ObjectList {
+				return &v1alpha1.DBInstanceList{}
+			})),
+		).
+		Watches(
+			&source.Kind{Type: &v1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(providerSecretsEventHandlerFunc(r.Client, r.Log, func() client.ObjectList {
+				return &v1alpha1.DBInstanceList{}
+			})),
+		).
+		Watches(
+			&source.Kind{Type: &v1alpha1.Provider{}},
+			handler.EnqueueRequestsFromMapFunc(providerEventHandlerFunc(r.Client, r.Log, func() client.ObjectList {
+				return &v1alpha1.DBInstanceList{}
+			})),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&source.Kind{Type: &v1alpha1.DBParameterGroup{}},
+			handler.EnqueueRequestsFromMapFunc(parameterGroupEventHandlerFunc(r.Client, r.Log, dbParameterGroupRefIndexKey,
+				func() client.ObjectList {
+					return &v1alpha1.DBInstanceList{}
+				})),
+			builder.WithPredicates(parameterGroupChangedPredicate()),
+		).
 		Complete(r)
 }
@@ -8,7 +8,8 @@ import (
 func (r *DBInstanceReconciler) dbInstancePredicates() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(event event.UpdateEvent) bool {
-			return event.ObjectOld.GetGeneration() != event.ObjectNew.GetGeneration()
+			return event.ObjectOld.GetGeneration() != event.ObjectNew.GetGeneration() ||
+				promoteAnnotationChanged(event)
 		},
 	}
 }
@@ -0,0 +1,887 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDBInstanceReconciler_ReconcileMixedProviders(t *testing.T) {
+	testScheme := newDBInstanceTestScheme()
+
+	dbInstance := func(name string, provider v1alpha1.ProviderConfig) *v1alpha1.DBInstance {
+		return &v1alpha1.DBInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha1.DBInstanceSpec{
+				Provider:        provider,
+				Engine:          "postgres",
+				DBInstanceClass: "db.t3.micro",
+				MasterUsername:  "admin",
+				DeletionPolicy:  v1alpha1.DeletionPolicyDelete,
+			},
+		}
+	}
+	cloudProvider := &v1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloud"},
+		Spec: v1alpha1.ProviderSpec{
+			Type:        multiRegionProvider,
+			Region:      "eu-west-1",
+			Credentials: v1alpha1.ProviderCredentials{Source: v1alpha1.CredentialsSourceInjectedIdentity},
+		},
+	}
+	fakeClient := fake.NewFakeClientWithScheme(testScheme, cloudProvider,
+		dbInstance("dev", v1alpha1.ProviderConfig{Type: v1alpha1.Local}),
+		dbInstance("prod", v1alpha1.ProviderConfig{Name: "cloud"}))
+	r := &DBInstanceReconciler{
+		Client: fakeClient,
+		Log:    logf.Log.WithName("dbinstance-controller-test"),
+		Scheme: testScheme,
+	}
+	for _, name := range []string{"dev", "prod"} {
+		if _, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+			NamespacedName: types.NamespacedName{Name: name, Namespace: "default"},
+		}); err != nil {
+			t.Fatalf("Reconcile() of %s error = %v", name, err)
+		}
+	}
+
+	// dev runs as a statefulset of the local provider, prod only reached the cloud client
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := fakeClient.List(context.TODO(), statefulSets); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var gotStatefulSets []string
+	for _, sts := range statefulSets.Items {
+		gotStatefulSets = append(gotStatefulSets, sts.GetName())
+	}
+	if len(gotStatefulSets) != 1 {
+		t.Errorf("local provider created statefulsets %v, want one for dev", gotStatefulSets)
+	}
+	cloudClient, ok := multiRegionClients["provider/cloud/eu-west-1"]
+	if !ok {
+		t.Fatalf("no client was built for provider cloud")
+	}
+	if !reflect.DeepEqual(cloudClient.requested, []string{"prod"}) {
+		t.Errorf("cloud client was asked for %v, want [prod]", cloudClient.requested)
+	}
+	for _, name := range []string{"dev", "prod"} {
+		got := &v1alpha1.DBInstance{}
+		if err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, got); err != nil {
+			t.Fatalf("Get() of %s error = %v", name, err)
+		}
+		if got.Status.Phase != v1alpha1.Creating {
+			t.Errorf("%s phase = %v, want %v", name, got.Status.Phase, v1alpha1.Creating)
+		}
+	}
+}
+
+// recordingCloudDB records the calls that change a db instance, the rest is answered by the mock
+type recordingCloudDB struct {
+	factory.MockCloudDB
+	calls []string
+}
+
+func (c *recordingCloudDB) record(format string, args ...interface{}) {
+	c.calls = append(c.calls, fmt.Sprintf(format, args...))
+}
+
+func (c *recordingCloudDB) CreateDBInstance(input *v1alpha1.DBInstance, password string) error {
+	c.record("CreateDBInstance")
+	return c.CreateDBInstanceErr
+}
+
+func (c *recordingCloudDB) DeleteDBInstance(input *v1alpha1.DBInstance) error {
+	c.record("DeleteDBInstance %s", input.Status.FinalSnapshotIdentifier)
+	return c.DeleteDBInstanceErr
+}
+
+func (c *recordingCloudDB) UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error {
+	c.record("UpdateDBInstancePassword %s", password)
+	return c.UpdateDBInstancePasswordErr
+}
+
+func (c *recordingCloudDB) RestoreDBInstanceFromSnapshot(input *v1alpha1.DBInstance, snapshotID string) error {
+	c.record("RestoreDBInstanceFromSnapshot %s", snapshotID)
+	return c.RestoreDBInstanceErr
+}
+
+func (c *recordingCloudDB) RestoreDBInstanceToPointInTime(input *v1alpha1.DBInstance, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error) {
+	c.record("RestoreDBInstanceToPointInTime %s", pointInTime.SourceIdentifier)
+	return c.RestoreTimeResp, c.RestoreDBInstanceErr
+}
+
+func (c *recordingCloudDB) BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error {
+	c.record("BackfillDBInstanceSpec")
+	input.Spec.DBInstanceClass = "db.r5.large"
+	return c.BackfillDBInstanceSpecErr
+}
+
+func (c *recordingCloudDB) CreateDBInstanceReadReplica(input *v1alpha1.DBInstance, sourceID, sourceRegion string) error {
+	c.record("CreateDBInstanceReadReplica %s %s", sourceID, sourceRegion)
+	return c.CreateReadReplicaErr
+}
+
+func (c *recordingCloudDB) PromoteDBInstanceReadReplica(input *v1alpha1.DBInstance) error {
+	c.record("PromoteDBInstanceReadReplica")
+	return c.PromoteErr
+}
+
+func (c *recordingCloudDB) AddTagsToResource(arn string, tags map[string]string) error {
+	c.record("AddTagsToResource %s %s", arn, tags[v1alpha1.OwnerTagKey])
+	return c.AddTagsErr
+}
+
+func newDBInstanceTestScheme() *runtime.Scheme {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+	return testScheme
+}
+
+// newAWSDBInstance returns a DBInstance named app using the inline aws provider of newAWSProviderSecret
+func newAWSDBInstance() *v1alpha1.DBInstance {
+	return &v1alpha1.DBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: v1alpha1.DBInstanceSpec{
+			Provider: v1alpha1.ProviderConfig{
+				Type:      v1alpha1.AWS,
+				SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+			},
+			Region:          "us-east-1",
+			Engine:          "postgres",
+			DBInstanceClass: "db.t3.micro",
+			MasterUsername:  "admin",
+		},
+	}
+}
+
+func newAWSProviderSecret() *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-provider-secret", Namespace: "default"},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+			"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+		},
+	}
+}
+
+// generatedPasswordSecret is the generated password secret of cr, owned by cr
+func generatedPasswordSecret(cr *v1alpha1.DBInstance) *v1.Secret {
+	name, key := cr.GetPasswordSecretNameAndKey()
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cr.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cr, v1alpha1.GroupVersion.WithKind("DBInstance"))},
+		},
+		Data: map[string][]byte{key: []byte("generated")},
+	}
+}
+
+// ownedDBStatus is an available db instance tagged as owned by cr
+func ownedDBStatus(cr *v1alpha1.DBInstance) *v1alpha1.DBStatus {
+	return &v1alpha1.DBStatus{
+		Exists:       true,
+		CurrentPhase: string(v1alpha1.Available),
+		Arn:          "arn:aws:rds:us-east-1:123456789012:db:" + cr.GetDBInstanceID(),
+		Tags:         map[string]string{v1alpha1.OwnerTagKey: cr.GetOwnerTagValue()},
+	}
+}
+
+// reconcileDBInstance reconciles app once with cloudDB
+func reconcileDBInstance(cloudDB factory.CloudDB, objects ...runtime.Object) (client.Client, controllerruntime.Result, error) {
+	testScheme := newDBInstanceTestScheme()
+	fakeClient := fake.NewFakeClientWithScheme(testScheme, append(objects, newAWSProviderSecret())...)
+	r := &DBInstanceReconciler{
+		Client:           fakeClient,
+		Log:              logf.Log.WithName("dbinstance-controller-test"),
+		Scheme:           testScheme,
+		CloudDBInterface: cloudDB,
+	}
+	result, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+		NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"},
+	})
+	return fakeClient, result, err
+}
+
+func getDBInstance(t *testing.T, c client.Client) *v1alpha1.DBInstance {
+	got := &v1alpha1.DBInstance{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "app", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	return got
+}
+
+func TestDBInstanceReconciler_RotatesPassword(t *testing.T) {
+	newDBInstance := func() *v1alpha1.DBInstance {
+		cr := newAWSDBInstance()
+		cr.Spec.DeletionPolicy = v1alpha1.DeletionPolicyDelete
+		cr.Spec.PasswordRef = &v1alpha1.PasswordRef{
+			PasswordKey: "password",
+			SecretRef:   &v1.LocalObjectReference{Name: "app-password"},
+		}
+		cr.Status.Phase = v1alpha1.Available
+		return cr
+	}
+	tests := []struct {
+		name         string
+		appliedHash  string
+		restoredFrom string
+		secretLabels map[string]string
+		wantCalls    []string
+	}{
+		{
+			name:        "the password in the secret changed",
+			appliedHash: utils.PasswordHash(newDBInstance(), "old"),
+			wantCalls:   []string{"UpdateDBInstancePassword new"},
+		},
+		{
+			name:         "only the labels of the secret changed",
+			appliedHash:  utils.PasswordHash(newDBInstance(), "new"),
+			secretLabels: map[string]string{"team": "payments"},
+		},
+		{
+			name: "instances created before the hash was recorded only record it",
+		},
+		{
+			name:         "a restored instance gets the password of the secret",
+			restoredFrom: "app-snapshot",
+			wantCalls:    []string{"UpdateDBInstancePassword new"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newDBInstance()
+			cr.Status.PasswordHash = tt.appliedHash
+			cr.Status.RestoredFrom = tt.restoredFrom
+			cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{
+				IsDBInstanceUpToDateResp: true,
+				DBInstanceStatusResp:     ownedDBStatus(cr),
+			}}
+			fakeClient, _, err := reconcileDBInstance(cloudDB, cr, &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-password", Namespace: "default", Labels: tt.secretLabels},
+				Data:       map[string][]byte{"password": []byte("new")},
+			})
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			got := getDBInstance(t, fakeClient)
+
+			if !reflect.DeepEqual(cloudDB.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", cloudDB.calls, tt.wantCalls)
+			}
+			if want := utils.PasswordHash(got, "new"); got.Status.PasswordHash != want {
+				t.Errorf("status.passwordHash = %v, want %v", got.Status.PasswordHash, want)
+			}
+			if rotated := meta.IsStatusConditionTrue(got.Status.Conditions, v1alpha1.ConditionPasswordRotated); rotated != (tt.wantCalls != nil) {
+				t.Errorf("PasswordRotated condition = %v, want %v", rotated, tt.wantCalls != nil)
+			}
+		})
+	}
+}
+
+func TestDBInstanceReconciler_RestoresFromSnapshot(t *testing.T) {
+	snapshot := func(phase v1alpha1.Phase) *v1alpha1.DBSnapshot {
+		return &v1alpha1.DBSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Status:     v1alpha1.DBSnapshotStatus{Phase: phase, SnapshotIdentifier: "nightly-2021-06-01"},
+		}
+	}
+	tests := []struct {
+		name             string
+		restoreFrom      *v1alpha1.RestoreFrom
+		snapshot         *v1alpha1.DBSnapshot
+		wantCalls        []string
+		wantRestoredFrom string
+		wantReason       string
+	}{
+		{
+			name:             "from a snapshot identifier",
+			restoreFrom:      &v1alpha1.RestoreFrom{SnapshotIdentifier: "app-snapshot"},
+			wantCalls:        []string{"RestoreDBInstanceFromSnapshot app-snapshot"},
+			wantRestoredFrom: "app-snapshot",
+			wantReason:       v1alpha1.ReasonRestoring,
+		},
+		{
+			name:             "from an available DBSnapshot",
+			restoreFrom:      &v1alpha1.RestoreFrom{SnapshotRef: &v1.LocalObjectReference{Name: "nightly"}},
+			snapshot:         snapshot(v1alpha1.Available),
+			wantCalls:        []string{"RestoreDBInstanceFromSnapshot nightly-2021-06-01"},
+			wantRestoredFrom: "nightly-2021-06-01",
+			wantReason:       v1alpha1.ReasonRestoring,
+		},
+		{
+			name:        "waits for the DBSnapshot to become available",
+			restoreFrom: &v1alpha1.RestoreFrom{SnapshotRef: &v1.LocalObjectReference{Name: "nightly"}},
+			snapshot:    snapshot(v1alpha1.Creating),
+			wantReason:  v1alpha1.ReasonSourceNotAvailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newAWSDBInstance()
+			cr.Spec.RestoreFrom = tt.restoreFrom
+			objects := []runtime.Object{cr}
+			if tt.snapshot != nil {
+				objects = append(objects, tt.snapshot)
+			}
+			cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{DBInstanceStatusResp: &v1alpha1.DBStatus{}}}
+			fakeClient, result, err := reconcileDBInstance(cloudDB, objects...)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			got := getDBInstance(t, fakeClient)
+
+			if !result.Requeue {
+				t.Errorf("Reconcile() = %v, want a requeue", result)
+			}
+			if !reflect.DeepEqual(cloudDB.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", cloudDB.calls, tt.wantCalls)
+			}
+			if got.Status.RestoredFrom != tt.wantRestoredFrom {
+				t.Errorf("status.restoredFrom = %v, want %v", got.Status.RestoredFrom, tt.wantRestoredFrom)
+			}
+			if ready := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionReady); ready == nil || ready.Reason != tt.wantReason {
+				t.Errorf("Ready condition = %v, want reason %v", ready, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDBInstanceReconciler_RestoresToPointInTime(t *testing.T) {
+	restoreTime := metav1.NewTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	cr := newAWSDBInstance()
+	cr.Spec.RestoreFrom = &v1alpha1.RestoreFrom{PointInTime: &v1alpha1.PointInTime{
+		SourceIdentifier:        "app-source",
+		UseLatestRestorableTime: true,
+	}}
+	cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{
+		DBInstanceStatusResp: &v1alpha1.DBStatus{},
+		RestoreTimeResp:      &restoreTime,
+	}}
+	fakeClient, _, err := reconcileDBInstance(cloudDB, cr)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := getDBInstance(t, fakeClient)
+
+	if want := []string{"RestoreDBInstanceToPointInTime app-source"}; !reflect.DeepEqual(cloudDB.calls, want) {
+		t.Errorf("calls = %v, want %v", cloudDB.calls, want)
+	}
+	if got.Status.RestoredFrom != "app-source" {
+		t.Errorf("status.restoredFrom = %v, want app-source", got.Status.RestoredFrom)
+	}
+	if got.Status.RestoreTime == nil || !got.Status.RestoreTime.Equal(&restoreTime) {
+		t.Errorf("status.restoreTime = %v, want %v", got.Status.RestoreTime, restoreTime)
+	}
+	if got.Status.Phase != v1alpha1.Creating {
+		t.Errorf("phase = %v, want %v", got.Status.Phase, v1alpha1.Creating)
+	}
+}
+
+func TestDBInstanceReconciler_Adopt(t *testing.T) {
+	untagged := func(cr *v1alpha1.DBInstance) *v1alpha1.DBStatus {
+		dbStatus := ownedDBStatus(cr)
+		dbStatus.Tags = nil
+		return dbStatus
+	}
+	tests := []struct {
+		name             string
+		managementPolicy v1alpha1.ManagementPolicy
+		backfillSpec     bool
+		phase            v1alpha1.Phase
+		dbStatus         func(cr *v1alpha1.DBInstance) *v1alpha1.DBStatus
+		wantErr          bool
+		wantCalls        []string
+		wantReason       string
+		wantClass        string
+	}{
+		{
+			name:             "adopt tags an untagged instance as owned",
+			managementPolicy: v1alpha1.ManagementPolicyAdopt,
+			dbStatus:         untagged,
+			wantCalls:        []string{"AddTagsToResource arn:aws:rds:us-east-1:123456789012:db:default-app DBInstance/default/app"},
+			wantReason:       v1alpha1.ReasonUpToDate,
+			wantClass:        "db.t3.micro",
+		},
+		{
+			name:             "adopt with backfillSpec fills the spec from the instance",
+			managementPolicy: v1alpha1.ManagementPolicyAdopt,
+			backfillSpec:     true,
+			dbStatus:         untagged,
+			wantCalls: []string{"BackfillDBInstanceSpec",
+				"AddTagsToResource arn:aws:rds:us-east-1:123456789012:db:default-app DBInstance/default/app"},
+			wantReason: v1alpha1.ReasonAdopted,
+			wantClass:  "db.r5.large",
+		},
+		{
+			name:             "adopt never creates a missing instance",
+			managementPolicy: v1alpha1.ManagementPolicyAdopt,
+			dbStatus:         func(cr *v1alpha1.DBInstance) *v1alpha1.DBStatus { return &v1alpha1.DBStatus{} },
+			wantReason:       v1alpha1.ReasonNotFound,
+			wantClass:        "db.t3.micro",
+		},
+		{
+			name:       "full refuses an untagged instance it did not create",
+			dbStatus:   untagged,
+			wantErr:    true,
+			wantReason: v1alpha1.ReasonOwnershipConflict,
+			wantClass:  "db.t3.micro",
+		},
+		{
+			name:       "full refuses an untagged instance it only observed before",
+			phase:      v1alpha1.Available,
+			dbStatus:   untagged,
+			wantErr:    true,
+			wantReason: v1alpha1.ReasonOwnershipConflict,
+			wantClass:  "db.t3.micro",
+		},
+		{
+			name:             "an instance owned by another object is never adopted",
+			managementPolicy: v1alpha1.ManagementPolicyAdopt,
+			dbStatus: func(cr *v1alpha1.DBInstance) *v1alpha1.DBStatus {
+				dbStatus := ownedDBStatus(cr)
+				dbStatus.Tags[v1alpha1.OwnerTagKey] = "DBInstance/other/app"
+				return dbStatus
+			},
+			wantErr:    true,
+			wantReason: v1alpha1.ReasonOwnershipConflict,
+			wantClass:  "db.t3.micro",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newAWSDBInstance()
+			cr.Spec.ManagementPolicy = tt.managementPolicy
+			cr.Spec.BackfillSpec = tt.backfillSpec
+			cr.Status.Phase = tt.phase
+			cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{
+				IsDBInstanceUpToDateResp: true,
+				DBInstanceStatusResp:     tt.dbStatus(cr),
+			}}
+			fakeClient, _, err := reconcileDBInstance(cloudDB, cr, generatedPasswordSecret(cr))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := getDBInstance(t, fakeClient)
+
+			if !reflect.DeepEqual(cloudDB.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", cloudDB.calls, tt.wantCalls)
+			}
+			if synced := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionSynced); synced == nil || synced.Reason != tt.wantReason {
+				t.Errorf("Synced condition = %v, want reason %v", synced, tt.wantReason)
+			}
+			if got.Spec.DBInstanceClass != tt.wantClass {
+				t.Errorf("spec.dbInstanceClass = %v, want %v", got.Spec.DBInstanceClass, tt.wantClass)
+			}
+		})
+	}
+}
+
+// conflictingUpdateClient fails every spec update with a resourceVersion conflict
+type conflictingUpdateClient struct {
+	client.Client
+}
+
+func (c conflictingUpdateClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return apierrors.NewConflict(v1alpha1.GroupVersion.WithResource("dbinstances").GroupResource(), obj.GetName(),
+		fmt.Errorf("the object has been modified"))
+}
+
+func TestDBInstanceReconciler_AdoptBackfillUpdateFails(t *testing.T) {
+	cr := newAWSDBInstance()
+	cr.Spec.ManagementPolicy = v1alpha1.ManagementPolicyAdopt
+	cr.Spec.BackfillSpec = true
+	cr.SetFinalizers([]string{dbInstanceFinalizer})
+	dbStatus := ownedDBStatus(cr)
+	dbStatus.Tags = nil
+	cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{DBInstanceStatusResp: dbStatus}}
+	testScheme := newDBInstanceTestScheme()
+	fakeClient := fake.NewFakeClientWithScheme(testScheme, cr, generatedPasswordSecret(cr), newAWSProviderSecret())
+	r := &DBInstanceReconciler{
+		Client:           conflictingUpdateClient{Client: fakeClient},
+		Log:              logf.Log.WithName("dbinstance-controller-test"),
+		Scheme:           testScheme,
+		CloudDBInterface: cloudDB,
+	}
+	if _, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+		NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"},
+	}); !apierrors.IsConflict(err) {
+		t.Fatalf("Reconcile() error = %v, want the update conflict", err)
+	}
+	// the instance stays untagged, the next reconcile backfills again instead of modifying the instance
+	if want := []string{"BackfillDBInstanceSpec"}; !reflect.DeepEqual(cloudDB.calls, want) {
+		t.Errorf("calls = %v, want %v", cloudDB.calls, want)
+	}
+	got := getDBInstance(t, fakeClient)
+	if synced := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionSynced); synced == nil || synced.Reason != v1alpha1.ReasonAdoptFailed {
+		t.Errorf("Synced condition = %v, want reason %v", synced, v1alpha1.ReasonAdoptFailed)
+	}
+}
+
+func TestDBInstanceReconciler_DeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		deletionPolicy   v1alpha1.DeletionPolicy
+		managementPolicy v1alpha1.ManagementPolicy
+		untagged         bool
+		wantCalls        []string
+		wantSecretsOwned bool
+	}{
+		{
+			name:             "delete deletes the instance",
+			deletionPolicy:   v1alpha1.DeletionPolicyDelete,
+			wantCalls:        []string{"DeleteDBInstance "},
+			wantSecretsOwned: true,
+		},
+		{
+			name:           "retain keeps the instance and releases its secrets",
+			deletionPolicy: v1alpha1.DeletionPolicyRetain,
+		},
+		{
+			name:           "orphan keeps the instance and releases its secrets",
+			deletionPolicy: v1alpha1.DeletionPolicyOrphan,
+		},
+		{
+			name:             "an instance that was never adopted is left alone",
+			deletionPolicy:   v1alpha1.DeletionPolicyDelete,
+			managementPolicy: v1alpha1.ManagementPolicyAdopt,
+			untagged:         true,
+			wantSecretsOwned: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newAWSDBInstance()
+			cr.UID = "app-uid"
+			cr.Finalizers = []string{dbInstanceFinalizer}
+			cr.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			cr.Spec.DeletionPolicy = tt.deletionPolicy
+			cr.Spec.ManagementPolicy = tt.managementPolicy
+			cr.Status.Phase = v1alpha1.Available
+			dbStatus := ownedDBStatus(cr)
+			if tt.untagged {
+				dbStatus.Tags = nil
+			}
+			connectionSecret := generatedPasswordSecret(cr)
+			connectionSecret.Name = cr.GetConnectionSecretName()
+			cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{DBInstanceStatusResp: dbStatus}}
+			fakeClient, _, err := reconcileDBInstance(cloudDB, cr, generatedPasswordSecret(cr), connectionSecret)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			got := getDBInstance(t, fakeClient)
+
+			if !reflect.DeepEqual(cloudDB.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", cloudDB.calls, tt.wantCalls)
+			}
+			if len(got.Finalizers) != 0 {
+				t.Errorf("finalizers = %v, want none", got.Finalizers)
+			}
+			passwordSecretName, _ := cr.GetPasswordSecretNameAndKey()
+			for _, name := range []string{passwordSecretName, cr.GetConnectionSecretName()} {
+				secret := &v1.Secret{}
+				if err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, secret); err != nil {
+					t.Fatalf("Get() of secret %s error = %v", name, err)
+				}
+				if owned := len(secret.OwnerReferences) > 0; owned != tt.wantSecretsOwned {
+					t.Errorf("secret %s owned = %v, want %v", name, owned, tt.wantSecretsOwned)
+				}
+			}
+		})
+	}
+}
+
+func TestDBInstanceReconciler_FinalSnapshot(t *testing.T) {
+	tests := []struct {
+		name            string
+		exists          bool
+		finalSnapshotID string
+		snapshotState   *v1alpha1.SnapshotState
+		existingRecord  string
+		wantErr         bool
+		wantCalls       []string
+		wantFinalizer   bool
+		wantReason      string
+		wantRecord      bool
+	}{
+		{
+			name:            "a retried delete reuses the recorded final snapshot",
+			exists:          true,
+			finalSnapshotID: "default-app-final-1",
+			wantCalls:       []string{"DeleteDBInstance default-app-final-1"},
+			wantFinalizer:   true,
+		},
+		{
+			name:            "waits for the final snapshot",
+			finalSnapshotID: "default-app-final-1",
+			snapshotState:   &v1alpha1.SnapshotState{Exists: true, CurrentPhase: "creating", PercentProgress: 40},
+			wantFinalizer:   true,
+			wantReason:      v1alpha1.ReasonFinalSnapshotPending,
+		},
+		{
+			name:            "records the available final snapshot as a DBSnapshot",
+			finalSnapshotID: "default-app-final-1",
+			snapshotState:   &v1alpha1.SnapshotState{Exists: true, CurrentPhase: string(v1alpha1.Available)},
+			wantRecord:      true,
+		},
+		{
+			name:            "keeps an existing record of the final snapshot",
+			finalSnapshotID: "default-app-final-1",
+			snapshotState:   &v1alpha1.SnapshotState{Exists: true, CurrentPhase: string(v1alpha1.Available)},
+			existingRecord:  "default-app-final-1",
+			wantRecord:      true,
+		},
+		{
+			name:            "refuses an existing record of another snapshot",
+			finalSnapshotID: "default-app-final-1",
+			snapshotState:   &v1alpha1.SnapshotState{Exists: true, CurrentPhase: string(v1alpha1.Available)},
+			existingRecord:  "nightly",
+			wantErr:         true,
+			wantFinalizer:   true,
+			wantReason:      v1alpha1.ReasonDeleteFailed,
+			wantRecord:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newAWSDBInstance()
+			cr.Finalizers = []string{dbInstanceFinalizer}
+			cr.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			cr.Spec.DeletionPolicy = v1alpha1.DeletionPolicySnapshot
+			cr.Spec.FinalSnapshotRecord = true
+			cr.Status.Phase = v1alpha1.Deleting
+			cr.Status.FinalSnapshotIdentifier = tt.finalSnapshotID
+			dbStatus := &v1alpha1.DBStatus{}
+			if tt.exists {
+				dbStatus = ownedDBStatus(cr)
+			}
+			cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{
+				DBInstanceStatusResp: dbStatus,
+				SnapshotStateResp:    tt.snapshotState,
+			}}
+			objects := []runtime.Object{cr}
+			if tt.existingRecord != "" {
+				objects = append(objects, &v1alpha1.DBSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "app-final", Namespace: "default"},
+					Spec:       v1alpha1.DBSnapshotSpec{SnapshotIdentifierOverride: tt.existingRecord},
+					Status: v1alpha1.DBSnapshotStatus{
+						Phase:              v1alpha1.Available,
+						SnapshotIdentifier: tt.existingRecord,
+						Region:             "us-east-1",
+					},
+				})
+			}
+			fakeClient, _, err := reconcileDBInstance(cloudDB, objects...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := getDBInstance(t, fakeClient)
+
+			if !reflect.DeepEqual(cloudDB.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", cloudDB.calls, tt.wantCalls)
+			}
+			if hasFinalizer := len(got.Finalizers) > 0; hasFinalizer != tt.wantFinalizer {
+				t.Errorf("finalizers = %v, want finalizer %v", got.Finalizers, tt.wantFinalizer)
+			}
+			if tt.wantReason != "" {
+				if ready := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionReady); ready == nil || ready.Reason != tt.wantReason {
+					t.Errorf("Ready condition = %v, want reason %v", ready, tt.wantReason)
+				}
+			}
+			record := &v1alpha1.DBSnapshot{}
+			errGettingRecord := fakeClient.Get(context.TODO(), types.NamespacedName{Name: "app-final", Namespace: "default"}, record)
+			if (errGettingRecord == nil) != tt.wantRecord {
+				t.Fatalf("Get() of the final snapshot record error = %v, want record %v", errGettingRecord, tt.wantRecord)
+			}
+			wantID := tt.finalSnapshotID
+			if tt.existingRecord != "" {
+				wantID = tt.existingRecord
+			}
+			if tt.wantRecord && (record.GetDBSnapshotID() != wantID || record.Status.Region != "us-east-1") {
+				t.Errorf("final snapshot record = %s in %s, want %s in us-east-1", record.GetDBSnapshotID(),
+					record.Status.Region, wantID)
+			}
+		})
+	}
+}
+
+func TestDBInstanceReconciler_FinalSnapshotIdentifier(t *testing.T) {
+	cr := newAWSDBInstance()
+	cr.Finalizers = []string{dbInstanceFinalizer}
+	cr.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	cr.Status.Phase = v1alpha1.Available
+	cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{DBInstanceStatusResp: ownedDBStatus(cr)}}
+	fakeClient, result, err := reconcileDBInstance(cloudDB, cr)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := getDBInstance(t, fakeClient)
+
+	// the identifier is persisted before the delete so a retried delete reuses it
+	if !strings.HasPrefix(got.Status.FinalSnapshotIdentifier, "default-app-final-") {
+		t.Errorf("status.finalSnapshotIdentifier = %v, want default-app-final-<timestamp>", got.Status.FinalSnapshotIdentifier)
+	}
+	if want := []string{"DeleteDBInstance " + got.Status.FinalSnapshotIdentifier}; !reflect.DeepEqual(cloudDB.calls, want) {
+		t.Errorf("calls = %v, want %v", cloudDB.calls, want)
+	}
+	if !result.Requeue || len(got.Finalizers) == 0 {
+		t.Errorf("Reconcile() = %v with finalizers %v, want a requeue until the final snapshot is available", result, got.Finalizers)
+	}
+}
+
+func TestDBInstanceReconciler_CreatesReadReplica(t *testing.T) {
+	source := func(region string, phase v1alpha1.Phase) *v1alpha1.DBInstance {
+		cr := newAWSDBInstance()
+		cr.Name = "source"
+		cr.Spec.Region = region
+		cr.Status.Phase = phase
+		cr.Status.CloudResource = &v1alpha1.CloudResourceStatus{Arn: "arn:aws:rds:" + region + ":123456789012:db:default-source"}
+		return cr
+	}
+	tests := []struct {
+		name       string
+		replicaOf  *v1alpha1.ReplicaOf
+		source     *v1alpha1.DBInstance
+		wantCalls  []string
+		wantReason string
+	}{
+		{
+			name:       "of a source identifier",
+			replicaOf:  &v1alpha1.ReplicaOf{SourceIdentifier: "legacy-db"},
+			wantCalls:  []string{"CreateDBInstanceReadReplica legacy-db "},
+			wantReason: v1alpha1.ReasonCreating,
+		},
+		{
+			name: "of a source identifier in another region",
+			replicaOf: &v1alpha1.ReplicaOf{
+				SourceIdentifier: "arn:aws:rds:us-west-2:123456789012:db:legacy-db",
+				SourceRegion:     "us-west-2",
+			},
+			wantCalls:  []string{"CreateDBInstanceReadReplica arn:aws:rds:us-west-2:123456789012:db:legacy-db us-west-2"},
+			wantReason: v1alpha1.ReasonCreating,
+		},
+		{
+			name:       "of a DBInstance in the same region",
+			replicaOf:  &v1alpha1.ReplicaOf{DBInstanceRef: &v1.LocalObjectReference{Name: "source"}},
+			source:     source("us-east-1", v1alpha1.Available),
+			wantCalls:  []string{"CreateDBInstanceReadReplica default-source "},
+			wantReason: v1alpha1.ReasonCreating,
+		},
+		{
+			name:       "of a DBInstance in another region",
+			replicaOf:  &v1alpha1.ReplicaOf{DBInstanceRef: &v1.LocalObjectReference{Name: "source"}},
+			source:     source("us-west-2", v1alpha1.Available),
+			wantCalls:  []string{"CreateDBInstanceReadReplica arn:aws:rds:us-west-2:123456789012:db:default-source us-west-2"},
+			wantReason: v1alpha1.ReasonCreating,
+		},
+		{
+			name:       "waits for the source DBInstance to become available",
+			replicaOf:  &v1alpha1.ReplicaOf{DBInstanceRef: &v1.LocalObjectReference{Name: "source"}},
+			source:     source("us-east-1", v1alpha1.Creating),
+			wantReason: v1alpha1.ReasonSourceNotAvailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newAWSDBInstance()
+			cr.Spec.ReplicaOf = tt.replicaOf
+			objects := []runtime.Object{cr}
+			if tt.source != nil {
+				objects = append(objects, tt.source, generatedPasswordSecret(tt.source))
+			}
+			cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{DBInstanceStatusResp: &v1alpha1.DBStatus{}}}
+			fakeClient, result, err := reconcileDBInstance(cloudDB, objects...)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			got := getDBInstance(t, fakeClient)
+
+			if !result.Requeue {
+				t.Errorf("Reconcile() = %v, want a requeue", result)
+			}
+			if !reflect.DeepEqual(cloudDB.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", cloudDB.calls, tt.wantCalls)
+			}
+			if ready := meta.FindStatusCondition(got.Status.Conditions, v1alpha1.ConditionReady); ready == nil || ready.Reason != tt.wantReason {
+				t.Errorf("Ready condition = %v, want reason %v", ready, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDBInstanceReconciler_PromotesReadReplica(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       string
+		promotedAt   *metav1.Time
+		wantCalls    []string
+		wantPromoted bool
+	}{
+		{
+			name:         "promotes a read replica",
+			source:       "legacy-db",
+			wantCalls:    []string{"PromoteDBInstanceReadReplica"},
+			wantPromoted: true,
+		},
+		{
+			name:         "records an instance that is not a replica as promoted",
+			wantPromoted: true,
+		},
+		{
+			name:         "a promoted replica is not promoted again",
+			source:       "legacy-db",
+			promotedAt:   &metav1.Time{Time: time.Now()},
+			wantPromoted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newAWSDBInstance()
+			cr.Annotations = map[string]string{v1alpha1.PromoteAnnotation: "true"}
+			cr.Spec.ReplicaOf = &v1alpha1.ReplicaOf{SourceIdentifier: "legacy-db"}
+			cr.Status.Phase = v1alpha1.Available
+			cr.Status.PromotedAt = tt.promotedAt
+			dbStatus := ownedDBStatus(cr)
+			dbStatus.ReplicaSourceIdentifier = tt.source
+			cloudDB := &recordingCloudDB{MockCloudDB: factory.MockCloudDB{
+				IsDBInstanceUpToDateResp: true,
+				DBInstanceStatusResp:     dbStatus,
+			}}
+			fakeClient, _, err := reconcileDBInstance(cloudDB, cr)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			got := getDBInstance(t, fakeClient)
+
+			if !reflect.DeepEqual(cloudDB.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", cloudDB.calls, tt.wantCalls)
+			}
+			if promoted := got.Status.PromotedAt != nil; promoted != tt.wantPromoted {
+				t.Errorf("status.promotedAt = %v, want promoted %v", got.Status.PromotedAt, tt.wantPromoted)
+			}
+			if tt.promotedAt == nil && (got.Status.Replication != nil || got.Status.Phase != v1alpha1.Updating) {
+				t.Errorf("status.replication = %v, phase = %v, want no replication while %v", got.Status.Replication,
+					got.Status.Phase, v1alpha1.Updating)
+			}
+		})
+	}
+}
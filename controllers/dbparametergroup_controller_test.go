@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func TestDBParameterGroupReconciler_Reconcile(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+	timeNow := metav1.Now()
+
+	providerSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "aws-provider-secret",
+		},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+			"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+	parameterGroup := func(deletionTimestamp *metav1.Time, phase v1alpha1.Phase, pending ...string) *v1alpha1.DBParameterGroup {
+		return &v1alpha1.DBParameterGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "params",
+				Namespace:         "default",
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{dbParameterGroupFinalizer},
+			},
+			Spec: v1alpha1.DBParameterGroupSpec{
+				Provider: v1alpha1.ProviderConfig{
+					Type:      "aws",
+					SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+				},
+				Region:      "us-east-1",
+				Family:      "postgres14",
+				Description: "test",
+				Parameters: map[string]v1alpha1.Parameter{
+					"max_connections": {Value: "500"},
+					"work_mem":        {Value: "8192"},
+				},
+			},
+			Status: v1alpha1.DBParameterGroupStatus{Phase: phase, PendingRebootParameters: pending},
+		}
+	}
+	existing := func(family string, parameters map[string]v1alpha1.ParameterState) *v1alpha1.ParameterGroupState {
+		return &v1alpha1.ParameterGroupState{Exists: true, Family: family, Description: "test", Parameters: parameters}
+	}
+	inSyncParameters := map[string]v1alpha1.ParameterState{
+		"max_connections": {Value: "500", ApplyType: "static", Source: "user"},
+		"work_mem":        {Value: "8192", ApplyType: "dynamic", Source: "user"},
+		"shared_buffers":  {Value: "", ApplyType: "static", Source: "engine-default"},
+	}
+	dbInstance := func(applyStatus string) *v1alpha1.DBInstance {
+		return &v1alpha1.DBInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec:       v1alpha1.DBInstanceSpec{DBParameterGroupRef: &v1.LocalObjectReference{Name: "params"}},
+			Status: v1alpha1.DBInstanceStatus{
+				CloudResource: &v1alpha1.CloudResourceStatus{ParameterApplyStatus: applyStatus},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		mockCloudDB *factory.MockCloudDB
+		want        controllerruntime.Result
+		wantErr     bool
+		wantPhase   v1alpha1.Phase
+		wantPending []string
+		wantDeleted bool
+	}{
+		{
+			name:        "when parameter group does not exist, should create it and requeue",
+			objects:     []runtime.Object{providerSecret, parameterGroup(nil, "")},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: &v1alpha1.ParameterGroupState{}},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Creating,
+		},
+		{
+			name:    "when parameters match the spec, should be available",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, "")},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", inSyncParameters),
+				ModifyParameterGroupErr: errors.New("should not be called"),
+				ResetParameterGroupErr:  errors.New("should not be called")},
+			want:      controllerruntime.Result{},
+			wantPhase: v1alpha1.Available,
+		},
+		{
+			name:    "when a static parameter differs, should modify it and report it pending reboot",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", map[string]v1alpha1.ParameterState{
+				"max_connections": {Value: "100", ApplyType: "static", Source: "engine-default"},
+				"work_mem":        {Value: "8192", ApplyType: "dynamic", Source: "user"},
+			}), ResetParameterGroupErr: errors.New("should not be called")},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Updating,
+			wantPending: []string{"max_connections"},
+		},
+		{
+			name:    "when a dynamic parameter differs, should modify it without a reboot",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", map[string]v1alpha1.ParameterState{
+				"max_connections": {Value: "500", ApplyType: "static", Source: "user"},
+				"work_mem":        {Value: "4096", ApplyType: "dynamic", Source: "user"},
+			})},
+			want:      controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase: v1alpha1.Updating,
+		},
+		{
+			name:    "when a modified parameter was removed from the spec, should reset it",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", map[string]v1alpha1.ParameterState{
+				"max_connections": {Value: "500", ApplyType: "static", Source: "user"},
+				"work_mem":        {Value: "8192", ApplyType: "dynamic", Source: "user"},
+				"shared_buffers":  {Value: "65536", ApplyType: "static", Source: "user"},
+			}), ModifyParameterGroupErr: errors.New("should not be called")},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Updating,
+			wantPending: []string{"shared_buffers"},
+		},
+		{
+			name:    "when modify fails, should error",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", nil),
+				ModifyParameterGroupErr: errors.New("invalid parameter")},
+			want:    controllerruntime.Result{},
+			wantErr: true,
+		},
+		{
+			name:        "when the family differs from the spec, should error",
+			objects:     []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres13", inSyncParameters)},
+			want:        controllerruntime.Result{},
+			wantErr:     true,
+		},
+		{
+			name: "when referencing db instances are still pending reboot, should keep the pending parameters",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Available, "max_connections"),
+				dbInstance("pending-reboot")},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", inSyncParameters)},
+			want:        controllerruntime.Result{},
+			wantPhase:   v1alpha1.Available,
+			wantPending: []string{"max_connections"},
+		},
+		{
+			name: "when referencing db instances are in sync, should clear the pending parameters",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Available, "max_connections"),
+				dbInstance("in-sync")},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", inSyncParameters)},
+			want:        controllerruntime.Result{},
+			wantPhase:   v1alpha1.Available,
+		},
+		{
+			name: "when the parameters were just modified, should keep the pending parameters until the db instances report back",
+			objects: []runtime.Object{providerSecret, parameterGroup(nil, v1alpha1.Updating, "max_connections"),
+				dbInstance("in-sync")},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", inSyncParameters)},
+			want:        controllerruntime.Result{},
+			wantPhase:   v1alpha1.Available,
+			wantPending: []string{"max_connections"},
+		},
+		{
+			name:        "when marked for deletion, should delete and remove the finalizer",
+			objects:     []runtime.Object{providerSecret, parameterGroup(&timeNow, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{ParameterGroupStateResp: existing("postgres14", inSyncParameters)},
+			want:        controllerruntime.Result{},
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DBParameterGroupReconciler{
+				Client:           fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: tt.mockCloudDB,
+			}
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "params"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			cr := &v1alpha1.DBParameterGroup{}
+			if err := r.Client.Get(context.Background(), req.NamespacedName, cr); err != nil {
+				if !tt.wantDeleted {
+					t.Fatalf("failed to get DBParameterGroup: %v", err)
+				}
+				return
+			}
+			if tt.wantDeleted {
+				if len(cr.GetFinalizers()) != 0 {
+					t.Errorf("Reconcile() finalizers = %v, want none", cr.GetFinalizers())
+				}
+				return
+			}
+			if cr.Status.Phase != tt.wantPhase {
+				t.Errorf("Reconcile() phase = %v, want %v", cr.Status.Phase, tt.wantPhase)
+			}
+			if !reflect.DeepEqual(cr.Status.PendingRebootParameters, tt.wantPending) {
+				t.Errorf("Reconcile() pendingRebootParameters = %v, want %v", cr.Status.PendingRebootParameters, tt.wantPending)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"context"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func TestDBSnapshotReconciler_Reconcile(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+	timeNow := time.Now()
+
+	provider := v1alpha1.ProviderConfig{
+		Type: "aws",
+		SecretRef: v1.SecretReference{
+			Name:      "aws-provider-secret",
+			Namespace: "default",
+		},
+	}
+	providerSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "aws-provider-secret",
+		},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+			"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+	dbInstance := func(phase v1alpha1.Phase) *v1alpha1.DBInstance {
+		return &v1alpha1.DBInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mysql",
+				Namespace: "default",
+			},
+			Spec: v1alpha1.DBInstanceSpec{
+				Provider: provider,
+				Region:   "us-east-1",
+				Engine:   "mysql",
+			},
+			Status: v1alpha1.DBInstanceStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		objects       []runtime.Object
+		mock          *factory.MockCloudDB
+		want          controllerruntime.Result
+		wantErr       bool
+		wantFinalizer bool
+	}{
+		{
+			name: "when snapshot does not exist and dbinstance is available, should create and requeue",
+			objects: []runtime.Object{providerSecret, dbInstance(v1alpha1.Available), &v1alpha1.DBSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-snapshot", Namespace: "default"},
+				Spec:       v1alpha1.DBSnapshotSpec{DBInstanceName: "mysql"},
+			}},
+			mock:          &factory.MockCloudDB{SnapshotStateResp: &v1alpha1.SnapshotState{}},
+			want:          controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantFinalizer: true,
+		},
+		{
+			name: "when snapshot does not exist and dbinstance is not available, should wait",
+			objects: []runtime.Object{providerSecret, dbInstance(v1alpha1.Creating), &v1alpha1.DBSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-snapshot", Namespace: "default"},
+				Spec:       v1alpha1.DBSnapshotSpec{DBInstanceName: "mysql"},
+			}},
+			mock:          &factory.MockCloudDB{SnapshotStateResp: &v1alpha1.SnapshotState{}},
+			want:          controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantFinalizer: true,
+		},
+		{
+			name: "when dbinstance does not exist, should error",
+			objects: []runtime.Object{providerSecret, &v1alpha1.DBSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-snapshot", Namespace: "default"},
+				Spec:       v1alpha1.DBSnapshotSpec{DBInstanceName: "mysql"},
+			}},
+			mock:          &factory.MockCloudDB{SnapshotStateResp: &v1alpha1.SnapshotState{}},
+			want:          controllerruntime.Result{},
+			wantErr:       true,
+			wantFinalizer: true,
+		},
+		{
+			name: "when snapshot is available, should not requeue",
+			objects: []runtime.Object{providerSecret, dbInstance(v1alpha1.Available), &v1alpha1.DBSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-snapshot", Namespace: "default"},
+				Spec:       v1alpha1.DBSnapshotSpec{DBInstanceName: "mysql"},
+			}},
+			mock: &factory.MockCloudDB{SnapshotStateResp: &v1alpha1.SnapshotState{
+				Exists: true, CurrentPhase: "available", PercentProgress: 100, AllocatedStorage: 20,
+			}},
+			want:          controllerruntime.Result{},
+			wantFinalizer: true,
+		},
+		{
+			name: "when the provider of the dbinstance does not support snapshots, should error before calling it",
+			objects: []runtime.Object{&v1alpha1.DBInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: "default"},
+				Spec: v1alpha1.DBInstanceSpec{
+					Provider: v1alpha1.ProviderConfig{Type: v1alpha1.Local},
+					Engine:   "mysql",
+				},
+				Status: v1alpha1.DBInstanceStatus{Phase: v1alpha1.Available},
+			}, &v1alpha1.DBSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-snapshot", Namespace: "default"},
+				Spec:       v1alpha1.DBSnapshotSpec{DBInstanceName: "mysql"},
+			}},
+			mock:          &factory.MockCloudDB{},
+			want:          controllerruntime.Result{},
+			wantErr:       true,
+			wantFinalizer: true,
+		},
+		{
+			name: "when marked for deletion with retain policy, should remove finalizer even though the dbinstance is gone",
+			objects: []runtime.Object{providerSecret, &v1alpha1.DBSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "mysql-snapshot",
+					Namespace:         "default",
+					DeletionTimestamp: &metav1.Time{Time: timeNow},
+					Finalizers:        []string{dbSnapshotFinalizer},
+				},
+				Spec: v1alpha1.DBSnapshotSpec{
+					DBInstanceName: "mysql",
+					DeletionPolicy: v1alpha1.SnapshotDeletionPolicyRetain,
+				},
+				Status: v1alpha1.DBSnapshotStatus{
+					Phase:              v1alpha1.Available,
+					SnapshotIdentifier: "default-mysql-snapshot",
+					Provider:           &provider,
+					Region:             "us-east-1",
+				},
+			}},
+			mock: &factory.MockCloudDB{SnapshotStateResp: &v1alpha1.SnapshotState{
+				Exists: true, CurrentPhase: "available",
+			}},
+			want:          controllerruntime.Result{},
+			wantFinalizer: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DBSnapshotReconciler{
+				Client:           fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: tt.mock,
+			}
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "mysql-snapshot"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+			cr := &v1alpha1.DBSnapshot{}
+			if err := r.Client.Get(context.Background(), req.NamespacedName, cr); err != nil {
+				t.Fatalf("failed to get DBSnapshot: %v", err)
+			}
+			if hasFinalizer := len(cr.GetFinalizers()) > 0; hasFinalizer != tt.wantFinalizer {
+				t.Errorf("Reconcile() finalizers = %v, wantFinalizer %v", cr.GetFinalizers(), tt.wantFinalizer)
+			}
+		})
+	}
+}
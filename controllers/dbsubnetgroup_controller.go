@@ -0,0 +1,162 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1 "github.com/agill17/db-operator/api/v1alpha1"
+)
+
+// DBSubnetGroupReconciler reconciles a DBSubnetGroup object
+type DBSubnetGroupReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// CloudDBInterface replaces the client built from the provider of every object, only tests set it
+	CloudDBInterface factory.CloudDB
+}
+
+var (
+	dbSubnetGroupFinalizer = fmt.Sprintf("%s/%s-dbsubnetgroup", groupName, groupVersion)
+)
+
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbsubnetgroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbsubnetgroups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=dbsubnetgroups/finalizers,verbs=update
+// Reconcile creates the subnet group and keeps its description and subnets in sync with the spec.
+func (r *DBSubnetGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("dbsubnetgroup", req.NamespacedName)
+	namespacedName := req.NamespacedName.String()
+	cr := &v1alpha1.DBSubnetGroup{}
+	if errGettingCr := r.Client.Get(context.TODO(), req.NamespacedName, cr); errGettingCr != nil {
+		if errors.IsNotFound(errGettingCr) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errGettingCr
+	}
+
+	// add finalizer
+	if errAddingFinalizer := utils.AddFinalizer(dbSubnetGroupFinalizer, r.Client, cr); errAddingFinalizer != nil {
+		return ctrl.Result{}, errAddingFinalizer
+	}
+
+	// resolve the provider, its region and default tags are passed to the client
+	providerOptions, errResolvingProvider := resolveProvider(cr.Spec.Provider, cr.Spec.Region, r.Client)
+	if errResolvingProvider != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderSecretError, errResolvingProvider, cr, r.Client)
+	}
+
+	// reject what the provider does not support before calling it, a delete still goes through
+	if cr.GetDeletionTimestamp() == nil {
+		if errValidating := factory.ValidateFeatures(providerOptions.Type, v1alpha1.FeatureSubnetGroups); errValidating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUnsupportedFeature,
+				errValidating, cr, r.Client)
+		}
+	}
+
+	// create the cloud client of the resolved provider, objects of one kind can use different providers
+	cloudDB, errCreatingClient := cloudDBFor(r.CloudDBInterface, r.Log, providerOptions)
+	if errCreatingClient != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderClientError, errCreatingClient, cr, r.Client)
+	}
+
+	// get subnet group status
+	subnetGroupName := cr.GetDBSubnetGroupName()
+	state, err := cloudDB.DBSubnetGroupExists(subnetGroupName)
+	if err != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, err, cr, r.Client)
+	}
+	setCloudResourceConditions(state.Exists, state.Status, cr)
+	cr.Status.Arn = state.Arn
+	cr.Status.VpcID = state.VpcID
+	cr.Status.Status = state.Status
+	cr.Status.Subnets = state.Subnets
+
+	// handle delete, the cloud provider refuses it while a db instance or db cluster still uses the subnet group
+	if cr.GetDeletionTimestamp() != nil {
+		r.Log.Info(fmt.Sprintf("%v - is marked for deletion", namespacedName))
+		setDeletingConditions(cr)
+		if errUpdatingPhase := utils.UpdateStatusPhase(v1alpha1.Deleting, cr, r.Client); errUpdatingPhase != nil {
+			return ctrl.Result{}, errUpdatingPhase
+		}
+		if state.Exists {
+			if errDeleting := cloudDB.DeleteDBSubnetGroup(subnetGroupName); errDeleting != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errDeleting, cr, r.Client)
+			}
+		}
+		if errRemovingFinalizer := utils.RemoveFinalizer(dbSubnetGroupFinalizer, r.Client, cr); errRemovingFinalizer != nil {
+			return ctrl.Result{}, errRemovingFinalizer
+		}
+		r.Log.Info(fmt.Sprintf("%v - deleted successfully", namespacedName))
+		return ctrl.Result{}, nil
+	}
+
+	// create
+	if !state.Exists {
+		if errCreating := cloudDB.CreateDBSubnetGroup(cr); errCreating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonCreateFailed, errCreating, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - subnet group does not exist, creating now.", namespacedName))
+		cr.Status.DBSubnetGroupName = subnetGroupName
+		setInProgressConditions(v1alpha1.ReasonCreating, "create requested", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
+	}
+	cr.Status.DBSubnetGroupName = subnetGroupName
+
+	// update
+	if !cr.IsUpToDate(state) {
+		if errModifying := cloudDB.ModifyDBSubnetGroup(cr); errModifying != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUpdateFailed, errModifying, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - subnet group is not up to date, updating now.", namespacedName))
+		setInProgressConditions(v1alpha1.ReasonUpdating, "update requested", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+	}
+
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue,
+		v1alpha1.ReasonUpToDate, "cloud resource matches the spec", cr)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonAvailable,
+		fmt.Sprintf("subnet group %s is available in vpc %s", subnetGroupName, state.VpcID), cr)
+	if errUpdatingStatus := utils.UpdateStatusPhase(v1alpha1.Available, cr, r.Client); errUpdatingStatus != nil {
+		return ctrl.Result{}, errUpdatingStatus
+	}
+	r.Log.Info(fmt.Sprintf("%s - reconciled", namespacedName))
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBSubnetGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.DBSubnetGroup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func TestDBSubnetGroupReconciler_Reconcile(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+	timeNow := metav1.Now()
+
+	providerSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "aws-provider-secret",
+		},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+			"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+	subnetGroup := func(deletionTimestamp *metav1.Time) *v1alpha1.DBSubnetGroup {
+		return &v1alpha1.DBSubnetGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "subnets",
+				Namespace:         "default",
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{dbSubnetGroupFinalizer},
+			},
+			Spec: v1alpha1.DBSubnetGroupSpec{
+				Provider: v1alpha1.ProviderConfig{
+					Type:      "aws",
+					SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+				},
+				Region:      "us-east-1",
+				Description: "test",
+				SubnetIDs:   []string{"subnet-a", "subnet-b"},
+			},
+		}
+	}
+	existing := func(subnetIDs ...string) *v1alpha1.DBSubnetGroupState {
+		state := &v1alpha1.DBSubnetGroupState{Exists: true, Status: "Complete", VpcID: "vpc-1", Description: "test"}
+		for _, subnetID := range subnetIDs {
+			state.Subnets = append(state.Subnets, v1alpha1.DBSubnetGroupSubnet{SubnetID: subnetID, Status: "Active"})
+		}
+		return state
+	}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		mockCloudDB *factory.MockCloudDB
+		want        controllerruntime.Result
+		wantErr     bool
+		wantPhase   v1alpha1.Phase
+		wantDeleted bool
+	}{
+		{
+			name:        "when subnet group does not exist, should create it and requeue",
+			objects:     []runtime.Object{providerSecret, subnetGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{DBSubnetGroupStateResp: &v1alpha1.DBSubnetGroupState{}},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Creating,
+		},
+		{
+			name:        "when subnet group matches the spec, should be available",
+			objects:     []runtime.Object{providerSecret, subnetGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{DBSubnetGroupStateResp: existing("subnet-b", "subnet-a")},
+			want:        controllerruntime.Result{},
+			wantPhase:   v1alpha1.Available,
+		},
+		{
+			name:        "when subnets differ from the spec, should modify and requeue",
+			objects:     []runtime.Object{providerSecret, subnetGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{DBSubnetGroupStateResp: existing("subnet-a", "subnet-c")},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Updating,
+		},
+		{
+			name:        "when modify fails, should error",
+			objects:     []runtime.Object{providerSecret, subnetGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{DBSubnetGroupStateResp: existing("subnet-a"), ModifyDBSubnetGroupErr: errors.New("modify failed")},
+			want:        controllerruntime.Result{},
+			wantErr:     true,
+		},
+		{
+			name:        "when marked for deletion, should delete and remove the finalizer",
+			objects:     []runtime.Object{providerSecret, subnetGroup(&timeNow)},
+			mockCloudDB: &factory.MockCloudDB{DBSubnetGroupStateResp: existing("subnet-a", "subnet-b")},
+			want:        controllerruntime.Result{},
+			wantDeleted: true,
+		},
+		{
+			name:    "when marked for deletion and the subnet group is in use, should error",
+			objects: []runtime.Object{providerSecret, subnetGroup(&timeNow)},
+			mockCloudDB: &factory.MockCloudDB{DBSubnetGroupStateResp: existing("subnet-a", "subnet-b"),
+				DeleteDBSubnetGroupErr: errors.New("subnet group is in use")},
+			want:    controllerruntime.Result{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DBSubnetGroupReconciler{
+				Client:           fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: tt.mockCloudDB,
+			}
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "subnets"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			cr := &v1alpha1.DBSubnetGroup{}
+			if err := r.Client.Get(context.Background(), req.NamespacedName, cr); err != nil {
+				if !tt.wantDeleted {
+					t.Fatalf("failed to get DBSubnetGroup: %v", err)
+				}
+				return
+			}
+			if tt.wantDeleted {
+				if len(cr.GetFinalizers()) != 0 {
+					t.Errorf("Reconcile() finalizers = %v, want none", cr.GetFinalizers())
+				}
+				return
+			}
+			if cr.Status.Phase != tt.wantPhase {
+				t.Errorf("Reconcile() phase = %v, want %v", cr.Status.Phase, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestDBSubnetGroupReconciler_ReconcilePerProvider(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	provider := func(name, region string) *v1alpha1.Provider {
+		return &v1alpha1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.ProviderSpec{
+				Type:        multiRegionProvider,
+				Region:      region,
+				Credentials: v1alpha1.ProviderCredentials{Source: v1alpha1.CredentialsSourceInjectedIdentity},
+			},
+		}
+	}
+	subnetGroup := func(name, providerName string) *v1alpha1.DBSubnetGroup {
+		return &v1alpha1.DBSubnetGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha1.DBSubnetGroupSpec{
+				Provider:    v1alpha1.ProviderConfig{Name: providerName},
+				Description: "test",
+				SubnetIDs:   []string{"subnet-a"},
+			},
+		}
+	}
+	fakeClient := fake.NewFakeClientWithScheme(testScheme,
+		provider("east", "us-east-1"), provider("west", "us-west-2"),
+		subnetGroup("east-subnets", "east"), subnetGroup("west-subnets", "west"))
+	r := &DBSubnetGroupReconciler{
+		Client: fakeClient,
+		Log:    logf.Log.WithName("dbsubnetgroup-controller-test"),
+		Scheme: testScheme,
+	}
+	for _, name := range []string{"east-subnets", "west-subnets", "east-subnets"} {
+		if _, err := r.Reconcile(context.TODO(), controllerruntime.Request{
+			NamespacedName: types.NamespacedName{Name: name, Namespace: "default"},
+		}); err != nil {
+			t.Fatalf("Reconcile() of %s error = %v", name, err)
+		}
+	}
+
+	want := map[string][]string{
+		"provider/east/us-east-1": {"default-east-subnets", "default-east-subnets"},
+		"provider/west/us-west-2": {"default-west-subnets"},
+	}
+	for key, wantRequested := range want {
+		client, ok := multiRegionClients[key]
+		if !ok {
+			t.Fatalf("no client was built for %s", key)
+		}
+		if !reflect.DeepEqual(client.requested, wantRequested) {
+			t.Errorf("client %s was asked for %v, want %v", key, client.requested, wantRequested)
+		}
+	}
+}
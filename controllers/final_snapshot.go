@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+// finalSnapshotID returns a new final snapshot identifier, it must be persisted in status before it is
+// used so a retried delete reuses it
+func finalSnapshotID(resourceID string) string {
+	return fmt.Sprintf("%s-final-%d", resourceID, time.Now().Unix())
+}
+
+func finalSnapshotRecordName(ownerName string) string {
+	return ownerName + "-final"
+}
+
+func finalSnapshotRecordConflict(kind, namespace, name, recordedID, finalSnapshotID string) error {
+	return utils.ErrFinalSnapshotRecordConflict{Message: fmt.Sprintf(
+		"%s %s/%s already exists and points at snapshot %s instead of the final snapshot %s, delete or rename it",
+		kind, namespace, name, recordedID, finalSnapshotID)}
+}
+
+// setFinalSnapshotPendingCondition records that the object is released once its final snapshot is available
+func setFinalSnapshotPendingCondition(snapshotID string, state *v1alpha1.SnapshotState, object v1alpha1.ConditionedObject) {
+	message := fmt.Sprintf("waiting for final snapshot %s to be requested", snapshotID)
+	if state.Exists {
+		message = fmt.Sprintf("waiting for final snapshot %s, current status: %s, progress: %d%%",
+			snapshotID, state.CurrentPhase, state.PercentProgress)
+	}
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonFinalSnapshotPending, message, object)
+}
+
+// finalSnapshotSource is the DBInstance or DBCluster whose final snapshot is recorded
+type finalSnapshotSource struct {
+	kind       string
+	namespace  string
+	name       string
+	snapshotID string
+	provider   *v1alpha1.ProviderConfig
+	region     string
+}
+
+// finalSnapshotRecord is the DBSnapshot or DBClusterSnapshot recording a final snapshot
+type finalSnapshotRecord struct {
+	kind   string
+	object client.Object
+	// snapshotID returns the snapshot the record points at
+	snapshotID func() string
+	// recorded is true once the status of the record was filled in
+	recorded func() bool
+	// setStatus fills in the status of the record from the source, the source is about to be gone so
+	// provider and region are recorded right away
+	setStatus func()
+}
+
+// newFinalSnapshotRecord returns a DBSnapshot for the final snapshot of a DBInstance and a DBClusterSnapshot for
+// the final snapshot of a DBCluster. The snapshot is retained when the record is deleted.
+func newFinalSnapshotRecord(source finalSnapshotSource) finalSnapshotRecord {
+	objectMeta := metav1.ObjectMeta{Name: finalSnapshotRecordName(source.name), Namespace: source.namespace}
+	if source.kind == "DBCluster" {
+		record := &v1alpha1.DBClusterSnapshot{
+			ObjectMeta: objectMeta,
+			Spec: v1alpha1.DBClusterSnapshotSpec{
+				DBClusterName:              source.name,
+				SnapshotIdentifierOverride: source.snapshotID,
+				DeletionPolicy:             v1alpha1.SnapshotDeletionPolicyRetain,
+			},
+		}
+		return finalSnapshotRecord{
+			kind:       "dbclustersnapshot",
+			object:     record,
+			snapshotID: record.GetDBClusterSnapshotID,
+			recorded:   func() bool { return record.Status.SnapshotIdentifier != "" },
+			setStatus: func() {
+				record.Status = v1alpha1.DBClusterSnapshotStatus{Phase: v1alpha1.Available,
+					SnapshotIdentifier: source.snapshotID, Provider: source.provider, Region: source.region}
+			},
+		}
+	}
+	record := &v1alpha1.DBSnapshot{
+		ObjectMeta: objectMeta,
+		Spec: v1alpha1.DBSnapshotSpec{
+			DBInstanceName:             source.name,
+			SnapshotIdentifierOverride: source.snapshotID,
+			DeletionPolicy:             v1alpha1.SnapshotDeletionPolicyRetain,
+		},
+	}
+	return finalSnapshotRecord{
+		kind:       "dbsnapshot",
+		object:     record,
+		snapshotID: record.GetDBSnapshotID,
+		recorded:   func() bool { return record.Status.SnapshotIdentifier != "" },
+		setStatus: func() {
+			record.Status = v1alpha1.DBSnapshotStatus{Phase: v1alpha1.Available,
+				SnapshotIdentifier: source.snapshotID, Provider: source.provider, Region: source.region}
+		},
+	}
+}
+
+// recordFinalSnapshot creates the record of the final snapshot of source. A retry after a failed status write
+// finds its own record and fills in its status, a record of another snapshot is left alone.
+func recordFinalSnapshot(source finalSnapshotSource, c client.Client) error {
+	record := newFinalSnapshotRecord(source)
+	if err := c.Create(context.TODO(), record.object); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return err
+		}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: record.object.GetNamespace(),
+			Name: record.object.GetName()}, record.object); err != nil {
+			return err
+		}
+		if record.snapshotID() != source.snapshotID {
+			return finalSnapshotRecordConflict(record.kind, record.object.GetNamespace(), record.object.GetName(),
+				record.snapshotID(), source.snapshotID)
+		}
+		if record.recorded() {
+			return nil
+		}
+	}
+	record.setStatus()
+	return c.Status().Update(context.TODO(), record.object)
+}
+
+// recordFinalDBSnapshot creates a DBSnapshot pointing at the final snapshot of cr in region
+func (r *DBInstanceReconciler) recordFinalDBSnapshot(cr *v1alpha1.DBInstance, region string) error {
+	return recordFinalSnapshot(finalSnapshotSource{
+		kind:       "DBInstance",
+		namespace:  cr.GetNamespace(),
+		name:       cr.GetName(),
+		snapshotID: cr.Status.FinalSnapshotIdentifier,
+		provider:   cr.Spec.Provider.DeepCopy(),
+		region:     region,
+	}, r.Client)
+}
+
+// recordFinalDBClusterSnapshot creates a DBClusterSnapshot pointing at the final snapshot of cr in region
+func (r *DBClusterReconciler) recordFinalDBClusterSnapshot(cr *v1alpha1.DBCluster, region string) error {
+	return recordFinalSnapshot(finalSnapshotSource{
+		kind:       "DBCluster",
+		namespace:  cr.GetNamespace(),
+		name:       cr.GetName(),
+		snapshotID: cr.Status.FinalSnapshotIdentifier,
+		provider:   cr.Spec.Provider.DeepCopy(),
+		region:     region,
+	}, r.Client)
+}
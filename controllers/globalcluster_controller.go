@@ -0,0 +1,302 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1 "github.com/agill17/db-operator/api/v1alpha1"
+)
+
+// GlobalClusterReconciler reconciles a GlobalCluster object
+type GlobalClusterReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// CloudDBInterface replaces the client built from the provider of every object, only tests set it
+	CloudDBInterface factory.CloudDB
+}
+
+var (
+	globalClusterFinalizer = fmt.Sprintf("%s/%s-globalcluster", groupName, groupVersion)
+)
+
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=globalclusters,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=globalclusters/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=globalclusters/finalizers,verbs=update
+// Reconcile creates the global database from the primary DBCluster, waits for the secondary DBClusters to
+// join it and fails over to spec.failoverToDBClusterName. Members are detached before the global database is deleted.
+func (r *GlobalClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("globalcluster", req.NamespacedName)
+	namespacedName := req.NamespacedName.String()
+	cr := &v1alpha1.GlobalCluster{}
+	if errGettingCr := r.Client.Get(context.TODO(), req.NamespacedName, cr); errGettingCr != nil {
+		if k8serrors.IsNotFound(errGettingCr) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errGettingCr
+	}
+
+	// add finalizer
+	if errAddingFinalizer := utils.AddFinalizer(globalClusterFinalizer, r.Client, cr); errAddingFinalizer != nil {
+		return ctrl.Result{}, errAddingFinalizer
+	}
+
+	if errValidating := cr.Validate(); errValidating != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonInvalidSpec, errValidating, cr, r.Client)
+	}
+
+	// resolve the provider, its region and default tags are passed to the client
+	providerOptions, errResolvingProvider := resolveProvider(cr.Spec.Provider, cr.Spec.Region, r.Client)
+	if errResolvingProvider != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderSecretError, errResolvingProvider, cr, r.Client)
+	}
+
+	// reject what the provider does not support before calling it, a delete still goes through
+	if cr.GetDeletionTimestamp() == nil {
+		if errValidating := factory.ValidateFeatures(providerOptions.Type, v1alpha1.FeatureClusters); errValidating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUnsupportedFeature,
+				errValidating, cr, r.Client)
+		}
+	}
+
+	// create the cloud client of the resolved provider, objects of one kind can use different providers
+	cloudDB, errCreatingClient := cloudDBFor(r.CloudDBInterface, r.Log, providerOptions)
+	if errCreatingClient != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderClientError, errCreatingClient, cr, r.Client)
+	}
+
+	// get global cluster status
+	globalClusterID := cr.GetGlobalClusterID()
+	state, err := cloudDB.GlobalClusterExists(globalClusterID)
+	if err != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonReconcileError, err, cr, r.Client)
+	}
+	setCloudResourceConditions(state.Exists, state.Status, cr)
+	memberArns, errGettingMembers := r.getMemberArns(cr)
+	if errGettingMembers != nil {
+		return ctrl.Result{}, errGettingMembers
+	}
+	setGlobalClusterStatus(state, memberArns, cr)
+
+	// handle delete
+	if cr.GetDeletionTimestamp() != nil {
+		r.Log.Info(fmt.Sprintf("%v - is marked for deletion", namespacedName))
+		setDeletingConditions(cr)
+		if errUpdatingPhase := utils.UpdateStatusPhase(v1alpha1.Deleting, cr, r.Client); errUpdatingPhase != nil {
+			return ctrl.Result{}, errUpdatingPhase
+		}
+		if state.Exists {
+			return r.deleteGlobalCluster(cr, state, cloudDB)
+		}
+		if errRemovingFinalizer := utils.RemoveFinalizer(globalClusterFinalizer, r.Client, cr); errRemovingFinalizer != nil {
+			return ctrl.Result{}, errRemovingFinalizer
+		}
+		r.Log.Info(fmt.Sprintf("%v - deleted successfully", namespacedName))
+		return ctrl.Result{}, nil
+	}
+
+	// create from the primary dbcluster
+	if !state.Exists {
+		primary := &v1alpha1.DBCluster{}
+		if errGettingPrimary := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cr.GetNamespace(),
+			Name: cr.Spec.PrimaryDBClusterName}, primary); errGettingPrimary != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonSourceNotFound,
+				errGettingPrimary, cr, r.Client)
+		}
+		if primary.Status.Phase != v1alpha1.Available || primary.Status.CloudResource == nil {
+			r.Log.Info(fmt.Sprintf("%s - waiting for dbcluster %s to become available", namespacedName, primary.GetName()))
+			utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonSourceNotAvailable,
+				fmt.Sprintf("dbcluster %s is not available", primary.GetName()), cr)
+			return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+		}
+		if errCreating := cloudDB.CreateGlobalCluster(cr, primary.Status.CloudResource.Arn); errCreating != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonCreateFailed, errCreating, cr, r.Client)
+		}
+		r.Log.Info(fmt.Sprintf("%s - global cluster does not exist, creating now.", namespacedName))
+		cr.Status.GlobalClusterIdentifier = globalClusterID
+		setInProgressConditions(v1alpha1.ReasonCreating, "create requested", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Creating, cr, r.Client)
+	}
+	cr.Status.GlobalClusterIdentifier = globalClusterID
+
+	if state.Status != string(v1alpha1.Available) || state.FailoverStatus != "" {
+		r.Log.Info(fmt.Sprintf("%s - global cluster exists but not yet available. Current status: %s",
+			namespacedName, state.Status))
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+	}
+
+	if state.DeletionProtection != cr.Spec.DeletionProtection {
+		if errModifying := cloudDB.ModifyGlobalCluster(cr); errModifying != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonUpdateFailed, errModifying, cr, r.Client)
+		}
+		setInProgressConditions(v1alpha1.ReasonUpdating, "update requested", cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+	}
+
+	// secondaries join the global database when their own controller creates them
+	if pending := r.pendingSecondaries(cr, state, memberArns); pending != "" {
+		r.Log.Info(fmt.Sprintf("%s - %s", namespacedName, pending))
+		utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonWaitingForMembers, pending, cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatus(cr, r.Client)
+	}
+
+	// managed planned failover when the writer is not the desired primary
+	writerName := cr.GetWriterDBClusterName()
+	if writerArn := memberArns[writerName]; writerArn != "" && state.Writer() != "" && writerArn != state.Writer() {
+		r.Log.Info(fmt.Sprintf("%s - failing over to dbcluster %s", namespacedName, writerName))
+		if errFailingOver := cloudDB.FailoverGlobalCluster(globalClusterID, writerArn); errFailingOver != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonFailoverFailed, errFailingOver, cr, r.Client)
+		}
+		setInProgressConditions(v1alpha1.ReasonFailingOver, fmt.Sprintf("failover to dbcluster %s requested", writerName), cr)
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+	}
+
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue,
+		v1alpha1.ReasonUpToDate, "cloud resource matches the spec", cr)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonAvailable,
+		fmt.Sprintf("global cluster %s is available with primary dbcluster %s", globalClusterID, writerName), cr)
+	if errUpdatingStatus := utils.UpdateStatusPhase(v1alpha1.Available, cr, r.Client); errUpdatingStatus != nil {
+		return ctrl.Result{}, errUpdatingStatus
+	}
+	r.Log.Info(fmt.Sprintf("%s - reconciled", namespacedName))
+	return ctrl.Result{}, nil
+}
+
+// deleteGlobalCluster detaches the secondaries, then the primary, and deletes the global database once it
+// has no members left. The detached DB clusters keep running as standalone clusters.
+func (r *GlobalClusterReconciler) deleteGlobalCluster(cr *v1alpha1.GlobalCluster, state *v1alpha1.GlobalClusterState, cloudDB factory.CloudDB) (ctrl.Result, error) {
+	globalClusterID := cr.GetGlobalClusterID()
+	if cr.Spec.DeletionProtection {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed,
+			errors.New("cannot delete, deletion protection is enabled"), cr, r.Client)
+	}
+	if state.DeletionProtection {
+		if errModifying := cloudDB.ModifyGlobalCluster(cr); errModifying != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errModifying, cr, r.Client)
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+	if len(state.Members) > 0 {
+		writer := state.Writer()
+		for _, member := range state.Members {
+			// the primary can only be removed once it is the last member
+			if member.DBClusterArn == writer && len(state.Members) > 1 {
+				continue
+			}
+			r.Log.Info(fmt.Sprintf("%s - removing dbcluster %s from the global cluster", globalClusterID, member.DBClusterArn))
+			if errRemoving := cloudDB.RemoveFromGlobalCluster(globalClusterID, member.DBClusterArn); errRemoving != nil {
+				return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errRemoving, cr, r.Client)
+			}
+		}
+		return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	}
+	if errDeleting := cloudDB.DeleteGlobalCluster(globalClusterID); errDeleting != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonDeleteFailed, errDeleting, cr, r.Client)
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+}
+
+// getMemberArns returns the arn of each primary and secondary DBCluster by name, DBClusters that do not
+// exist yet or were not created in the cloud provider yet are left out
+func (r *GlobalClusterReconciler) getMemberArns(cr *v1alpha1.GlobalCluster) (map[string]string, error) {
+	arns := map[string]string{}
+	for _, name := range cr.GetMemberDBClusterNames() {
+		member := &v1alpha1.DBCluster{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cr.GetNamespace(), Name: name}, member); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if member.Status.CloudResource != nil && member.Status.CloudResource.Arn != "" {
+			arns[name] = member.Status.CloudResource.Arn
+		}
+	}
+	return arns, nil
+}
+
+// pendingSecondaries describes the first secondary DBCluster that is not a member of the global database yet,
+// empty once they all joined
+func (r *GlobalClusterReconciler) pendingSecondaries(cr *v1alpha1.GlobalCluster, state *v1alpha1.GlobalClusterState,
+	memberArns map[string]string) string {
+	joined := map[string]bool{}
+	for _, member := range state.Members {
+		joined[member.DBClusterArn] = true
+	}
+	for _, name := range cr.Spec.SecondaryDBClusterNames {
+		arn, found := memberArns[name]
+		if !found {
+			return fmt.Sprintf("waiting for dbcluster %s to be created with globalClusterIdentifier %s",
+				name, cr.GetGlobalClusterID())
+		}
+		if !joined[arn] {
+			return fmt.Sprintf("dbcluster %s is not a member of the global cluster, it joins only when created with "+
+				"globalClusterIdentifier %s", name, cr.GetGlobalClusterID())
+		}
+	}
+	return ""
+}
+
+// setGlobalClusterStatus copies the describe result to status, naming the members managed by this GlobalCluster
+func setGlobalClusterStatus(state *v1alpha1.GlobalClusterState, memberArns map[string]string, cr *v1alpha1.GlobalCluster) {
+	names := map[string]string{}
+	for name, arn := range memberArns {
+		names[arn] = name
+	}
+	cr.Status.Arn = state.Arn
+	cr.Status.Engine = state.Engine
+	cr.Status.EngineVersion = state.EngineVersion
+	cr.Status.Status = state.Status
+	cr.Status.FailoverStatus = state.FailoverStatus
+	cr.Status.Members = nil
+	for _, member := range state.Members {
+		role := v1alpha1.GlobalClusterSecondary
+		if member.IsWriter {
+			role = v1alpha1.GlobalClusterPrimary
+		}
+		cr.Status.Members = append(cr.Status.Members, v1alpha1.GlobalClusterMemberStatus{
+			DBClusterName: names[member.DBClusterArn],
+			DBClusterArn:  member.DBClusterArn,
+			Role:          role,
+		})
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GlobalClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.GlobalCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func TestGlobalClusterReconciler_Reconcile(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	provider := v1alpha1.ProviderConfig{
+		Type: "aws",
+		SecretRef: v1.SecretReference{
+			Name:      "aws-provider-secret",
+			Namespace: "default",
+		},
+	}
+	providerSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "aws-provider-secret",
+		},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+			"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+	primaryArn := "arn:aws:rds:us-east-1:123456789012:cluster:primary"
+	secondaryArn := "arn:aws:rds:us-west-2:123456789012:cluster:secondary"
+	dbCluster := func(name, region, arn string, phase v1alpha1.Phase) *v1alpha1.DBCluster {
+		cluster := &v1alpha1.DBCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha1.DBClusterSpec{
+				Provider: provider,
+				Region:   region,
+				Engine:   "aurora-mysql",
+			},
+			Status: v1alpha1.DBClusterStatus{Phase: phase},
+		}
+		if arn != "" {
+			cluster.Status.CloudResource = &v1alpha1.CloudResourceStatus{Arn: arn}
+		}
+		return cluster
+	}
+	globalCluster := func(failoverTo string) *v1alpha1.GlobalCluster {
+		return &v1alpha1.GlobalCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "default"},
+			Spec: v1alpha1.GlobalClusterSpec{
+				Provider:                provider,
+				Region:                  "us-east-1",
+				PrimaryDBClusterName:    "primary",
+				SecondaryDBClusterNames: []string{"secondary"},
+				FailoverToDBClusterName: failoverTo,
+			},
+		}
+	}
+	availableState := func(members ...v1alpha1.GlobalClusterMemberState) *v1alpha1.GlobalClusterState {
+		return &v1alpha1.GlobalClusterState{Exists: true, Status: "available", Arn: "arn:aws:rds::123456789012:global-cluster:default-global",
+			Members: members}
+	}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		mockCloudDB *factory.MockCloudDB
+		want        controllerruntime.Result
+		wantErr     bool
+		wantPhase   v1alpha1.Phase
+		wantMembers []v1alpha1.GlobalClusterMemberStatus
+	}{
+		{
+			name: "when global cluster does not exist and the primary is available, should create it and requeue",
+			objects: []runtime.Object{providerSecret, globalCluster(""),
+				dbCluster("primary", "us-east-1", primaryArn, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{GlobalClusterStateResp: &v1alpha1.GlobalClusterState{}},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantPhase:   v1alpha1.Creating,
+		},
+		{
+			name: "when global cluster does not exist and the primary is not available, should wait for it",
+			objects: []runtime.Object{providerSecret, globalCluster(""),
+				dbCluster("primary", "us-east-1", "", v1alpha1.Creating)},
+			mockCloudDB: &factory.MockCloudDB{GlobalClusterStateResp: &v1alpha1.GlobalClusterState{},
+				CreateGlobalClusterErr: errors.New("should not be called")},
+			want: controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+		},
+		{
+			name: "when create fails, should error",
+			objects: []runtime.Object{providerSecret, globalCluster(""),
+				dbCluster("primary", "us-east-1", primaryArn, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{GlobalClusterStateResp: &v1alpha1.GlobalClusterState{},
+				CreateGlobalClusterErr: errors.New("create failed")},
+			want:    controllerruntime.Result{},
+			wantErr: true,
+		},
+		{
+			name: "when the secondary did not join yet, should wait for it",
+			objects: []runtime.Object{providerSecret, globalCluster(""),
+				dbCluster("primary", "us-east-1", primaryArn, v1alpha1.Available),
+				dbCluster("secondary", "us-west-2", "", v1alpha1.Creating)},
+			mockCloudDB: &factory.MockCloudDB{GlobalClusterStateResp: availableState(
+				v1alpha1.GlobalClusterMemberState{DBClusterArn: primaryArn, IsWriter: true})},
+			want: controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantMembers: []v1alpha1.GlobalClusterMemberStatus{
+				{DBClusterName: "primary", DBClusterArn: primaryArn, Role: v1alpha1.GlobalClusterPrimary},
+			},
+		},
+		{
+			name: "when all members joined, should be available and report member roles",
+			objects: []runtime.Object{providerSecret, globalCluster(""),
+				dbCluster("primary", "us-east-1", primaryArn, v1alpha1.Available),
+				dbCluster("secondary", "us-west-2", secondaryArn, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{GlobalClusterStateResp: availableState(
+				v1alpha1.GlobalClusterMemberState{DBClusterArn: primaryArn, IsWriter: true},
+				v1alpha1.GlobalClusterMemberState{DBClusterArn: secondaryArn})},
+			want:      controllerruntime.Result{},
+			wantPhase: v1alpha1.Available,
+			wantMembers: []v1alpha1.GlobalClusterMemberStatus{
+				{DBClusterName: "primary", DBClusterArn: primaryArn, Role: v1alpha1.GlobalClusterPrimary},
+				{DBClusterName: "secondary", DBClusterArn: secondaryArn, Role: v1alpha1.GlobalClusterSecondary},
+			},
+		},
+		{
+			name: "when failoverToDBClusterName is not the writer, should fail over and requeue",
+			objects: []runtime.Object{providerSecret, globalCluster("secondary"),
+				dbCluster("primary", "us-east-1", primaryArn, v1alpha1.Available),
+				dbCluster("secondary", "us-west-2", secondaryArn, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{GlobalClusterStateResp: availableState(
+				v1alpha1.GlobalClusterMemberState{DBClusterArn: primaryArn, IsWriter: true},
+				v1alpha1.GlobalClusterMemberState{DBClusterArn: secondaryArn})},
+			want:      controllerruntime.Result{Requeue: true, RequeueAfter: 30 * time.Second},
+			wantPhase: v1alpha1.Updating,
+			wantMembers: []v1alpha1.GlobalClusterMemberStatus{
+				{DBClusterName: "primary", DBClusterArn: primaryArn, Role: v1alpha1.GlobalClusterPrimary},
+				{DBClusterName: "secondary", DBClusterArn: secondaryArn, Role: v1alpha1.GlobalClusterSecondary},
+			},
+		},
+		{
+			name: "when failoverToDBClusterName is already the writer, should be available",
+			objects: []runtime.Object{providerSecret, globalCluster("secondary"),
+				dbCluster("primary", "us-east-1", primaryArn, v1alpha1.Available),
+				dbCluster("secondary", "us-west-2", secondaryArn, v1alpha1.Available)},
+			mockCloudDB: &factory.MockCloudDB{GlobalClusterStateResp: availableState(
+				v1alpha1.GlobalClusterMemberState{DBClusterArn: primaryArn},
+				v1alpha1.GlobalClusterMemberState{DBClusterArn: secondaryArn, IsWriter: true}),
+				FailoverGlobalClusterErr: errors.New("should not be called")},
+			want:      controllerruntime.Result{},
+			wantPhase: v1alpha1.Available,
+			wantMembers: []v1alpha1.GlobalClusterMemberStatus{
+				{DBClusterName: "primary", DBClusterArn: primaryArn, Role: v1alpha1.GlobalClusterSecondary},
+				{DBClusterName: "secondary", DBClusterArn: secondaryArn, Role: v1alpha1.GlobalClusterPrimary},
+			},
+		},
+		{
+			name:        "when failoverToDBClusterName is not a member, should error",
+			objects:     []runtime.Object{providerSecret, globalCluster("other")},
+			mockCloudDB: &factory.MockCloudDB{},
+			want:        controllerruntime.Result{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &GlobalClusterReconciler{
+				Client:           fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: tt.mockCloudDB,
+			}
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "global"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			cr := &v1alpha1.GlobalCluster{}
+			if err := r.Client.Get(context.Background(), req.NamespacedName, cr); err != nil {
+				t.Fatalf("failed to get GlobalCluster: %v", err)
+			}
+			if cr.Status.Phase != tt.wantPhase {
+				t.Errorf("Reconcile() phase = %v, want %v", cr.Status.Phase, tt.wantPhase)
+			}
+			if !reflect.DeepEqual(cr.Status.Members, tt.wantMembers) {
+				t.Errorf("Reconcile() members = %v, want %v", cr.Status.Members, tt.wantMembers)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"time"
+)
+
+// how often an observed cloud resource is described again
+const observeOnlyRequeueAfter = 5 * time.Minute
+
+// observeOnly reports the state of a cloud resource the operator must not change. On deletion the
+// finalizer is released without touching the cloud resource.
+func observeOnly(dbStatus *v1alpha1.DBStatus, finalizer string, object v1alpha1.ConditionedObject, c client.Client) (ctrl.Result, error) {
+	if object.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, utils.RemoveFinalizer(finalizer, c, object)
+	}
+	phase := object.GetPhase()
+	if dbStatus.Exists && dbStatus.CurrentPhase == string(v1alpha1.Available) {
+		setAvailableConditions(dbStatus, object)
+		phase = v1alpha1.Available
+	} else if !dbStatus.Exists {
+		utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse,
+			v1alpha1.ReasonNotFound, "cloud resource does not exist", object)
+	}
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue, v1alpha1.ReasonObserveOnly,
+		"managementPolicy is ObserveOnly, the cloud resource is never changed", object)
+	return ctrl.Result{RequeueAfter: observeOnlyRequeueAfter}, utils.UpdateStatusPhase(phase, object, c)
+}
+
+// cloudResourceOwner returns the object owning an existing cloud resource according to its owner tag
+func cloudResourceOwner(dbStatus *v1alpha1.DBStatus) string {
+	if !dbStatus.Exists {
+		return ""
+	}
+	return dbStatus.Tags[v1alpha1.OwnerTagKey]
+}
+
+func ownershipConflictError(resourceID, owner string) error {
+	return utils.ErrOwnershipConflict{Message: fmt.Sprintf(
+		"%s is owned by %s, refusing to manage it", resourceID, owner)}
+}
+
+func untaggedResourceError(resourceID string) error {
+	return utils.ErrOwnershipConflict{Message: fmt.Sprintf(
+		"%s already exists and has no owner tag, set managementPolicy to Adopt to take it over", resourceID)}
+}
+
+// tagOwner marks an existing cloud resource as owned by the object
+func tagOwner(dbStatus *v1alpha1.DBStatus, owner string, cloudDB factory.CloudDB) error {
+	return cloudDB.AddTagsToResource(dbStatus.Arn, map[string]string{v1alpha1.OwnerTagKey: owner})
+}
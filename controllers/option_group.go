@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolveOptionGroupRef returns the cloud name of the referenced OptionGroup once it was created
+func resolveOptionGroupRef(namespace string, ref *v1.LocalObjectReference, c client.Client) (string, error) {
+	optionGroup := &v1alpha1.OptionGroup{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, optionGroup); err != nil {
+		return "", err
+	}
+	if optionGroup.Status.OptionGroupName == "" {
+		return "", utils.ErrOptionGroupNotAvailable{Message: fmt.Sprintf("%s/%s - optiongroup is not created yet",
+			namespace, ref.Name)}
+	}
+	return optionGroup.Status.OptionGroupName, nil
+}
@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+	"time"
+)
+
+func TestOptionGroupReconciler_Reconcile(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+	timeNow := metav1.Now()
+
+	providerSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "aws-provider-secret",
+		},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+			"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+	optionGroup := func(deletionTimestamp *metav1.Time) *v1alpha1.OptionGroup {
+		return &v1alpha1.OptionGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "sqlserver-options",
+				Namespace:         "default",
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{optionGroupFinalizer},
+			},
+			Spec: v1alpha1.OptionGroupSpec{
+				Provider: v1alpha1.ProviderConfig{
+					Type:      "aws",
+					SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "default"},
+				},
+				Region:             "us-east-1",
+				EngineName:         "sqlserver-se",
+				MajorEngineVersion: "15.00",
+				Description:        "test",
+				Options: []v1alpha1.Option{{
+					Name:     "SQLSERVER_BACKUP_RESTORE",
+					Settings: map[string]string{"IAM_ROLE_ARN": "arn:aws:iam::123456789012:role/backup"},
+				}},
+			},
+		}
+	}
+	existing := func(engineName string, options ...v1alpha1.OptionState) *v1alpha1.OptionGroupState {
+		return &v1alpha1.OptionGroupState{Exists: true, EngineName: engineName, MajorEngineVersion: "15.00",
+			Description: "test", Options: options}
+	}
+	backupRestore := func(roleArn string) v1alpha1.OptionState {
+		return v1alpha1.OptionState{
+			OptionStatus: v1alpha1.OptionStatus{Name: "SQLSERVER_BACKUP_RESTORE"},
+			Settings:     map[string]string{"IAM_ROLE_ARN": roleArn},
+		}
+	}
+	roleArn := "arn:aws:iam::123456789012:role/backup"
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		mockCloudDB *factory.MockCloudDB
+		want        controllerruntime.Result
+		wantErr     bool
+		wantPhase   v1alpha1.Phase
+		wantOptions []v1alpha1.OptionStatus
+		wantDeleted bool
+	}{
+		{
+			name:        "when option group does not exist, should create it and requeue",
+			objects:     []runtime.Object{providerSecret, optionGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: &v1alpha1.OptionGroupState{}},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Creating,
+		},
+		{
+			name:    "when options match the spec, should be available and keep permanent options",
+			objects: []runtime.Object{providerSecret, optionGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: existing("sqlserver-se", backupRestore(roleArn),
+				v1alpha1.OptionState{OptionStatus: v1alpha1.OptionStatus{Name: "TDE", Permanent: true, Persistent: true}}),
+				ModifyOptionGroupErr: errors.New("should not be called")},
+			want:      controllerruntime.Result{},
+			wantPhase: v1alpha1.Available,
+			wantOptions: []v1alpha1.OptionStatus{
+				{Name: "SQLSERVER_BACKUP_RESTORE"},
+				{Name: "TDE", Permanent: true, Persistent: true},
+			},
+		},
+		{
+			name:        "when an option is missing, should add it and requeue",
+			objects:     []runtime.Object{providerSecret, optionGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: existing("sqlserver-se")},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Updating,
+		},
+		{
+			name:    "when an option setting differs, should modify it and requeue",
+			objects: []runtime.Object{providerSecret, optionGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: existing("sqlserver-se",
+				backupRestore("arn:aws:iam::123456789012:role/old"))},
+			want:        controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase:   v1alpha1.Updating,
+			wantOptions: []v1alpha1.OptionStatus{{Name: "SQLSERVER_BACKUP_RESTORE"}},
+		},
+		{
+			name:    "when an option was removed from the spec, should remove it and requeue",
+			objects: []runtime.Object{providerSecret, optionGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: existing("sqlserver-se", backupRestore(roleArn),
+				v1alpha1.OptionState{OptionStatus: v1alpha1.OptionStatus{Name: "SQLSERVER_AUDIT"}})},
+			want:      controllerruntime.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+			wantPhase: v1alpha1.Updating,
+			wantOptions: []v1alpha1.OptionStatus{
+				{Name: "SQLSERVER_BACKUP_RESTORE"},
+				{Name: "SQLSERVER_AUDIT"},
+			},
+		},
+		{
+			name:    "when modify fails, should error",
+			objects: []runtime.Object{providerSecret, optionGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: existing("sqlserver-se"),
+				ModifyOptionGroupErr: errors.New("option requires a port")},
+			want:    controllerruntime.Result{},
+			wantErr: true,
+		},
+		{
+			name:        "when the engine differs from the spec, should error",
+			objects:     []runtime.Object{providerSecret, optionGroup(nil)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: existing("sqlserver-ee", backupRestore(roleArn))},
+			want:        controllerruntime.Result{},
+			wantErr:     true,
+		},
+		{
+			name:        "when marked for deletion, should delete and remove the finalizer",
+			objects:     []runtime.Object{providerSecret, optionGroup(&timeNow)},
+			mockCloudDB: &factory.MockCloudDB{OptionGroupStateResp: existing("sqlserver-se", backupRestore(roleArn))},
+			want:        controllerruntime.Result{},
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &OptionGroupReconciler{
+				Client:           fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: tt.mockCloudDB,
+			}
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "sqlserver-options"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			cr := &v1alpha1.OptionGroup{}
+			if err := r.Client.Get(context.Background(), req.NamespacedName, cr); err != nil {
+				if !tt.wantDeleted {
+					t.Fatalf("failed to get OptionGroup: %v", err)
+				}
+				return
+			}
+			if tt.wantDeleted {
+				if len(cr.GetFinalizers()) != 0 {
+					t.Errorf("Reconcile() finalizers = %v, want none", cr.GetFinalizers())
+				}
+				return
+			}
+			if cr.Status.Phase != tt.wantPhase {
+				t.Errorf("Reconcile() phase = %v, want %v", cr.Status.Phase, tt.wantPhase)
+			}
+			if !reflect.DeepEqual(cr.Status.Options, tt.wantOptions) {
+				t.Errorf("Reconcile() options = %v, want %v", cr.Status.Options, tt.wantOptions)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// dbParameterGroupRefIndexKey and dbClusterParameterGroupRefIndexKey index db instances and db clusters by
+// the parameter group they reference, so a parameter group event only enqueues the objects using it
+const (
+	dbParameterGroupRefIndexKey        = "spec.dbParameterGroupRef"
+	dbClusterParameterGroupRefIndexKey = "spec.dbClusterParameterGroupRef"
+)
+
+func dbInstanceParameterGroupRef(object client.Object) []string {
+	dbInstance, ok := object.(*v1alpha1.DBInstance)
+	if !ok || dbInstance.Spec.DBParameterGroupRef == nil {
+		return nil
+	}
+	return []string{dbInstance.Spec.DBParameterGroupRef.Name}
+}
+
+func dbClusterParameterGroupRef(object client.Object) []string {
+	dbCluster, ok := object.(*v1alpha1.DBCluster)
+	if !ok || dbCluster.Spec.DBClusterParameterGroupRef == nil {
+		return nil
+	}
+	return []string{dbCluster.Spec.DBClusterParameterGroupRef.Name}
+}
+
+// parameterGroupEventHandlerFunc maps a parameter group event to reconcile requests for every object in
+// newList's kind that references the parameter group, using the indexKey field index.
+func parameterGroupEventHandlerFunc(c client.Client, log logr.Logger, indexKey string, newList func() client.ObjectList) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		list := newList()
+		result, err := listRequests(c, list, client.InNamespace(object.GetNamespace()),
+			client.MatchingFields{indexKey: object.GetName()})
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to list %T referencing parameter group %s/%s", list, object.GetNamespace(), object.GetName()))
+		}
+		return result
+	}
+}
+
+// parameterGroupChangedPredicate passes parameter group updates that change the spec or the phase, the phase
+// goes back to available once the parameters were modified so the referencing objects pick up the new apply status
+func parameterGroupChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(event event.UpdateEvent) bool {
+			if event.ObjectOld.GetGeneration() != event.ObjectNew.GetGeneration() {
+				return true
+			}
+			oldObject, isOldConditioned := event.ObjectOld.(v1alpha1.ConditionedObject)
+			newObject, isNewConditioned := event.ObjectNew.(v1alpha1.ConditionedObject)
+			return isOldConditioned && isNewConditioned && oldObject.GetPhase() != newObject.GetPhase()
+		},
+	}
+}
+
+// parameterApplyStatusChangedPredicate passes db instance and db cluster updates that change the
+// apply status of their parameter group
+func parameterApplyStatusChangedPredicate(applyStatus func(object client.Object) string) predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event event.CreateEvent) bool { return false },
+		UpdateFunc: func(event event.UpdateEvent) bool {
+			return applyStatus(event.ObjectOld) != applyStatus(event.ObjectNew)
+		},
+	}
+}
+
+// referencedParameterGroupRequest maps a db instance or db cluster to its referenced parameter group
+func referencedParameterGroupRequest(ref func(object client.Object) []string) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		var result []reconcile.Request
+		for _, name := range ref(object) {
+			result = append(result, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: name},
+			})
+		}
+		return result
+	}
+}
+
+func dbInstanceParameterApplyStatus(object client.Object) string {
+	if dbInstance, ok := object.(*v1alpha1.DBInstance); ok && dbInstance.Status.CloudResource != nil {
+		return dbInstance.Status.CloudResource.ParameterApplyStatus
+	}
+	return ""
+}
+
+func dbClusterParameterApplyStatus(object client.Object) string {
+	if dbCluster, ok := object.(*v1alpha1.DBCluster); ok && dbCluster.Status.CloudResource != nil {
+		return dbCluster.Status.CloudResource.ParameterApplyStatus
+	}
+	return ""
+}
+
+// indexParameterGroupRefs registers the indexKey field index for the given kind
+func indexParameterGroupRefs(mgr ctrl.Manager, object client.Object, indexKey string, extractValue client.IndexerFunc) error {
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), object, indexKey, extractValue)
+}
+
+// parameterGroupInSync is true when none of the apply statuses reported by the referencing objects is
+// waiting on a reboot or still applying, objects that do not exist yet do not report one
+func parameterGroupInSync(applyStatuses []string) bool {
+	for _, applyStatus := range applyStatuses {
+		if applyStatus != "" && applyStatus != "in-sync" {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveDBParameterGroupRef returns the cloud name of the referenced DBParameterGroup once it was created
+func resolveDBParameterGroupRef(namespace string, ref *v1.LocalObjectReference, c client.Client) (string, error) {
+	parameterGroup := &v1alpha1.DBParameterGroup{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, parameterGroup); err != nil {
+		return "", err
+	}
+	if parameterGroup.Status.DBParameterGroupName == "" {
+		return "", utils.ErrParameterGroupNotAvailable{Message: fmt.Sprintf("%s/%s - dbparametergroup is not created yet",
+			namespace, ref.Name)}
+	}
+	return parameterGroup.Status.DBParameterGroupName, nil
+}
+
+// resolveDBClusterParameterGroupRef returns the cloud name of the referenced DBClusterParameterGroup once it was created
+func resolveDBClusterParameterGroupRef(namespace string, ref *v1.LocalObjectReference, c client.Client) (string, error) {
+	parameterGroup := &v1alpha1.DBClusterParameterGroup{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, parameterGroup); err != nil {
+		return "", err
+	}
+	if parameterGroup.Status.DBClusterParameterGroupName == "" {
+		return "", utils.ErrParameterGroupNotAvailable{Message: fmt.Sprintf("%s/%s - dbclusterparametergroup is not created yet",
+			namespace, ref.Name)}
+	}
+	return parameterGroup.Status.DBClusterParameterGroupName, nil
+}
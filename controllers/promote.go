@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"time"
+)
+
+// promoteDBInstance promotes the read replica backing cr and records the promotion, after which spec.replicaOf
+// is ignored. An instance that is no longer a replica in the cloud provider is only recorded as promoted.
+func (r *DBInstanceReconciler) promoteDBInstance(cr *v1alpha1.DBInstance, instanceStatus *v1alpha1.DBStatus, cloudDB factory.CloudDB) (ctrl.Result, error) {
+	source := instanceStatus.ReplicaSourceIdentifier
+	if source != "" {
+		r.Log.Info(fmt.Sprintf("%s/%s - promoting read replica of %s", cr.GetNamespace(), cr.GetName(), source))
+		if err := cloudDB.PromoteDBInstanceReadReplica(cr); err != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonPromoteFailed, err, cr, r.Client)
+		}
+	}
+	cr.Status.PromotedAt = &metav1.Time{Time: time.Now()}
+	cr.Status.Replication = nil
+	setPromotedConditions(source, cr)
+	return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+}
+
+// promoteDBCluster promotes the replica db cluster backing cr and records the promotion, after which
+// spec.replicationSourceIdentifier is ignored
+func (r *DBClusterReconciler) promoteDBCluster(cr *v1alpha1.DBCluster, dbStatus *v1alpha1.DBStatus, cloudDB factory.CloudDB) (ctrl.Result, error) {
+	source := dbStatus.ReplicaSourceIdentifier
+	if source != "" {
+		r.Log.Info(fmt.Sprintf("%s/%s - promoting replica of %s", cr.GetNamespace(), cr.GetName(), source))
+		if err := cloudDB.PromoteDBClusterReadReplica(cr); err != nil {
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonPromoteFailed, err, cr, r.Client)
+		}
+	}
+	cr.Status.PromotedAt = &metav1.Time{Time: time.Now()}
+	cr.Status.ReplicationSourceIdentifier = ""
+	setPromotedConditions(source, cr)
+	return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, utils.UpdateStatusPhase(v1alpha1.Updating, cr, r.Client)
+}
+
+func setPromotedConditions(source string, object v1alpha1.ConditionedObject) {
+	message := "not a replica, recorded as promoted"
+	if source != "" {
+		message = fmt.Sprintf("promotion from %s requested", source)
+	}
+	setInProgressConditions(v1alpha1.ReasonPromoting, message, object)
+}
+
+// promoteAnnotationChanged lets annotation only updates through the generation based predicates
+func promoteAnnotationChanged(e event.UpdateEvent) bool {
+	return e.ObjectOld.GetAnnotations()[v1alpha1.PromoteAnnotation] != e.ObjectNew.GetAnnotations()[v1alpha1.PromoteAnnotation]
+}
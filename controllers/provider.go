@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"strconv"
+)
+
+// resolveProvider returns the options to build the cloud client of an object from its provider config,
+// region wins over the default region of a referenced Provider. The options carry the resolved region and
+// the default tags to the client, the spec of the object is never changed. The local provider needs no secret nor region.
+func resolveProvider(config v1alpha1.ProviderConfig, region string, c client.Client) (factory.ProviderOptions, error) {
+	if err := config.Validate(); err != nil {
+		return factory.ProviderOptions{}, err
+	}
+	if config.Name == "" && config.Type == v1alpha1.Local {
+		return factory.ProviderOptions{Type: config.Type, Name: "local", Region: region, KubeClient: c}, nil
+	}
+	if config.Name == "" {
+		secret, err := utils.GetSecret(config.SecretRef.Name, config.SecretRef.Namespace, c)
+		if err != nil {
+			return factory.ProviderOptions{}, err
+		}
+		if region == "" {
+			return factory.ProviderOptions{}, fmt.Errorf("region is required when provider.name is not set")
+		}
+		return factory.ProviderOptions{
+			Type:        config.Type,
+			Name:        fmt.Sprintf("secret/%s/%s", config.SecretRef.Namespace, config.SecretRef.Name),
+			Version:     secret.GetResourceVersion(),
+			Credentials: secret.Data,
+			Region:      region,
+			KubeClient:  c,
+		}, nil
+	}
+
+	provider := &v1alpha1.Provider{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: config.Name}, provider); err != nil {
+		return factory.ProviderOptions{}, err
+	}
+	options, err := providerOptions(provider, c)
+	if err != nil {
+		return factory.ProviderOptions{}, err
+	}
+	if region != "" {
+		options.Region = region
+	}
+	if options.Region == "" && options.Type != v1alpha1.Local {
+		return factory.ProviderOptions{}, fmt.Errorf("region is required, provider %s has no default region", config.Name)
+	}
+	return options, nil
+}
+
+// providerOptions returns the options to build a cloud client with the credentials of a Provider
+func providerOptions(provider *v1alpha1.Provider, c client.Client) (factory.ProviderOptions, error) {
+	if err := provider.Validate(); err != nil {
+		return factory.ProviderOptions{}, err
+	}
+	// a changed spec, like new default tags, replaces the cached client
+	options := factory.ProviderOptions{
+		Type:        provider.Spec.Type,
+		Name:        fmt.Sprintf("provider/%s", provider.GetName()),
+		Version:     strconv.FormatInt(provider.GetGeneration(), 10),
+		Region:      provider.Spec.Region,
+		DefaultTags: provider.Spec.DefaultTags,
+		KubeClient:  c,
+	}
+	if provider.UsesInjectedIdentity() || provider.Spec.Type == v1alpha1.Local {
+		return options, nil
+	}
+	secretRef := provider.Spec.Credentials.SecretRef
+	secret, err := utils.GetSecret(secretRef.Name, secretRef.Namespace, c)
+	if err != nil {
+		return factory.ProviderOptions{}, err
+	}
+	// a changed secret replaces the cached client
+	options.Version = fmt.Sprintf("%s/%s", options.Version, secret.GetResourceVersion())
+	options.Credentials = secret.Data
+	return options, nil
+}
+
+// cloudDBFor returns the client of the resolved provider. It is created on every reconcile because objects of one
+// kind can use different providers and regions, the factory caches one client per provider and region.
+// injected is returned when set, tests use it to replace the cloud provider.
+func cloudDBFor(injected factory.CloudDB, logger logr.Logger, options factory.ProviderOptions) (factory.CloudDB, error) {
+	if injected != nil {
+		return injected, nil
+	}
+	return factory.NewCloudDB(logger, options)
+}
+
+// providerSecretRefs returns the secret index value of an inline provider secret, objects referencing a Provider
+// are found through providerNameIndexKey instead
+func providerSecretRefs(config v1alpha1.ProviderConfig) []string {
+	if config.Name != "" || config.SecretRef.Name == "" {
+		return nil
+	}
+	return []string{secretIndexValue(config.SecretRef.Namespace, config.SecretRef.Name)}
+}
+
+// providerNameIndexKey indexes db instances and db clusters by the Provider they reference, so a change to
+// the Provider or to its credentials secret only enqueues the objects using it
+const providerNameIndexKey = "spec.provider.name"
+
+func dbInstanceProviderName(object client.Object) []string {
+	dbInstance, ok := object.(*v1alpha1.DBInstance)
+	if !ok || dbInstance.Spec.Provider.Name == "" {
+		return nil
+	}
+	return []string{dbInstance.Spec.Provider.Name}
+}
+
+func dbClusterProviderName(object client.Object) []string {
+	dbCluster, ok := object.(*v1alpha1.DBCluster)
+	if !ok || dbCluster.Spec.Provider.Name == "" {
+		return nil
+	}
+	return []string{dbCluster.Spec.Provider.Name}
+}
+
+// indexProviderName registers the providerNameIndexKey field index for the given kind
+func indexProviderName(mgr ctrl.Manager, object client.Object, extractValue client.IndexerFunc) error {
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), object, providerNameIndexKey, extractValue)
+}
+
+// providerEventHandlerFunc maps a Provider event to reconcile requests for every object in newList's kind
+// that references the Provider, using the providerNameIndexKey field index.
+func providerEventHandlerFunc(c client.Client, log logr.Logger, newList func() client.ObjectList) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		list := newList()
+		result, err := listRequests(c, list, client.MatchingFields{providerNameIndexKey: object.GetName()})
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to list %T referencing provider %s", list, object.GetName()))
+		}
+		return result
+	}
+}
+
+// providerSecretsEventHandlerFunc maps a secret event to reconcile requests for every object in newList's kind
+// that references a Provider whose credentials are in the secret. Providers are indexed by their credentials
+// secret under secretRefsIndexKey by the provider controller.
+func providerSecretsEventHandlerFunc(c client.Client, log logr.Logger, newList func() client.ObjectList) handler.MapFunc {
+	providerRequests := providerEventHandlerFunc(c, log, newList)
+	return func(object client.Object) []reconcile.Request {
+		providers := &v1alpha1.ProviderList{}
+		if err := c.List(context.TODO(), providers, client.MatchingFields{
+			secretRefsIndexKey: secretIndexValue(object.GetNamespace(), object.GetName()),
+		}); err != nil {
+			log.Error(err, fmt.Sprintf("Failed to list providers referencing secret %s/%s", object.GetNamespace(), object.GetName()))
+			return nil
+		}
+		var result []reconcile.Request
+		for i := range providers.Items {
+			result = append(result, providerRequests(&providers.Items[i])...)
+		}
+		return result
+	}
+}
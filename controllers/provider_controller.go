@@ -0,0 +1,149 @@
+/*
+Copyright 2021 agill17.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1 "github.com/agill17/db-operator/api/v1alpha1"
+)
+
+const (
+	// providerRecheckInterval is how often the credentials of an available provider are checked again,
+	// so revoked or expired credentials show up in its status
+	providerRecheckInterval = 10 * time.Minute
+	// defaultAWSIdentityRegion is used to look up the account of aws providers without a default region
+	defaultAWSIdentityRegion = "us-east-1"
+)
+
+// ProviderReconciler reconciles a Provider object
+type ProviderReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// CloudDBInterface replaces the client built from the provider of every object, only tests set it
+	CloudDBInterface factory.CloudDB
+}
+
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=providers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=providers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=agill.apps.db-operator,resources=providers/finalizers,verbs=update
+// Reconcile checks the credentials of the provider against the cloud provider and reports the account they resolve to.
+// Providers own no cloud resource, so there is nothing to clean up on delete.
+func (r *ProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("provider", req.NamespacedName)
+	name := req.NamespacedName.Name
+	cr := &v1alpha1.Provider{}
+	if errGettingCr := r.Client.Get(context.TODO(), req.NamespacedName, cr); errGettingCr != nil {
+		if errors.IsNotFound(errGettingCr) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errGettingCr
+	}
+	if cr.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if errValidating := cr.Validate(); errValidating != nil {
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionSynced, v1alpha1.ReasonInvalidSpec, errValidating, cr, r.Client)
+	}
+
+	// get provider secret
+	options, errGettingSecret := providerOptions(cr, r.Client)
+	if errGettingSecret != nil {
+		cr.Status.AccountID = ""
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderSecretError, errGettingSecret, cr, r.Client)
+	}
+	if options.Region == "" && options.Type == v1alpha1.AWS {
+		options.Region = defaultAWSIdentityRegion
+	}
+
+	// create a cloud client per provider, the credentials differ between providers
+	cloudDB := r.CloudDBInterface
+	if cloudDB == nil {
+		cloudDBInterface, err := factory.NewCloudDB(r.Log, options)
+		if err != nil {
+			cr.Status.AccountID = ""
+			return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+				v1alpha1.ReasonProviderClientError, err, cr, r.Client)
+		}
+		cloudDB = cloudDBInterface
+	}
+
+	accountID, errGettingAccount := cloudDB.GetAccountID()
+	if errGettingAccount != nil {
+		cr.Status.AccountID = ""
+		return ctrl.Result{}, utils.RecordError(v1alpha1.ConditionCredentialsValid,
+			v1alpha1.ReasonProviderClientError, errGettingAccount, cr, r.Client)
+	}
+	cr.Status.AccountID = accountID
+
+	utils.SetCondition(v1alpha1.ConditionCredentialsValid, metav1.ConditionTrue,
+		v1alpha1.ReasonCredentialsAccepted, "cloud provider accepted the credentials", cr)
+	utils.SetCondition(v1alpha1.ConditionSynced, metav1.ConditionTrue,
+		v1alpha1.ReasonUpToDate, "credentials were checked against the cloud provider", cr)
+	utils.SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonAvailable,
+		fmt.Sprintf("credentials resolve to account %s", accountID), cr)
+	if errUpdatingStatus := utils.UpdateStatusPhase(v1alpha1.Available, cr, r.Client); errUpdatingStatus != nil {
+		return ctrl.Result{}, errUpdatingStatus
+	}
+	r.Log.Info(fmt.Sprintf("%s - reconciled", name))
+	return ctrl.Result{RequeueAfter: providerRecheckInterval}, nil
+}
+
+// providerCredentialsSecretRefs returns the secret index value of the credentials secret of a Provider
+func providerCredentialsSecretRefs(object client.Object) []string {
+	provider, ok := object.(*v1alpha1.Provider)
+	if !ok || provider.UsesInjectedIdentity() || provider.Spec.Credentials.SecretRef == nil {
+		return nil
+	}
+	secretRef := provider.Spec.Credentials.SecretRef
+	return []string{secretIndexValue(secretRef.Namespace, secretRef.Name)}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := indexSecretRefs(mgr, &v1alpha1.Provider{}, providerCredentialsSecretRefs); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Provider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&source.Kind{Type: &v1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(secretsEventHandlerFunc(r.Client, r.Log, func() client.ObjectList {
+				return &v1alpha1.ProviderList{}
+			})),
+		).
+		Complete(r)
+}
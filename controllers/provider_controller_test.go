@@ -0,0 +1,328 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func TestProviderReconciler_Reconcile(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	providerSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "db-operator",
+			Name:      "aws-provider-secret",
+		},
+		Data: map[string][]byte{
+			"AWS_ACCESS_KEY_ID":     []byte("fake-id"),
+			"AWS_SECRET_ACCESS_KEY": []byte("fake-access-key"),
+		},
+		Type: v1.SecretTypeOpaque,
+	}
+	provider := func(credentials v1alpha1.ProviderCredentials) *v1alpha1.Provider {
+		return &v1alpha1.Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-prod"},
+			Spec: v1alpha1.ProviderSpec{
+				Type:        "aws",
+				Region:      "us-east-1",
+				Credentials: credentials,
+			},
+			Status: v1alpha1.ProviderStatus{AccountID: "111111111111"},
+		}
+	}
+	fromSecret := v1alpha1.ProviderCredentials{
+		Source:    v1alpha1.CredentialsSourceSecret,
+		SecretRef: &v1.SecretReference{Name: "aws-provider-secret", Namespace: "db-operator"},
+	}
+
+	tests := []struct {
+		name          string
+		objects       []runtime.Object
+		mockCloudDB   *factory.MockCloudDB
+		want          controllerruntime.Result
+		wantErr       bool
+		wantPhase     v1alpha1.Phase
+		wantAccountID string
+		wantReady     metav1.ConditionStatus
+	}{
+		{
+			name:          "when the credentials are accepted, should report the account and be available",
+			objects:       []runtime.Object{providerSecret, provider(fromSecret)},
+			mockCloudDB:   &factory.MockCloudDB{AccountIDResp: "123456789012"},
+			want:          controllerruntime.Result{RequeueAfter: providerRecheckInterval},
+			wantPhase:     v1alpha1.Available,
+			wantAccountID: "123456789012",
+			wantReady:     metav1.ConditionTrue,
+		},
+		{
+			name: "when the provider uses the injected identity, should not need a secret",
+			objects: []runtime.Object{provider(v1alpha1.ProviderCredentials{
+				Source: v1alpha1.CredentialsSourceInjectedIdentity,
+			})},
+			mockCloudDB:   &factory.MockCloudDB{AccountIDResp: "123456789012"},
+			want:          controllerruntime.Result{RequeueAfter: providerRecheckInterval},
+			wantPhase:     v1alpha1.Available,
+			wantAccountID: "123456789012",
+			wantReady:     metav1.ConditionTrue,
+		},
+		{
+			name:        "when the credentials secret does not exist, should error and clear the account",
+			objects:     []runtime.Object{provider(fromSecret)},
+			mockCloudDB: &factory.MockCloudDB{AccountIDResp: "123456789012"},
+			want:        controllerruntime.Result{},
+			wantErr:     true,
+			wantReady:   metav1.ConditionFalse,
+		},
+		{
+			name:        "when the cloud provider rejects the credentials, should error and clear the account",
+			objects:     []runtime.Object{providerSecret, provider(fromSecret)},
+			mockCloudDB: &factory.MockCloudDB{AccountIDErr: errors.New("invalid client token id")},
+			want:        controllerruntime.Result{},
+			wantErr:     true,
+			wantReady:   metav1.ConditionFalse,
+		},
+		{
+			name: "when the credentials source is Secret without a secretRef, should error",
+			objects: []runtime.Object{provider(v1alpha1.ProviderCredentials{
+				Source: v1alpha1.CredentialsSourceSecret,
+			})},
+			mockCloudDB:   &factory.MockCloudDB{AccountIDResp: "123456789012"},
+			want:          controllerruntime.Result{},
+			wantErr:       true,
+			wantAccountID: "111111111111",
+			wantReady:     metav1.ConditionFalse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ProviderReconciler{
+				Client:           fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:              logf.Log,
+				Scheme:           testScheme,
+				CloudDBInterface: tt.mockCloudDB,
+			}
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Name: "aws-prod"}}
+			got, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reconcile() got = %v, want %v", got, tt.want)
+			}
+			cr := &v1alpha1.Provider{}
+			if err := r.Client.Get(context.Background(), req.NamespacedName, cr); err != nil {
+				t.Fatalf("failed to get Provider: %v", err)
+			}
+			if cr.Status.Phase != tt.wantPhase {
+				t.Errorf("Reconcile() phase = %v, want %v", cr.Status.Phase, tt.wantPhase)
+			}
+			if cr.Status.AccountID != tt.wantAccountID {
+				t.Errorf("Reconcile() accountID = %v, want %v", cr.Status.AccountID, tt.wantAccountID)
+			}
+			if ready := meta.FindStatusCondition(cr.Status.Conditions, v1alpha1.ConditionReady); ready == nil || ready.Status != tt.wantReady {
+				t.Errorf("Reconcile() Ready = %v, want %v", ready, tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestResolveProvider(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	providerSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "db-operator", Name: "aws-provider-secret", ResourceVersion: "7"},
+		Data:       map[string][]byte{"AWS_ROLE_ARN": []byte("arn:aws:iam::123456789012:role/db-operator")},
+	}
+	provider := &v1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-prod"},
+		Spec: v1alpha1.ProviderSpec{
+			Type:   "aws",
+			Region: "us-east-1",
+			Credentials: v1alpha1.ProviderCredentials{
+				Source:    v1alpha1.CredentialsSourceSecret,
+				SecretRef: &v1.SecretReference{Name: "aws-provider-secret", Namespace: "db-operator"},
+			},
+			DefaultTags: map[string]string{"environment": "prod"},
+		},
+	}
+	noRegionProvider := &v1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-irsa"},
+		Spec: v1alpha1.ProviderSpec{
+			Type:        "aws",
+			Credentials: v1alpha1.ProviderCredentials{Source: v1alpha1.CredentialsSourceInjectedIdentity},
+		},
+	}
+	localProvider := &v1alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: "local"},
+		Spec:       v1alpha1.ProviderSpec{Type: "local", DefaultTags: map[string]string{"environment": "dev"}},
+	}
+	c := fake.NewFakeClientWithScheme(testScheme, providerSecret, provider, noRegionProvider, localProvider)
+
+	tests := []struct {
+		name    string
+		config  v1alpha1.ProviderConfig
+		region  string
+		want    factory.ProviderOptions
+		wantErr bool
+	}{
+		{
+			name: "inline provider uses its secret and the region of the object",
+			config: v1alpha1.ProviderConfig{
+				Type:      "aws",
+				SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "db-operator"},
+			},
+			region: "eu-west-1",
+			want: factory.ProviderOptions{
+				Type:        "aws",
+				Name:        "secret/db-operator/aws-provider-secret",
+				Version:     "7",
+				Credentials: providerSecret.Data,
+				Region:      "eu-west-1",
+			},
+		},
+		{
+			name: "inline provider without a region is an error",
+			config: v1alpha1.ProviderConfig{
+				Type:      "aws",
+				SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "db-operator"},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "referenced provider defaults the region and the tags",
+			config: v1alpha1.ProviderConfig{Name: "aws-prod"},
+			want: factory.ProviderOptions{
+				Type:        "aws",
+				Name:        "provider/aws-prod",
+				Version:     "0/7",
+				Credentials: providerSecret.Data,
+				Region:      "us-east-1",
+				DefaultTags: map[string]string{"environment": "prod"},
+			},
+		},
+		{
+			name:   "region of the object wins over the default region",
+			config: v1alpha1.ProviderConfig{Name: "aws-prod"},
+			region: "us-west-2",
+			want: factory.ProviderOptions{
+				Type:        "aws",
+				Name:        "provider/aws-prod",
+				Version:     "0/7",
+				Credentials: providerSecret.Data,
+				Region:      "us-west-2",
+				DefaultTags: map[string]string{"environment": "prod"},
+			},
+		},
+		{
+			name:   "referenced provider with the injected identity has no credentials",
+			config: v1alpha1.ProviderConfig{Name: "aws-irsa"},
+			region: "us-west-2",
+			want:   factory.ProviderOptions{Type: "aws", Name: "provider/aws-irsa", Version: "0", Region: "us-west-2"},
+		},
+		{
+			name:    "no region on the object nor the provider is an error",
+			config:  v1alpha1.ProviderConfig{Name: "aws-irsa"},
+			wantErr: true,
+		},
+		{
+			name:    "missing provider is an error",
+			config:  v1alpha1.ProviderConfig{Name: "does-not-exist"},
+			region:  "us-east-1",
+			wantErr: true,
+		},
+		{
+			name:   "inline local provider needs no secret nor region",
+			config: v1alpha1.ProviderConfig{Type: "local"},
+			want:   factory.ProviderOptions{Type: "local", Name: "local"},
+		},
+		{
+			name:   "referenced local provider needs no secret nor region",
+			config: v1alpha1.ProviderConfig{Name: "local"},
+			want:   factory.ProviderOptions{Type: "local", Name: "provider/local", Version: "0", DefaultTags: map[string]string{"environment": "dev"}},
+		},
+		{
+			name:    "inline cloud provider without a secret is an error",
+			config:  v1alpha1.ProviderConfig{Type: "gcp"},
+			region:  "us-central1",
+			wantErr: true,
+		},
+		{
+			name: "name and an inline secret together are an error",
+			config: v1alpha1.ProviderConfig{
+				Name:      "aws-prod",
+				SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "db-operator"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveProvider(tt.config, tt.region, c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// resolved options always carry the client, the local provider uses it
+			if !tt.wantErr {
+				tt.want.KubeClient = c
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveProvider() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// regionCloudDB records the objects a client was asked for, the factory builds one per provider and region
+type regionCloudDB struct {
+	factory.MockCloudDB
+	requested []string
+}
+
+func (r *regionCloudDB) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error) {
+	r.requested = append(r.requested, input.GetName())
+	return r.MockCloudDB.DBInstanceExists(input)
+}
+
+func (r *regionCloudDB) DBSubnetGroupExists(name string) (*v1alpha1.DBSubnetGroupState, error) {
+	r.requested = append(r.requested, name)
+	return r.MockCloudDB.DBSubnetGroupExists(name)
+}
+
+const multiRegionProvider v1alpha1.ProviderType = "multi-region-test"
+
+// multiRegionClients are the clients built by the multiRegionProvider, keyed by provider name and region
+var multiRegionClients = map[string]*regionCloudDB{}
+
+func init() {
+	factory.Register(multiRegionProvider, factory.Capabilities{Clusters: true, Snapshots: true, Replicas: true, Serverless: true,
+		SubnetGroups: true, ParameterGroups: true, OptionGroups: true},
+		func(logger logr.Logger, options factory.ProviderOptions) (factory.Provider, error) {
+			key := options.Name + "/" + options.Region
+			if _, ok := multiRegionClients[key]; !ok {
+				multiRegionClients[key] = &regionCloudDB{MockCloudDB: factory.MockCloudDB{
+					DBSubnetGroupStateResp: &v1alpha1.DBSubnetGroupState{},
+					DBInstanceStatusResp:   &v1alpha1.DBStatus{},
+				}}
+			}
+			return multiRegionClients[key], nil
+		})
+}
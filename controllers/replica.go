@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// resolveReplicaSource returns the identifier and, for cross region replicas, the region of the db instance
+// a read replica is created from. A referenced DBInstance must be available, and its ARN is used when it
+// lives in another region than region, the resolved region of the replica.
+func (r *DBInstanceReconciler) resolveReplicaSource(cr *v1alpha1.DBInstance, region string) (string, string, error) {
+	replicaOf := cr.Spec.ReplicaOf
+	if err := replicaOf.Validate(); err != nil {
+		return "", "", err
+	}
+	if replicaOf.SourceIdentifier != "" {
+		return replicaOf.SourceIdentifier, replicaOf.SourceRegion, nil
+	}
+	source, err := r.getReplicaSource(cr)
+	if err != nil {
+		return "", "", err
+	}
+	if source.Status.Phase != v1alpha1.Available || source.Status.CloudResource == nil {
+		return "", "", utils.ErrReplicaSourceNotAvailable{Message: fmt.Sprintf("%s/%s - dbinstance is not available yet",
+			source.GetNamespace(), source.GetName())}
+	}
+	sourceRegion := source.Spec.Region
+	if sourceRegion == "" {
+		// the source uses the default region of its provider
+		sourceProvider, err := resolveProvider(source.Spec.Provider, sourceRegion, r.Client)
+		if err != nil {
+			return "", "", err
+		}
+		sourceRegion = sourceProvider.Region
+	}
+	if sourceRegion == region {
+		return source.GetDBInstanceID(), "", nil
+	}
+	return source.Status.CloudResource.Arn, sourceRegion, nil
+}
+
+func (r *DBInstanceReconciler) getReplicaSource(cr *v1alpha1.DBInstance) (*v1alpha1.DBInstance, error) {
+	source := &v1alpha1.DBInstance{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: cr.GetNamespace(),
+		Name: cr.Spec.ReplicaOf.DBInstanceRef.Name}, source)
+	return source, err
+}
+
+// replicaPassword returns the master password a read replica inherits from its source, read from the password
+// secret of the referenced DBInstance or from passwordRef. Replicas never generate or rotate a password.
+func (r *DBInstanceReconciler) replicaPassword(cr *v1alpha1.DBInstance) (string, error) {
+	owner := cr
+	if cr.Spec.ReplicaOf.DBInstanceRef != nil {
+		source, err := r.getReplicaSource(cr)
+		if err != nil {
+			return "", err
+		}
+		owner = source
+	} else if cr.IsPasswordGenerated() {
+		return "", nil
+	}
+	secretName, key := owner.GetPasswordSecretNameAndKey()
+	password, _, err := utils.GetSecretValue(secretName, owner.GetNamespace(), key, r.Client)
+	return password, err
+}
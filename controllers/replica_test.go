@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"github.com/agill17/db-operator/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"testing"
+)
+
+func TestDBInstanceReconciler_resolveReplicaSource(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+
+	source := func(region string, phase v1alpha1.Phase) *v1alpha1.DBInstance {
+		return &v1alpha1.DBInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "default"},
+			Spec:       v1alpha1.DBInstanceSpec{Region: region, Engine: "mysql"},
+			Status: v1alpha1.DBInstanceStatus{
+				Phase:         phase,
+				CloudResource: &v1alpha1.CloudResourceStatus{Arn: "arn:aws:rds:" + region + ":123456789012:db:default-primary"},
+			},
+		}
+	}
+	replica := func(replicaOf *v1alpha1.ReplicaOf) *v1alpha1.DBInstance {
+		return &v1alpha1.DBInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "replica", Namespace: "default"},
+			Spec:       v1alpha1.DBInstanceSpec{Region: "us-east-1", Engine: "mysql", ReplicaOf: replicaOf},
+		}
+	}
+	sourceRef := &v1alpha1.ReplicaOf{DBInstanceRef: &v1.LocalObjectReference{Name: "primary"}}
+
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		cr         *v1alpha1.DBInstance
+		wantID     string
+		wantRegion string
+		wantErr    bool
+	}{
+		{
+			name:       "source identifier is used as is",
+			cr:         replica(&v1alpha1.ReplicaOf{SourceIdentifier: "arn:aws:rds:us-west-2:123456789012:db:legacy", SourceRegion: "us-west-2"}),
+			wantID:     "arn:aws:rds:us-west-2:123456789012:db:legacy",
+			wantRegion: "us-west-2",
+		},
+		{
+			name:    "source in the same region uses the identifier",
+			objects: []runtime.Object{source("us-east-1", v1alpha1.Available)},
+			cr:      replica(sourceRef),
+			wantID:  "default-primary",
+		},
+		{
+			name:       "source in another region uses the arn",
+			objects:    []runtime.Object{source("us-west-2", v1alpha1.Available)},
+			cr:         replica(sourceRef),
+			wantID:     "arn:aws:rds:us-west-2:123456789012:db:default-primary",
+			wantRegion: "us-west-2",
+		},
+		{
+			name:    "source not available yet",
+			objects: []runtime.Object{source("us-east-1", v1alpha1.Creating)},
+			cr:      replica(sourceRef),
+			wantErr: true,
+		},
+		{
+			name:    "both source identifier and reference",
+			cr:      replica(&v1alpha1.ReplicaOf{SourceIdentifier: "legacy", DBInstanceRef: &v1.LocalObjectReference{Name: "primary"}}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DBInstanceReconciler{
+				Client: fake.NewFakeClientWithScheme(testScheme, tt.objects...),
+				Log:    logf.Log,
+				Scheme: testScheme,
+			}
+			gotID, gotRegion, err := r.resolveReplicaSource(tt.cr, tt.cr.Spec.Region)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveReplicaSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotID != tt.wantID || gotRegion != tt.wantRegion {
+				t.Errorf("resolveReplicaSource() = %q, %q, want %q, %q", gotID, gotRegion, tt.wantID, tt.wantRegion)
+			}
+		})
+	}
+}
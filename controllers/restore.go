@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/factory"
+	"github.com/agill17/db-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolveDBSnapshotID returns the snapshot identifier a DBInstance is restored from,
+// resolving restoreFrom.snapshotRef to the identifier of an available DBSnapshot
+func resolveDBSnapshotID(restoreFrom *v1alpha1.RestoreFrom, namespace string, c client.Client) (string, error) {
+	if restoreFrom.SnapshotIdentifier != "" {
+		return restoreFrom.SnapshotIdentifier, nil
+	}
+	snapshot := &v1alpha1.DBSnapshot{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: restoreFrom.SnapshotRef.Name}, snapshot); err != nil {
+		return "", err
+	}
+	if snapshot.Status.Phase != v1alpha1.Available {
+		return "", utils.ErrSnapshotNotAvailable{Message: fmt.Sprintf("%s/%s - dbsnapshot is not available yet",
+			namespace, restoreFrom.SnapshotRef.Name)}
+	}
+	return snapshot.GetDBSnapshotID(), nil
+}
+
+// resolveDBClusterSnapshotID returns the snapshot identifier a DBCluster is restored from,
+// resolving restoreFrom.snapshotRef to the identifier of an available DBClusterSnapshot
+func resolveDBClusterSnapshotID(restoreFrom *v1alpha1.RestoreFrom, namespace string, c client.Client) (string, error) {
+	if restoreFrom.SnapshotIdentifier != "" {
+		return restoreFrom.SnapshotIdentifier, nil
+	}
+	snapshot := &v1alpha1.DBClusterSnapshot{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: restoreFrom.SnapshotRef.Name}, snapshot); err != nil {
+		return "", err
+	}
+	if snapshot.Status.Phase != v1alpha1.Available {
+		return "", utils.ErrSnapshotNotAvailable{Message: fmt.Sprintf("%s/%s - dbclustersnapshot is not available yet",
+			namespace, restoreFrom.SnapshotRef.Name)}
+	}
+	return snapshot.GetDBClusterSnapshotID(), nil
+}
+
+// restoreDBInstance requests the restore configured in spec.restoreFrom and records the source
+// in status, it returns a description of what was requested
+func (r *DBInstanceReconciler) restoreDBInstance(cr *v1alpha1.DBInstance, cloudDB factory.CloudDB) (string, error) {
+	restoreFrom := cr.Spec.RestoreFrom
+	if err := restoreFrom.Validate(); err != nil {
+		return "", err
+	}
+	if pointInTime := restoreFrom.PointInTime; pointInTime != nil {
+		restoreTime, err := cloudDB.RestoreDBInstanceToPointInTime(cr, pointInTime)
+		if err != nil {
+			return "", err
+		}
+		cr.Status.RestoredFrom = pointInTime.SourceIdentifier
+		cr.Status.RestoreTime = restoreTime
+		return fmt.Sprintf("restore of %s to %s requested", pointInTime.SourceIdentifier, restoreTime.UTC()), nil
+	}
+	snapshotID, err := resolveDBSnapshotID(restoreFrom, cr.GetNamespace(), r.Client)
+	if err != nil {
+		return "", err
+	}
+	if err := cloudDB.RestoreDBInstanceFromSnapshot(cr, snapshotID); err != nil {
+		return "", err
+	}
+	cr.Status.RestoredFrom = snapshotID
+	return fmt.Sprintf("restore from snapshot %s requested", snapshotID), nil
+}
+
+// restoreDBCluster requests the restore configured in spec.restoreFrom and records the source
+// in status, it returns a description of what was requested
+func (r *DBClusterReconciler) restoreDBCluster(cr *v1alpha1.DBCluster, cloudDB factory.CloudDB) (string, error) {
+	restoreFrom := cr.Spec.RestoreFrom
+	if err := restoreFrom.Validate(); err != nil {
+		return "", err
+	}
+	if pointInTime := restoreFrom.PointInTime; pointInTime != nil {
+		restoreTime, err := cloudDB.RestoreDBClusterToPointInTime(cr, pointInTime)
+		if err != nil {
+			return "", err
+		}
+		cr.Status.RestoredFrom = pointInTime.SourceIdentifier
+		cr.Status.RestoreTime = restoreTime
+		return fmt.Sprintf("restore of %s to %s requested", pointInTime.SourceIdentifier, restoreTime.UTC()), nil
+	}
+	snapshotID, err := resolveDBClusterSnapshotID(restoreFrom, cr.GetNamespace(), r.Client)
+	if err != nil {
+		return "", err
+	}
+	if err := cloudDB.RestoreDBClusterFromSnapshot(cr, snapshotID); err != nil {
+		return "", err
+	}
+	cr.Status.RestoredFrom = snapshotID
+	return fmt.Sprintf("restore from snapshot %s requested", snapshotID), nil
+}
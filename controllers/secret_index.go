@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// secretRefsIndexKey indexes objects by the "namespace/name" of every secret they reference,
+// so a secret event only enqueues the objects using that secret instead of listing all of them.
+const secretRefsIndexKey = "spec.secretRefs"
+
+func secretIndexValue(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+func dbInstanceSecretRefs(object client.Object) []string {
+	dbInstance, ok := object.(*v1alpha1.DBInstance)
+	if !ok {
+		return nil
+	}
+	refs := providerSecretRefs(dbInstance.Spec.Provider)
+	// instances that are part of a db cluster do not use a master password
+	if dbInstance.Spec.DBClusterID == "" {
+		passSecretName, _ := dbInstance.GetPasswordSecretNameAndKey()
+		refs = append(refs, secretIndexValue(dbInstance.GetNamespace(), passSecretName))
+	}
+	return refs
+}
+
+func dbClusterSecretRefs(object client.Object) []string {
+	dbCluster, ok := object.(*v1alpha1.DBCluster)
+	if !ok {
+		return nil
+	}
+	passSecretName, _ := dbCluster.GetPasswordSecretNameAndKey()
+	return append(providerSecretRefs(dbCluster.Spec.Provider), secretIndexValue(dbCluster.GetNamespace(), passSecretName))
+}
+
+// indexSecretRefs registers the secretRefsIndexKey field index for the given kind
+func indexSecretRefs(mgr ctrl.Manager, object client.Object, extractValue client.IndexerFunc) error {
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), object, secretRefsIndexKey, extractValue)
+}
+
+// secretsEventHandlerFunc maps a secret event to reconcile requests for every object in
+// newList's kind that references the secret, using the secretRefsIndexKey field index.
+func secretsEventHandlerFunc(c client.Client, log logr.Logger, newList func() client.ObjectList) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		list := newList()
+		result, err := listRequests(c, list, client.MatchingFields{
+			secretRefsIndexKey: secretIndexValue(object.GetNamespace(), object.GetName()),
+		})
+		if err != nil {
+			log.Error(err, fmt.Sprintf("Failed to list %T referencing secret %s/%s", list, object.GetNamespace(), object.GetName()))
+		}
+		return result
+	}
+}
+
+// listRequests returns a reconcile request for every item of list matching opts
+func listRequests(c client.Client, list client.ObjectList, opts ...client.ListOption) ([]reconcile.Request, error) {
+	var result []reconcile.Request
+	if err := c.List(context.TODO(), list, opts...); err != nil {
+		return result, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return result, err
+	}
+	for _, item := range items {
+		o, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()},
+		})
+	}
+	return result, nil
+}
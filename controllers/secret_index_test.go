@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"context"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"testing"
+)
+
+func TestSecretRefs(t *testing.T) {
+	provider := v1alpha1.ProviderConfig{
+		Type:      "aws",
+		SecretRef: v1.SecretReference{Name: "aws-provider-secret", Namespace: "operator"},
+	}
+	passwordRef := &v1alpha1.PasswordRef{
+		PasswordKey: "password",
+		SecretRef:   &v1.LocalObjectReference{Name: "db-password"},
+	}
+	tests := []struct {
+		name      string
+		indexFunc client.IndexerFunc
+		object    client.Object
+		want      []string
+	}{
+		{
+			name:      "dbinstance with passwordRef",
+			indexFunc: dbInstanceSecretRefs,
+			object: &v1alpha1.DBInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec:       v1alpha1.DBInstanceSpec{Provider: provider, PasswordRef: passwordRef},
+			},
+			want: []string{"operator/aws-provider-secret", "default/db-password"},
+		},
+		{
+			name:      "dbinstance with generated password",
+			indexFunc: dbInstanceSecretRefs,
+			object: &v1alpha1.DBInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec:       v1alpha1.DBInstanceSpec{Provider: provider},
+			},
+			want: []string{"operator/aws-provider-secret", "default/db-master-password"},
+		},
+		{
+			name:      "dbinstance part of a dbcluster",
+			indexFunc: dbInstanceSecretRefs,
+			object: &v1alpha1.DBInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec:       v1alpha1.DBInstanceSpec{Provider: provider, DBClusterID: "cluster"},
+			},
+			want: []string{"operator/aws-provider-secret"},
+		},
+		{
+			name:      "dbinstance referencing a provider",
+			indexFunc: dbInstanceSecretRefs,
+			object: &v1alpha1.DBInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec:       v1alpha1.DBInstanceSpec{Provider: v1alpha1.ProviderConfig{Name: "aws-prod"}, PasswordRef: passwordRef},
+			},
+			want: []string{"default/db-password"},
+		},
+		{
+			name:      "dbcluster",
+			indexFunc: dbClusterSecretRefs,
+			object: &v1alpha1.DBCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+				Spec:       v1alpha1.DBClusterSpec{Provider: provider, PasswordRef: passwordRef},
+			},
+			want: []string{"operator/aws-provider-secret", "default/db-password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.indexFunc(tt.object); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("secretRefs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderName(t *testing.T) {
+	tests := []struct {
+		name      string
+		indexFunc client.IndexerFunc
+		object    client.Object
+		want      []string
+	}{
+		{
+			name:      "dbinstance referencing a provider",
+			indexFunc: dbInstanceProviderName,
+			object: &v1alpha1.DBInstance{
+				Spec: v1alpha1.DBInstanceSpec{Provider: v1alpha1.ProviderConfig{Name: "aws-prod"}},
+			},
+			want: []string{"aws-prod"},
+		},
+		{
+			name:      "dbinstance with an inline provider",
+			indexFunc: dbInstanceProviderName,
+			object: &v1alpha1.DBInstance{
+				Spec: v1alpha1.DBInstanceSpec{Provider: v1alpha1.ProviderConfig{Type: "local"}},
+			},
+		},
+		{
+			name:      "dbcluster referencing a provider",
+			indexFunc: dbClusterProviderName,
+			object: &v1alpha1.DBCluster{
+				Spec: v1alpha1.DBClusterSpec{Provider: v1alpha1.ProviderConfig{Name: "aws-prod"}},
+			},
+			want: []string{"aws-prod"},
+		},
+		{
+			name:      "wrong kind",
+			indexFunc: dbClusterProviderName,
+			object:    &v1alpha1.DBInstance{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.indexFunc(tt.object); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("providerName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// indexedClient answers List with MatchingFields from indexers like the cache of the manager, the fake
+// client has no field indexes
+type indexedClient struct {
+	client.Client
+	indexers map[string]client.IndexerFunc
+}
+
+func (c indexedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := (&client.ListOptions{}).ApplyOptions(opts)
+	if listOptions.FieldSelector == nil {
+		return c.Client.List(ctx, list, opts...)
+	}
+	if err := c.Client.List(ctx, list, client.InNamespace(listOptions.Namespace)); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	var matching []runtime.Object
+	for _, item := range items {
+		matches := true
+		for _, requirement := range listOptions.FieldSelector.Requirements() {
+			values := c.indexers[requirement.Field](item.(client.Object))
+			if found, _ := utils.ListContainsString(values, requirement.Value); !found {
+				matches = false
+			}
+		}
+		if matches {
+			matching = append(matching, item)
+		}
+	}
+	return meta.SetList(list, matching)
+}
+
+func TestProviderEventHandlers(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(testScheme)
+	v1alpha1.AddToScheme(testScheme)
+	c := indexedClient{
+		Client: fake.NewFakeClientWithScheme(testScheme,
+			&v1alpha1.Provider{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-prod"},
+				Spec: v1alpha1.ProviderSpec{Type: v1alpha1.AWS, Credentials: v1alpha1.ProviderCredentials{
+					SecretRef: &v1.SecretReference{Name: "aws-prod-credentials", Namespace: "operator"},
+				}},
+			},
+			&v1alpha1.DBInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "uses-provider", Namespace: "default"},
+				Spec:       v1alpha1.DBInstanceSpec{Provider: v1alpha1.ProviderConfig{Name: "aws-prod"}},
+			},
+			&v1alpha1.DBInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "inline", Namespace: "default"},
+				Spec: v1alpha1.DBInstanceSpec{Provider: v1alpha1.ProviderConfig{
+					Type:      v1alpha1.AWS,
+					SecretRef: v1.SecretReference{Name: "aws-prod-credentials", Namespace: "operator"},
+				}},
+			},
+		),
+		indexers: map[string]client.IndexerFunc{
+			secretRefsIndexKey: func(object client.Object) []string {
+				if _, ok := object.(*v1alpha1.Provider); ok {
+					return providerCredentialsSecretRefs(object)
+				}
+				return dbInstanceSecretRefs(object)
+			},
+			providerNameIndexKey: dbInstanceProviderName,
+		},
+	}
+	newList := func() client.ObjectList { return &v1alpha1.DBInstanceList{} }
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "uses-provider", Namespace: "default"}}}
+
+	if got := providerEventHandlerFunc(c, logf.Log, newList)(&v1alpha1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "aws-prod"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("providerEventHandlerFunc() = %v, want %v", got, want)
+	}
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "aws-prod-credentials", Namespace: "operator"}}
+	if got := providerSecretsEventHandlerFunc(c, logf.Log, newList)(secret); !reflect.DeepEqual(got, want) {
+		t.Errorf("providerSecretsEventHandlerFunc() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resolveDBSubnetGroupRef returns the cloud name of the referenced DBSubnetGroup once it is available
+func resolveDBSubnetGroupRef(namespace string, ref *v1.LocalObjectReference, c client.Client) (string, error) {
+	subnetGroup := &v1alpha1.DBSubnetGroup{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: ref.Name}, subnetGroup); err != nil {
+		return "", err
+	}
+	if subnetGroup.Status.Phase != v1alpha1.Available {
+		return "", utils.ErrDBSubnetGroupNotAvailable{Message: fmt.Sprintf("%s/%s - dbsubnetgroup is not available yet",
+			namespace, ref.Name)}
+	}
+	return subnetGroup.GetDBSubnetGroupName(), nil
+}
@@ -3,14 +3,15 @@ module github.com/agill17/db-operator
 go 1.16
 
 require (
-	github.com/aws/aws-sdk-go v1.38.36
+	github.com/aws/aws-sdk-go v1.44.100
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/go-logr/logr v0.3.0
-	github.com/google/go-cmp v0.5.2 // indirect
+	github.com/google/go-cmp v0.5.2
 	github.com/hashicorp/vault/api v1.1.0
 	github.com/onsi/ginkgo v1.14.1
 	github.com/onsi/gomega v1.10.2
 	github.com/spf13/cast v1.3.0
+	golang.org/x/oauth2 v0.0.0-20191202225959-858c2ad4c8b6
 	k8s.io/api v0.19.2
 	k8s.io/apimachinery v0.19.2
 	k8s.io/client-go v0.19.2
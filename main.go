@@ -36,6 +36,7 @@ import (
 
 	agillappsdboperatorv1alpha1 "github.com/agill17/db-operator/api/v1alpha1"
 	"github.com/agill17/db-operator/controllers"
+	"github.com/agill17/db-operator/pkg/factory"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -113,6 +114,80 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBCluster")
 		os.Exit(1)
 	}
+	if err = (&controllers.DBSnapshotReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DBSnapshot"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBSnapshot")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBClusterSnapshotReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DBClusterSnapshot"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBClusterSnapshot")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBActionReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DBAction"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBAction")
+		os.Exit(1)
+	}
+	if err = (&controllers.GlobalClusterReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("GlobalCluster"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GlobalCluster")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBSubnetGroupReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DBSubnetGroup"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBSubnetGroup")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBParameterGroupReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DBParameterGroup"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBParameterGroup")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBClusterParameterGroupReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("DBClusterParameterGroup"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBClusterParameterGroup")
+		os.Exit(1)
+	}
+	if err = (&controllers.OptionGroupReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("OptionGroup"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OptionGroup")
+		os.Exit(1)
+	}
+	if err = (&controllers.ProviderReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Provider"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Provider")
+		os.Exit(1)
+	}
+	// the webhook rejects inline provider types that are not registered or lack a feature the spec uses
+	agillappsdboperatorv1alpha1.ProviderFeatureValidator = factory.ValidateFeatures
 	if err = (&agillappsdboperatorv1alpha1.DBInstance{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "DBInstance")
 		os.Exit(1)
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// BackfillDBInstanceSpec fills the spec fields left empty from the live db instance. The spec cannot
+// tell an unset boolean from false, so boolean fields that are false in the spec take the live value.
+func (i InternalAwsClients) BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error {
+	out, err := i.rdsClient.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(input.GetDBInstanceID()),
+	})
+	if err != nil {
+		return err
+	}
+	if len(out.DBInstances) == 0 {
+		return awserr.New(rds.ErrCodeDBInstanceNotFoundFault,
+			fmt.Sprintf("DBInstance %s not found", input.GetDBInstanceID()), nil)
+	}
+	live := out.DBInstances[0]
+	spec := &input.Spec
+	fillString(&spec.Engine, live.Engine)
+	fillString(&spec.EngineVersion, live.EngineVersion)
+	fillString(&spec.DBInstanceClass, live.DBInstanceClass)
+	fillString(&spec.MasterUsername, live.MasterUsername)
+	fillString(&spec.DBName, live.DBName)
+	fillString(&spec.StorageType, live.StorageType)
+	fillString(&spec.LicenseModel, live.LicenseModel)
+	fillString(&spec.KmsKeyId, live.KmsKeyId)
+	fillString(&spec.DBClusterID, live.DBClusterIdentifier)
+	fillInt64(&spec.AllocatedStorage, live.AllocatedStorage)
+	fillInt64(&spec.Iops, live.Iops)
+	fillInt64(&spec.Port, live.DbInstancePort)
+	fillInt64(&spec.BackupRetentionPeriod, live.BackupRetentionPeriod)
+	if !aws.BoolValue(live.MultiAZ) {
+		fillString(&spec.AvailabilityZone, live.AvailabilityZone)
+	}
+	if live.DBSubnetGroup != nil && spec.DBSubnetGroupRef == nil {
+		fillString(&spec.DBSubnetGroupName, live.DBSubnetGroup.DBSubnetGroupName)
+	}
+	if len(live.DBParameterGroups) > 0 && spec.DBParameterGroupRef == nil {
+		fillString(&spec.DBParameterGroupName, live.DBParameterGroups[0].DBParameterGroupName)
+	}
+	if len(live.OptionGroupMemberships) > 0 && spec.OptionGroupRef == nil {
+		fillString(&spec.OptionGroupName, live.OptionGroupMemberships[0].OptionGroupName)
+	}
+	if len(spec.VpcSecurityGroupIds) == 0 {
+		for _, sg := range live.VpcSecurityGroups {
+			spec.VpcSecurityGroupIds = append(spec.VpcSecurityGroupIds, aws.StringValue(sg.VpcSecurityGroupId))
+		}
+	}
+	if len(spec.CloudwatchLogsExports) == 0 {
+		spec.CloudwatchLogsExports = aws.StringValueSlice(live.EnabledCloudwatchLogsExports)
+	}
+	if len(spec.Tags) == 0 {
+		spec.Tags = i.userTags(rdsTagsToMap(live.TagList), v1alpha1.OwnerTagKey)
+	}
+	fillBool(&spec.AutoMinorVersionUpgrade, live.AutoMinorVersionUpgrade)
+	fillBool(&spec.CopyTagsToSnapshot, live.CopyTagsToSnapshot)
+	fillBool(&spec.DeletionProtection, live.DeletionProtection)
+	fillBool(&spec.EnablePerformanceInsights, live.PerformanceInsightsEnabled)
+	fillBool(&spec.MultiAZ, live.MultiAZ)
+	fillBool(&spec.PubliclyAccessible, live.PubliclyAccessible)
+	fillBool(&spec.StorageEncrypted, live.StorageEncrypted)
+	return nil
+}
+
+// BackfillDBClusterSpec fills the spec fields left empty from the live db cluster. The spec cannot
+// tell an unset boolean from false, so boolean fields that are false in the spec take the live value.
+func (i InternalAwsClients) BackfillDBClusterSpec(input *v1alpha1.DBCluster) error {
+	out, err := i.rdsClient.DescribeDBClusters(&rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(input.GetDBClusterID()),
+	})
+	if err != nil {
+		return err
+	}
+	if len(out.DBClusters) == 0 {
+		return awserr.New(rds.ErrCodeDBClusterNotFoundFault,
+			fmt.Sprintf("DBCluster %s not found", input.GetDBClusterID()), nil)
+	}
+	live := out.DBClusters[0]
+	spec := &input.Spec
+	fillString(&spec.Engine, live.Engine)
+	fillString(&spec.EngineMode, live.EngineMode)
+	fillString(&spec.EngineVersion, live.EngineVersion)
+	fillString(&spec.MasterUsername, live.MasterUsername)
+	fillString(&spec.DatabaseName, live.DatabaseName)
+	if spec.DBClusterParameterGroupRef == nil {
+		fillString(&spec.DBClusterParameterGroupName, live.DBClusterParameterGroup)
+	}
+	if spec.DBSubnetGroupRef == nil {
+		fillString(&spec.DBSubnetGroupName, live.DBSubnetGroup)
+	}
+	fillString(&spec.KmsKeyId, live.KmsKeyId)
+	fillString(&spec.PreferredBackupWindow, live.PreferredBackupWindow)
+	fillString(&spec.PreferredMaintenanceWindow, live.PreferredMaintenanceWindow)
+	fillInt64(&spec.Port, live.Port)
+	fillInt64(&spec.BackupRetentionPeriod, live.BackupRetentionPeriod)
+	if len(spec.AvailabilityZones) == 0 {
+		spec.AvailabilityZones = aws.StringValueSlice(live.AvailabilityZones)
+	}
+	if len(spec.VpcSecurityGroupIds) == 0 {
+		for _, sg := range live.VpcSecurityGroups {
+			spec.VpcSecurityGroupIds = append(spec.VpcSecurityGroupIds, aws.StringValue(sg.VpcSecurityGroupId))
+		}
+	}
+	if len(spec.EnableCloudwatchLogsExports) == 0 {
+		spec.EnableCloudwatchLogsExports = aws.StringValueSlice(live.EnabledCloudwatchLogsExports)
+	}
+	if len(spec.Tags) == 0 {
+		spec.Tags = i.userTags(rdsTagsToMap(live.TagList), v1alpha1.OwnerTagKey)
+	}
+	fillBool(&spec.CopyTagsToSnapshot, live.CopyTagsToSnapshot)
+	fillBool(&spec.DeletionProtection, live.DeletionProtection)
+	fillBool(&spec.EnableHttpEndpoint, live.HttpEndpointEnabled)
+	fillBool(&spec.StorageEncrypted, live.StorageEncrypted)
+	return nil
+}
+
+func fillString(dst *string, live *string) {
+	if *dst == "" {
+		*dst = aws.StringValue(live)
+	}
+}
+
+func fillInt64(dst *int64, live *int64) {
+	if *dst == 0 {
+		*dst = aws.Int64Value(live)
+	}
+}
+
+// fillBool takes the live value when dst is false, an explicit false in the spec included
+func fillBool(dst *bool, live *bool) {
+	if !*dst {
+		*dst = aws.BoolValue(live)
+	}
+}
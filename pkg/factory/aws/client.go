@@ -7,10 +7,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/go-logr/logr"
 	"os"
 	"strings"
@@ -24,9 +28,17 @@ const (
 type InternalAwsClients struct {
 	rdsClient    rdsiface.RDSAPI
 	smClient     secretsmanageriface.SecretsManagerAPI
+	cwClient     cloudwatchiface.CloudWatchAPI
+	stsClient    stsiface.STSAPI
 	cacheKeyName string
+	// cacheVersion is the version of the provider the cached client was created for
+	cacheVersion string
 	creds        *credentials.Credentials
 	logger       logr.Logger
+	// region the client was created for
+	region string
+	// defaultTags of the provider, put on every resource together with its own tags
+	defaultTags map[string]string
 }
 
 const (
@@ -42,14 +54,23 @@ func getAwsClientCacheKey(pName, region string) string {
 	return fmt.Sprintf("aws-%v-%v", pName, region)
 }
 
-func NewInternalAwsClient(logger logr.Logger, region string, pName string, providerCredentials map[string][]byte) (*InternalAwsClients, error) {
+// NewInternalAwsClient returns the cached client of the provider pName in region, a client cached for another
+// pVersion is replaced so the cache holds one client per provider and region.
+func NewInternalAwsClient(logger logr.Logger, region string, pName, pVersion string, providerCredentials map[string][]byte,
+	defaultTags map[string]string) (*InternalAwsClients, error) {
 	cacheKeyName := getAwsClientCacheKey(pName, region)
 	cachedInternalAwsClient, ok := awsClientCache.Load(cacheKeyName)
-	if !ok {
+	if !ok || cachedInternalAwsClient.(*InternalAwsClients).cacheVersion != pVersion {
 		sess := session.Must(session.NewSession())
-		creds, err := getAwsCredentials(providerCredentials)
-		if err != nil {
-			return nil, err
+		// without provider credentials the default chain of the session is used, which picks up
+		// the identity injected in the operator pod
+		creds := sess.Config.Credentials
+		if providerCredentials != nil {
+			var err error
+			creds, err = getAwsCredentials(providerCredentials)
+			if err != nil {
+				return nil, err
+			}
 		}
 		awsClientCfg := &aws.Config{
 			CredentialsChainVerboseErrors: aws.Bool(true),
@@ -64,9 +85,14 @@ func NewInternalAwsClient(logger logr.Logger, region string, pName string, provi
 		r := &InternalAwsClients{
 			rdsClient:    rds.New(sess, awsClientCfg),
 			smClient:     secretsmanager.New(sess, awsClientCfg),
+			cwClient:     cloudwatch.New(sess, awsClientCfg),
+			stsClient:    sts.New(sess, awsClientCfg),
 			cacheKeyName: cacheKeyName,
+			cacheVersion: pVersion,
 			creds:        creds,
 			logger:       logger,
+			region:       region,
+			defaultTags:  defaultTags,
 		}
 		// cache key is deleted when access key id is no longer valid
 		awsClientCache.Store(cacheKeyName, r)
@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -16,10 +17,36 @@ const (
 )
 
 func (i InternalAwsClients) CreateDBCluster(input *v1alpha1.DBCluster, password string) error {
-	_, errCreating := i.rdsClient.CreateDBCluster(createDBClusterInput(input, password))
+	_, errCreating := i.rdsClient.CreateDBCluster(createDBClusterInput(input, password, i.cloudTags(input.GetCloudTags())))
 	return errCreating
 }
 
+func (i InternalAwsClients) RestoreDBClusterFromSnapshot(input *v1alpha1.DBCluster, snapshotID string) error {
+	_, errRestoring := i.rdsClient.RestoreDBClusterFromSnapshot(restoreDBClusterFromSnapshotInput(input, snapshotID, i.cloudTags(input.GetCloudTags())))
+	return errRestoring
+}
+
+// RestoreDBClusterToPointInTime restores the source db cluster and returns the restore time that was used.
+// The latest restorable time is looked up beforehand so the exact time can be recorded.
+func (i InternalAwsClients) RestoreDBClusterToPointInTime(input *v1alpha1.DBCluster, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error) {
+	restoreTime := pointInTime.RestoreTime
+	if pointInTime.UseLatestRestorableTime {
+		out, err := i.rdsClient.DescribeDBClusters(&rds.DescribeDBClustersInput{
+			DBClusterIdentifier: aws.String(pointInTime.SourceIdentifier),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.DBClusters) == 0 || out.DBClusters[0].LatestRestorableTime == nil {
+			return nil, ErrNoLatestRestorableTime{Message: fmt.Sprintf("db cluster %s has no latest restorable time", pointInTime.SourceIdentifier)}
+		}
+		restoreTime = &metav1.Time{Time: *out.DBClusters[0].LatestRestorableTime}
+	}
+	_, err := i.rdsClient.RestoreDBClusterToPointInTime(restoreDBClusterToPointInTimeInput(input, pointInTime.SourceIdentifier, restoreTime,
+		i.cloudTags(input.GetCloudTags())))
+	return restoreTime, err
+}
+
 func (i InternalAwsClients) DeleteDBCluster(dbCluster *v1alpha1.DBCluster) error {
 	namespacedName := fmt.Sprintf("%s/%s", dbCluster.GetNamespace(), dbCluster.GetName())
 	// dont even make a delete attempt if deletionProtection is enabled in CR spec.
@@ -67,6 +94,16 @@ func (i InternalAwsClients) ModifyDBCluster(modifyIn interface{}) error {
 	return errUpdating
 }
 
+// UpdateDBClusterPassword sets the master user password of an existing db cluster
+func (i InternalAwsClients) UpdateDBClusterPassword(input *v1alpha1.DBCluster, password string) error {
+	_, err := i.rdsClient.ModifyDBCluster(&rds.ModifyDBClusterInput{
+		DBClusterIdentifier: aws.String(input.GetDBClusterID()),
+		MasterUserPassword:  aws.String(password),
+		ApplyImmediately:    aws.Bool(true),
+	})
+	return err
+}
+
 func (i InternalAwsClients) DBClusterExists(dbClusterID string) (*v1alpha1.DBStatus, error) {
 	out, err := i.rdsClient.DescribeDBClusters(&rds.DescribeDBClustersInput{
 		DBClusterIdentifier: aws.String(dbClusterID),
@@ -80,14 +117,41 @@ func (i InternalAwsClients) DBClusterExists(dbClusterID string) (*v1alpha1.DBSta
 		}
 		return result, err
 	}
-	result.CurrentPhase = *out.DBClusters[0].Status
+	cluster := out.DBClusters[0]
+	result.CurrentPhase = aws.StringValue(cluster.Status)
 	result.Exists = true
-	if *out.DBClusters[0].Endpoint != "" {
-		result.Endpoint = *out.DBClusters[0].Endpoint
-	}
+	result.Endpoint = aws.StringValue(cluster.Endpoint)
+	result.ReaderEndpoint = aws.StringValue(cluster.ReaderEndpoint)
+	result.Arn = aws.StringValue(cluster.DBClusterArn)
+	result.ResourceID = aws.StringValue(cluster.DbClusterResourceId)
+	result.Port = aws.Int64Value(cluster.Port)
+	result.EngineVersion = aws.StringValue(cluster.EngineVersion)
+	// only multi-AZ db clusters have a class of their own, aurora clusters leave it to their instances
+	result.InstanceClass = aws.StringValue(cluster.DBClusterInstanceClass)
+	result.AllocatedStorage = aws.Int64Value(cluster.AllocatedStorage)
+	result.MultiAZ = aws.BoolValue(cluster.MultiAZ)
+	result.Tags = rdsTagsToMap(cluster.TagList)
+	result.ReplicaSourceIdentifier = aws.StringValue(cluster.ReplicationSourceIdentifier)
+	result.ParameterApplyStatus = clusterParameterApplyStatus(cluster.DBClusterMembers)
 	return result, nil
 }
 
+// clusterParameterApplyStatus returns pending-reboot when any member still has to be rebooted
+// for the cluster parameter group changes to take effect
+func clusterParameterApplyStatus(members []*rds.DBClusterMember) string {
+	status := ""
+	for _, member := range members {
+		memberStatus := aws.StringValue(member.DBClusterParameterGroupStatus)
+		if memberStatus == "pending-reboot" {
+			return memberStatus
+		}
+		if status == "" {
+			status = memberStatus
+		}
+	}
+	return status
+}
+
 // TODO: refactor, I am not proud of this..
 func (i InternalAwsClients) IsDBClusterUpToDate(input *v1alpha1.DBCluster) (bool, interface{}, error) {
 	clusterState, err := i.rdsClient.DescribeDBClusters(&rds.DescribeDBClustersInput{
@@ -121,6 +185,10 @@ func (i InternalAwsClients) IsDBClusterUpToDate(input *v1alpha1.DBCluster) (bool
 	if currentState.DBClusterParameterGroup != nil && *currentState.DBClusterParameterGroup != input.Spec.DBClusterParameterGroupName {
 		modifyDBClusterInput.DBClusterParameterGroupName = aws.String(input.Spec.DBClusterParameterGroupName)
 	}
+	if input.Spec.OptionGroupName != "" && len(currentState.DBClusterOptionGroupMemberships) > 0 &&
+		aws.StringValue(currentState.DBClusterOptionGroupMemberships[0].DBClusterOptionGroupName) != input.Spec.OptionGroupName {
+		modifyDBClusterInput.OptionGroupName = aws.String(input.Spec.OptionGroupName)
+	}
 	if *currentState.DBClusterIdentifier != input.GetDBClusterID() {
 		modifyDBClusterInput.NewDBClusterIdentifier = aws.String(input.GetDBClusterID())
 	}
@@ -133,6 +201,14 @@ func (i InternalAwsClients) IsDBClusterUpToDate(input *v1alpha1.DBCluster) (bool
 	if currentState.PreferredMaintenanceWindow != nil && *currentState.PreferredMaintenanceWindow != input.Spec.PreferredMaintenanceWindow {
 		modifyDBClusterInput.PreferredMaintenanceWindow = aws.String(input.Spec.PreferredMaintenanceWindow)
 	}
+	if desired := scalingConfiguration(input.Spec.ScalingConfiguration); desired != nil &&
+		!scalingConfigurationUpToDate(desired, currentState.ScalingConfigurationInfo) {
+		modifyDBClusterInput.ScalingConfiguration = desired
+	}
+	if desired := serverlessV2ScalingConfiguration(input.Spec.ServerlessV2ScalingConfiguration); desired != nil &&
+		!serverlessV2ScalingConfigurationUpToDate(desired, currentState.ServerlessV2ScalingConfiguration) {
+		modifyDBClusterInput.ServerlessV2ScalingConfiguration = desired
+	}
 	// TODO: check VPC-SG and note that AWS by default adds a security group so beware when comparing with desired state with empty vpc-sg
 
 	isUpToDate := cmp.Equal(modifyDBClusterInput, &rds.ModifyDBClusterInput{})
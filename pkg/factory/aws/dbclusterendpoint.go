@@ -0,0 +1,76 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// DBClusterEndpoints returns the custom endpoints of a db cluster, the writer and reader endpoints are not included
+func (i InternalAwsClients) DBClusterEndpoints(dbClusterID string) ([]v1alpha1.DBClusterEndpointState, error) {
+	var out []v1alpha1.DBClusterEndpointState
+	err := i.rdsClient.DescribeDBClusterEndpointsPages(&rds.DescribeDBClusterEndpointsInput{
+		DBClusterIdentifier: aws.String(dbClusterID),
+		Filters: []*rds.Filter{{
+			Name:   aws.String("db-cluster-endpoint-type"),
+			Values: aws.StringSlice([]string{"custom"}),
+		}},
+	}, func(page *rds.DescribeDBClusterEndpointsOutput, lastPage bool) bool {
+		for _, endpoint := range page.DBClusterEndpoints {
+			out = append(out, v1alpha1.DBClusterEndpointState{
+				Identifier:      aws.StringValue(endpoint.DBClusterEndpointIdentifier),
+				Type:            aws.StringValue(endpoint.CustomEndpointType),
+				Endpoint:        aws.StringValue(endpoint.Endpoint),
+				Status:          aws.StringValue(endpoint.Status),
+				StaticMembers:   aws.StringValueSlice(endpoint.StaticMembers),
+				ExcludedMembers: aws.StringValueSlice(endpoint.ExcludedMembers),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBClusterNotFoundFault {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func (i InternalAwsClients) CreateDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error {
+	_, err := i.rdsClient.CreateDBClusterEndpoint(&rds.CreateDBClusterEndpointInput{
+		DBClusterEndpointIdentifier: aws.String(input.GetDBClusterEndpointID(endpoint.Name)),
+		DBClusterIdentifier:         aws.String(input.GetDBClusterID()),
+		EndpointType:                aws.String(endpoint.GetType()),
+		StaticMembers:               aws.StringSlice(endpoint.StaticMembers),
+		ExcludedMembers:             aws.StringSlice(endpoint.ExcludedMembers),
+		Tags:                        mapToRdsTags(i.cloudTags(input.GetCloudTags())),
+	})
+	return err
+}
+
+// ModifyDBClusterEndpoint sets the type and members of a custom endpoint, empty member lists clear them
+func (i InternalAwsClients) ModifyDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error {
+	_, err := i.rdsClient.ModifyDBClusterEndpoint(&rds.ModifyDBClusterEndpointInput{
+		DBClusterEndpointIdentifier: aws.String(input.GetDBClusterEndpointID(endpoint.Name)),
+		EndpointType:                aws.String(endpoint.GetType()),
+		StaticMembers:               aws.StringSlice(endpoint.StaticMembers),
+		ExcludedMembers:             aws.StringSlice(endpoint.ExcludedMembers),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DeleteDBClusterEndpoint(endpointID string) error {
+	if _, err := i.rdsClient.DeleteDBClusterEndpoint(&rds.DeleteDBClusterEndpointInput{
+		DBClusterEndpointIdentifier: aws.String(endpointID),
+	}); err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBClusterEndpointNotFoundFault {
+			i.logger.Info(fmt.Sprintf("%s - endpoint does not exist, nothing to delete.", endpointID))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -7,14 +7,41 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strings"
 )
 
 func (i InternalAwsClients) CreateDBInstance(input *v1alpha1.DBInstance, password string) error {
-	_, err := i.rdsClient.CreateDBInstance(createInstanceInput(input, password))
+	_, err := i.rdsClient.CreateDBInstance(createInstanceInput(input, password, i.cloudTags(input.GetCloudTags())))
 	return err
 }
 
+func (i InternalAwsClients) RestoreDBInstanceFromSnapshot(input *v1alpha1.DBInstance, snapshotID string) error {
+	_, err := i.rdsClient.RestoreDBInstanceFromDBSnapshot(restoreDBInstanceFromSnapshotInput(input, snapshotID, i.cloudTags(input.GetCloudTags())))
+	return err
+}
+
+// RestoreDBInstanceToPointInTime restores the source db instance and returns the restore time that was used.
+// The latest restorable time is looked up beforehand so the exact time can be recorded.
+func (i InternalAwsClients) RestoreDBInstanceToPointInTime(input *v1alpha1.DBInstance, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error) {
+	restoreTime := pointInTime.RestoreTime
+	if pointInTime.UseLatestRestorableTime {
+		out, err := i.rdsClient.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+			DBInstanceIdentifier: aws.String(pointInTime.SourceIdentifier),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.DBInstances) == 0 || out.DBInstances[0].LatestRestorableTime == nil {
+			return nil, ErrNoLatestRestorableTime{Message: fmt.Sprintf("db instance %s has no latest restorable time", pointInTime.SourceIdentifier)}
+		}
+		restoreTime = &metav1.Time{Time: *out.DBInstances[0].LatestRestorableTime}
+	}
+	_, err := i.rdsClient.RestoreDBInstanceToPointInTime(restoreDBInstanceToPointInTimeInput(input, pointInTime.SourceIdentifier, restoreTime,
+		i.cloudTags(input.GetCloudTags())))
+	return restoreTime, err
+}
+
 func (i InternalAwsClients) DeleteDBInstance(input *v1alpha1.DBInstance) error {
 	nsName := fmt.Sprintf("%s/%s", input.Namespace, input.Name)
 	if input.Spec.DeletionProtection {
@@ -43,6 +70,16 @@ func (i InternalAwsClients) ModifyDBInstance(modifyIn interface{}) error {
 	return err
 }
 
+// UpdateDBInstancePassword sets the master user password of an existing db instance
+func (i InternalAwsClients) UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error {
+	_, err := i.rdsClient.ModifyDBInstance(&rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(input.GetDBInstanceID()),
+		MasterUserPassword:   aws.String(password),
+		ApplyImmediately:     aws.Bool(true),
+	})
+	return err
+}
+
 func (i InternalAwsClients) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error) {
 	resp, err := i.rdsClient.DescribeDBInstances(&rds.DescribeDBInstancesInput{
 		DBInstanceIdentifier: aws.String(input.GetDBInstanceID()),
@@ -58,10 +95,27 @@ func (i InternalAwsClients) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alp
 	}
 
 	if resp != nil && len(resp.DBInstances) == 1 {
-		out.CurrentPhase = *resp.DBInstances[0].DBInstanceStatus
+		instance := resp.DBInstances[0]
+		out.CurrentPhase = aws.StringValue(instance.DBInstanceStatus)
 		out.Exists = true
-		if resp.DBInstances[0].Endpoint != nil && *resp.DBInstances[0].Endpoint.Address != "" {
-			out.Endpoint = *resp.DBInstances[0].Endpoint.Address
+		if instance.Endpoint != nil {
+			out.Endpoint = aws.StringValue(instance.Endpoint.Address)
+			out.Port = aws.Int64Value(instance.Endpoint.Port)
+		}
+		out.Arn = aws.StringValue(instance.DBInstanceArn)
+		out.ResourceID = aws.StringValue(instance.DbiResourceId)
+		out.EngineVersion = aws.StringValue(instance.EngineVersion)
+		out.InstanceClass = aws.StringValue(instance.DBInstanceClass)
+		out.AllocatedStorage = aws.Int64Value(instance.AllocatedStorage)
+		out.MultiAZ = aws.BoolValue(instance.MultiAZ)
+		out.Tags = rdsTagsToMap(instance.TagList)
+		if len(instance.DBParameterGroups) > 0 {
+			out.ParameterApplyStatus = aws.StringValue(instance.DBParameterGroups[0].ParameterApplyStatus)
+		}
+		if source := aws.StringValue(instance.ReadReplicaSourceDBInstanceIdentifier); source != "" {
+			out.ReplicaSourceIdentifier = source
+			out.ReplicationStatus = readReplicationStatus(instance.StatusInfos)
+			out.ReplicaLagSeconds = i.replicaLagSeconds(input.GetDBInstanceID())
 		}
 	}
 	return out, nil
@@ -132,6 +186,14 @@ func (i InternalAwsClients) IsDBInstanceUpToDate(input *v1alpha1.DBInstance) (bo
 	if desiredSpec.StorageType != "" && *currentDBInstance.StorageType != desiredSpec.StorageType {
 		modifyIn.StorageType = aws.String(desiredSpec.StorageType)
 	}
+	if desiredSpec.DBParameterGroupName != "" && len(currentDBInstance.DBParameterGroups) > 0 &&
+		aws.StringValue(currentDBInstance.DBParameterGroups[0].DBParameterGroupName) != desiredSpec.DBParameterGroupName {
+		modifyIn.DBParameterGroupName = aws.String(desiredSpec.DBParameterGroupName)
+	}
+	if desiredSpec.OptionGroupName != "" && len(currentDBInstance.OptionGroupMemberships) > 0 &&
+		aws.StringValue(currentDBInstance.OptionGroupMemberships[0].OptionGroupName) != desiredSpec.OptionGroupName {
+		modifyIn.OptionGroupName = aws.String(desiredSpec.OptionGroupName)
+	}
 
 	isUpToDate := cmp.Equal(modifyIn, &rds.ModifyDBInstanceInput{})
 	if !isUpToDate {
@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (i InternalAwsClients) CreateDBSnapshot(input *v1alpha1.DBSnapshot, dbInstanceID string) error {
+	_, err := i.rdsClient.CreateDBSnapshot(&rds.CreateDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(dbInstanceID),
+		DBSnapshotIdentifier: aws.String(input.GetDBSnapshotID()),
+		Tags:                 mapToRdsTags(i.cloudTags(input.Spec.Tags)),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DeleteDBSnapshot(snapshotID string) error {
+	if _, err := i.rdsClient.DeleteDBSnapshot(&rds.DeleteDBSnapshotInput{
+		DBSnapshotIdentifier: aws.String(snapshotID),
+	}); err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBSnapshotNotFoundFault {
+			i.logger.Info(fmt.Sprintf("%s - snapshot does not exist, nothing to delete.", snapshotID))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (i InternalAwsClients) DBSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error) {
+	resp, err := i.rdsClient.DescribeDBSnapshots(&rds.DescribeDBSnapshotsInput{
+		DBSnapshotIdentifier: aws.String(snapshotID),
+	})
+	out := &v1alpha1.SnapshotState{}
+	if err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBSnapshotNotFoundFault {
+			return out, nil
+		}
+		return nil, err
+	}
+	if resp != nil && len(resp.DBSnapshots) == 1 {
+		snapshot := resp.DBSnapshots[0]
+		out.Exists = true
+		out.CurrentPhase = aws.StringValue(snapshot.Status)
+		out.Arn = aws.StringValue(snapshot.DBSnapshotArn)
+		out.SourceIdentifier = aws.StringValue(snapshot.DBInstanceIdentifier)
+		out.Engine = aws.StringValue(snapshot.Engine)
+		out.EngineVersion = aws.StringValue(snapshot.EngineVersion)
+		out.PercentProgress = aws.Int64Value(snapshot.PercentProgress)
+		out.AllocatedStorage = aws.Int64Value(snapshot.AllocatedStorage)
+		if snapshot.SnapshotCreateTime != nil {
+			out.CreateTime = &metav1.Time{Time: *snapshot.SnapshotCreateTime}
+		}
+	}
+	return out, nil
+}
+
+func (i InternalAwsClients) CreateDBClusterSnapshot(input *v1alpha1.DBClusterSnapshot, dbClusterID string) error {
+	_, err := i.rdsClient.CreateDBClusterSnapshot(&rds.CreateDBClusterSnapshotInput{
+		DBClusterIdentifier:         aws.String(dbClusterID),
+		DBClusterSnapshotIdentifier: aws.String(input.GetDBClusterSnapshotID()),
+		Tags:                        mapToRdsTags(i.cloudTags(input.Spec.Tags)),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DeleteDBClusterSnapshot(snapshotID string) error {
+	if _, err := i.rdsClient.DeleteDBClusterSnapshot(&rds.DeleteDBClusterSnapshotInput{
+		DBClusterSnapshotIdentifier: aws.String(snapshotID),
+	}); err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBClusterSnapshotNotFoundFault {
+			i.logger.Info(fmt.Sprintf("%s - cluster snapshot does not exist, nothing to delete.", snapshotID))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (i InternalAwsClients) DBClusterSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error) {
+	resp, err := i.rdsClient.DescribeDBClusterSnapshots(&rds.DescribeDBClusterSnapshotsInput{
+		DBClusterSnapshotIdentifier: aws.String(snapshotID),
+	})
+	out := &v1alpha1.SnapshotState{}
+	if err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBClusterSnapshotNotFoundFault {
+			return out, nil
+		}
+		return nil, err
+	}
+	if resp != nil && len(resp.DBClusterSnapshots) == 1 {
+		snapshot := resp.DBClusterSnapshots[0]
+		out.Exists = true
+		out.CurrentPhase = aws.StringValue(snapshot.Status)
+		out.Arn = aws.StringValue(snapshot.DBClusterSnapshotArn)
+		out.SourceIdentifier = aws.StringValue(snapshot.DBClusterIdentifier)
+		out.Engine = aws.StringValue(snapshot.Engine)
+		out.EngineVersion = aws.StringValue(snapshot.EngineVersion)
+		out.PercentProgress = aws.Int64Value(snapshot.PercentProgress)
+		out.AllocatedStorage = aws.Int64Value(snapshot.AllocatedStorage)
+		if snapshot.SnapshotCreateTime != nil {
+			out.CreateTime = &metav1.Time{Time: *snapshot.SnapshotCreateTime}
+		}
+	}
+	return out, nil
+}
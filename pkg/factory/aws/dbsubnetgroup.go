@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+func (i InternalAwsClients) CreateDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error {
+	_, err := i.rdsClient.CreateDBSubnetGroup(&rds.CreateDBSubnetGroupInput{
+		DBSubnetGroupName:        aws.String(input.GetDBSubnetGroupName()),
+		DBSubnetGroupDescription: aws.String(input.Spec.Description),
+		SubnetIds:                aws.StringSlice(input.Spec.SubnetIDs),
+		Tags:                     mapToRdsTags(i.cloudTags(input.GetCloudTags())),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DBSubnetGroupExists(name string) (*v1alpha1.DBSubnetGroupState, error) {
+	resp, err := i.rdsClient.DescribeDBSubnetGroups(&rds.DescribeDBSubnetGroupsInput{
+		DBSubnetGroupName: aws.String(name),
+	})
+	out := &v1alpha1.DBSubnetGroupState{}
+	if err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBSubnetGroupNotFoundFault {
+			return out, nil
+		}
+		return nil, err
+	}
+	if resp != nil && len(resp.DBSubnetGroups) == 1 {
+		subnetGroup := resp.DBSubnetGroups[0]
+		out.Exists = true
+		out.Status = aws.StringValue(subnetGroup.SubnetGroupStatus)
+		out.Arn = aws.StringValue(subnetGroup.DBSubnetGroupArn)
+		out.VpcID = aws.StringValue(subnetGroup.VpcId)
+		out.Description = aws.StringValue(subnetGroup.DBSubnetGroupDescription)
+		for _, subnet := range subnetGroup.Subnets {
+			state := v1alpha1.DBSubnetGroupSubnet{
+				SubnetID: aws.StringValue(subnet.SubnetIdentifier),
+				Status:   aws.StringValue(subnet.SubnetStatus),
+			}
+			if subnet.SubnetAvailabilityZone != nil {
+				state.AvailabilityZone = aws.StringValue(subnet.SubnetAvailabilityZone.Name)
+			}
+			out.Subnets = append(out.Subnets, state)
+		}
+	}
+	return out, nil
+}
+
+// ModifyDBSubnetGroup replaces the description and the subnets of the subnet group with the ones in spec
+func (i InternalAwsClients) ModifyDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error {
+	_, err := i.rdsClient.ModifyDBSubnetGroup(&rds.ModifyDBSubnetGroupInput{
+		DBSubnetGroupName:        aws.String(input.GetDBSubnetGroupName()),
+		DBSubnetGroupDescription: aws.String(input.Spec.Description),
+		SubnetIds:                aws.StringSlice(input.Spec.SubnetIDs),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DeleteDBSubnetGroup(name string) error {
+	if _, err := i.rdsClient.DeleteDBSubnetGroup(&rds.DeleteDBSubnetGroupInput{
+		DBSubnetGroupName: aws.String(name),
+	}); err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBSubnetGroupNotFoundFault {
+			i.logger.Info(fmt.Sprintf("%s - subnet group does not exist, nothing to delete.", name))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -55,3 +55,11 @@ type ErrInvalidTypeWasPassedIn struct {
 func (e ErrInvalidTypeWasPassedIn) Error() string {
 	return e.Message
 }
+
+type ErrNoLatestRestorableTime struct {
+	Message string
+}
+
+func (e ErrNoLatestRestorableTime) Error() string {
+	return e.Message
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// CreateGlobalCluster creates the global database from an existing db cluster, which becomes its primary
+func (i InternalAwsClients) CreateGlobalCluster(input *v1alpha1.GlobalCluster, sourceDBClusterArn string) error {
+	_, err := i.rdsClient.CreateGlobalCluster(&rds.CreateGlobalClusterInput{
+		GlobalClusterIdentifier:   aws.String(input.GetGlobalClusterID()),
+		SourceDBClusterIdentifier: aws.String(sourceDBClusterArn),
+		DeletionProtection:        aws.Bool(input.Spec.DeletionProtection),
+	})
+	return err
+}
+
+func (i InternalAwsClients) GlobalClusterExists(globalClusterID string) (*v1alpha1.GlobalClusterState, error) {
+	resp, err := i.rdsClient.DescribeGlobalClusters(&rds.DescribeGlobalClustersInput{
+		GlobalClusterIdentifier: aws.String(globalClusterID),
+	})
+	out := &v1alpha1.GlobalClusterState{}
+	if err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeGlobalClusterNotFoundFault {
+			return out, nil
+		}
+		return nil, err
+	}
+	if resp != nil && len(resp.GlobalClusters) == 1 {
+		globalCluster := resp.GlobalClusters[0]
+		out.Exists = true
+		out.Status = aws.StringValue(globalCluster.Status)
+		out.Arn = aws.StringValue(globalCluster.GlobalClusterArn)
+		out.Engine = aws.StringValue(globalCluster.Engine)
+		out.EngineVersion = aws.StringValue(globalCluster.EngineVersion)
+		out.DeletionProtection = aws.BoolValue(globalCluster.DeletionProtection)
+		if globalCluster.FailoverState != nil {
+			out.FailoverStatus = aws.StringValue(globalCluster.FailoverState.Status)
+		}
+		for _, member := range globalCluster.GlobalClusterMembers {
+			out.Members = append(out.Members, v1alpha1.GlobalClusterMemberState{
+				DBClusterArn: aws.StringValue(member.DBClusterArn),
+				IsWriter:     aws.BoolValue(member.IsWriter),
+			})
+		}
+	}
+	return out, nil
+}
+
+// ModifyGlobalCluster applies spec.deletionProtection, the only setting of a global database that is managed
+func (i InternalAwsClients) ModifyGlobalCluster(input *v1alpha1.GlobalCluster) error {
+	_, err := i.rdsClient.ModifyGlobalCluster(&rds.ModifyGlobalClusterInput{
+		GlobalClusterIdentifier: aws.String(input.GetGlobalClusterID()),
+		DeletionProtection:      aws.Bool(input.Spec.DeletionProtection),
+	})
+	return err
+}
+
+// FailoverGlobalCluster runs a managed planned failover to a secondary db cluster
+func (i InternalAwsClients) FailoverGlobalCluster(globalClusterID, targetDBClusterArn string) error {
+	_, err := i.rdsClient.FailoverGlobalCluster(&rds.FailoverGlobalClusterInput{
+		GlobalClusterIdentifier:   aws.String(globalClusterID),
+		TargetDbClusterIdentifier: aws.String(targetDBClusterArn),
+	})
+	return err
+}
+
+// RemoveFromGlobalCluster detaches a db cluster from the global database, it becomes a standalone db cluster
+func (i InternalAwsClients) RemoveFromGlobalCluster(globalClusterID, dbClusterArn string) error {
+	_, err := i.rdsClient.RemoveFromGlobalCluster(&rds.RemoveFromGlobalClusterInput{
+		GlobalClusterIdentifier: aws.String(globalClusterID),
+		DbClusterIdentifier:     aws.String(dbClusterArn),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DeleteGlobalCluster(globalClusterID string) error {
+	if _, err := i.rdsClient.DeleteGlobalCluster(&rds.DeleteGlobalClusterInput{
+		GlobalClusterIdentifier: aws.String(globalClusterID),
+	}); err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeGlobalClusterNotFoundFault {
+			i.logger.Info(fmt.Sprintf("%s - global cluster does not exist, nothing to delete.", globalClusterID))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+func (i InternalAwsClients) GetAccountID() (string, error) {
+	resp, err := i.stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(resp.Account), nil
+}
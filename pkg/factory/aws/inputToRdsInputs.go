@@ -1,13 +1,11 @@
 package aws
 
 import (
-	"fmt"
 	"github.com/agill17/db-operator/api/v1alpha1"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
-	"strconv"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strings"
-	"time"
 )
 
 func mapToRdsTags(t map[string]string) []*rds.Tag {
@@ -21,7 +19,7 @@ func mapToRdsTags(t map[string]string) []*rds.Tag {
 	return out
 }
 
-func createDBClusterInput(in *v1alpha1.DBCluster, password string) *rds.CreateDBClusterInput {
+func createDBClusterInput(in *v1alpha1.DBCluster, password string, tags map[string]string) *rds.CreateDBClusterInput {
 	out := &rds.CreateDBClusterInput{
 		DBClusterIdentifier:         aws.String(in.GetDBClusterID()),
 		CopyTagsToSnapshot:          aws.Bool(in.Spec.CopyTagsToSnapshot),
@@ -34,9 +32,9 @@ func createDBClusterInput(in *v1alpha1.DBCluster, password string) *rds.CreateDB
 		KmsKeyId:                    aws.String(in.Spec.KmsKeyId),
 		MasterUserPassword:          aws.String(password),
 		MasterUsername:              aws.String(in.Spec.MasterUsername),
-		ReplicationSourceIdentifier: aws.String(in.Spec.ReplicationSourceIdentifier),
+		ReplicationSourceIdentifier: aws.String(in.GetReplicationSourceIdentifier()),
 		StorageEncrypted:            aws.Bool(in.Spec.StorageEncrypted),
-		Tags:                        mapToRdsTags(in.Spec.Tags),
+		Tags:                        mapToRdsTags(tags),
 		VpcSecurityGroupIds:         aws.StringSlice(in.Spec.VpcSecurityGroupIds),
 	}
 	if in.Spec.Port != 0 {
@@ -48,24 +46,102 @@ func createDBClusterInput(in *v1alpha1.DBCluster, password string) *rds.CreateDB
 	if in.Spec.PreferredBackupWindow != "" {
 		out.PreferredBackupWindow = aws.String(in.Spec.PreferredBackupWindow)
 	}
+	if in.Spec.DBSubnetGroupName != "" {
+		out.DBSubnetGroupName = aws.String(in.Spec.DBSubnetGroupName)
+	}
 
+	out.ScalingConfiguration = scalingConfiguration(in.Spec.ScalingConfiguration)
+	out.ServerlessV2ScalingConfiguration = serverlessV2ScalingConfiguration(in.Spec.ServerlessV2ScalingConfiguration)
+
+	// secondary clusters of a global database take the credentials and database of the primary
+	if in.Spec.GlobalClusterIdentifier != "" {
+		out.GlobalClusterIdentifier = aws.String(in.Spec.GlobalClusterIdentifier)
+		out.MasterUsername = nil
+		out.MasterUserPassword = nil
+		out.DatabaseName = nil
+	}
+	return out
+}
+
+// restoreDBClusterFromSnapshotInput restores the cluster with the settings RDS accepts on restore,
+// everything else is applied by the modify pass once the cluster is available
+func restoreDBClusterFromSnapshotInput(in *v1alpha1.DBCluster, snapshotID string, tags map[string]string) *rds.RestoreDBClusterFromSnapshotInput {
+	out := &rds.RestoreDBClusterFromSnapshotInput{
+		DBClusterIdentifier:         aws.String(in.GetDBClusterID()),
+		SnapshotIdentifier:          aws.String(snapshotID),
+		AvailabilityZones:           aws.StringSlice(in.Spec.AvailabilityZones),
+		CopyTagsToSnapshot:          aws.Bool(in.Spec.CopyTagsToSnapshot),
+		DeletionProtection:          aws.Bool(in.Spec.DeletionProtection),
+		EnableCloudwatchLogsExports: aws.StringSlice(in.Spec.EnableCloudwatchLogsExports),
+		Engine:                      aws.String(in.Spec.Engine),
+		EngineMode:                  aws.String(in.Spec.EngineMode),
+		EngineVersion:               aws.String(in.Spec.EngineVersion),
+		Tags:                        mapToRdsTags(tags),
+		VpcSecurityGroupIds:         aws.StringSlice(in.Spec.VpcSecurityGroupIds),
+	}
+	if in.Spec.Port != 0 {
+		out.Port = aws.Int64(in.Spec.Port)
+	}
+	if in.Spec.DBClusterParameterGroupName != "" {
+		out.DBClusterParameterGroupName = aws.String(in.Spec.DBClusterParameterGroupName)
+	}
+	if in.Spec.DBSubnetGroupName != "" {
+		out.DBSubnetGroupName = aws.String(in.Spec.DBSubnetGroupName)
+	}
+	if in.Spec.KmsKeyId != "" {
+		out.KmsKeyId = aws.String(in.Spec.KmsKeyId)
+	}
+	if in.Spec.OptionGroupName != "" {
+		out.OptionGroupName = aws.String(in.Spec.OptionGroupName)
+	}
+	out.ScalingConfiguration = scalingConfiguration(in.Spec.ScalingConfiguration)
+	out.ServerlessV2ScalingConfiguration = serverlessV2ScalingConfiguration(in.Spec.ServerlessV2ScalingConfiguration)
+	return out
+}
+
+func restoreDBClusterToPointInTimeInput(in *v1alpha1.DBCluster, sourceID string, restoreTime *metav1.Time, tags map[string]string) *rds.RestoreDBClusterToPointInTimeInput {
+	out := &rds.RestoreDBClusterToPointInTimeInput{
+		DBClusterIdentifier:         aws.String(in.GetDBClusterID()),
+		SourceDBClusterIdentifier:   aws.String(sourceID),
+		RestoreToTime:               aws.Time(restoreTime.Time),
+		CopyTagsToSnapshot:          aws.Bool(in.Spec.CopyTagsToSnapshot),
+		DeletionProtection:          aws.Bool(in.Spec.DeletionProtection),
+		EnableCloudwatchLogsExports: aws.StringSlice(in.Spec.EnableCloudwatchLogsExports),
+		Tags:                        mapToRdsTags(tags),
+		VpcSecurityGroupIds:         aws.StringSlice(in.Spec.VpcSecurityGroupIds),
+	}
+	if in.Spec.Port != 0 {
+		out.Port = aws.Int64(in.Spec.Port)
+	}
+	if in.Spec.DBClusterParameterGroupName != "" {
+		out.DBClusterParameterGroupName = aws.String(in.Spec.DBClusterParameterGroupName)
+	}
+	if in.Spec.DBSubnetGroupName != "" {
+		out.DBSubnetGroupName = aws.String(in.Spec.DBSubnetGroupName)
+	}
+	if in.Spec.KmsKeyId != "" {
+		out.KmsKeyId = aws.String(in.Spec.KmsKeyId)
+	}
+	if in.Spec.OptionGroupName != "" {
+		out.OptionGroupName = aws.String(in.Spec.OptionGroupName)
+	}
+	out.ScalingConfiguration = scalingConfiguration(in.Spec.ScalingConfiguration)
+	out.ServerlessV2ScalingConfiguration = serverlessV2ScalingConfiguration(in.Spec.ServerlessV2ScalingConfiguration)
 	return out
 }
 
 func deleteDBClusterInput(in *v1alpha1.DBCluster) *rds.DeleteDBClusterInput {
-	timeNow := time.Now().Unix()
-	timeNowStr := strconv.FormatInt(timeNow, 10)
 	out := &rds.DeleteDBClusterInput{
 		DBClusterIdentifier: aws.String(in.GetDBClusterID()),
-		SkipFinalSnapshot:   aws.Bool(in.Spec.SkipFinalSnapshot),
+		SkipFinalSnapshot:   aws.Bool(in.Status.FinalSnapshotIdentifier == ""),
 	}
-	if !in.Spec.SkipFinalSnapshot {
-		out.FinalDBSnapshotIdentifier = aws.String(fmt.Sprintf("%s-%s", in.GetDBClusterID(), timeNowStr))
+	if !*out.SkipFinalSnapshot {
+		out.FinalDBSnapshotIdentifier = aws.String(in.Status.FinalSnapshotIdentifier)
 	}
 	return out
 }
 
-func createInstanceInput(in *v1alpha1.DBInstance, password string) *rds.CreateDBInstanceInput {
+func createInstanceInput(in *v1alpha1.DBInstance, password string, tags map[string]string) *rds.CreateDBInstanceInput {
 	out := &rds.CreateDBInstanceInput{
 		AllocatedStorage:            aws.Int64(in.Spec.AllocatedStorage),
 		AutoMinorVersionUpgrade:     aws.Bool(in.Spec.AutoMinorVersionUpgrade),
@@ -90,7 +166,7 @@ func createInstanceInput(in *v1alpha1.DBInstance, password string) *rds.CreateDB
 		StorageEncrypted:            aws.Bool(in.Spec.StorageEncrypted),
 		StorageType:                 aws.String(in.Spec.StorageType),
 		VpcSecurityGroupIds:         aws.StringSlice(in.Spec.VpcSecurityGroupIds),
-		Tags:                        mapToRdsTags(in.Spec.Tags),
+		Tags:                        mapToRdsTags(tags),
 	}
 	if in.Spec.Port != 0 {
 		out.Port = aws.Int64(in.Spec.Port)
@@ -124,16 +200,152 @@ func createInstanceInput(in *v1alpha1.DBInstance, password string) *rds.CreateDB
 	return out
 }
 
+// restoreDBInstanceFromSnapshotInput restores the instance with the settings RDS accepts on restore,
+// storage size, backups and monitoring are applied by the modify pass once the instance is available
+func restoreDBInstanceFromSnapshotInput(in *v1alpha1.DBInstance, snapshotID string, tags map[string]string) *rds.RestoreDBInstanceFromDBSnapshotInput {
+	out := &rds.RestoreDBInstanceFromDBSnapshotInput{
+		DBInstanceIdentifier:        aws.String(in.GetDBInstanceID()),
+		DBSnapshotIdentifier:        aws.String(snapshotID),
+		AutoMinorVersionUpgrade:     aws.Bool(in.Spec.AutoMinorVersionUpgrade),
+		CopyTagsToSnapshot:          aws.Bool(in.Spec.CopyTagsToSnapshot),
+		DBInstanceClass:             aws.String(in.Spec.DBInstanceClass),
+		DeletionProtection:          aws.Bool(in.Spec.DeletionProtection),
+		EnableCloudwatchLogsExports: aws.StringSlice(in.Spec.CloudwatchLogsExports),
+		Engine:                      aws.String(in.Spec.Engine),
+		MultiAZ:                     aws.Bool(in.Spec.MultiAZ),
+		PubliclyAccessible:          aws.Bool(in.Spec.PubliclyAccessible),
+		Tags:                        mapToRdsTags(tags),
+		VpcSecurityGroupIds:         aws.StringSlice(in.Spec.VpcSecurityGroupIds),
+	}
+	if in.Spec.AvailabilityZone != "" && !in.Spec.MultiAZ {
+		out.AvailabilityZone = aws.String(in.Spec.AvailabilityZone)
+	}
+	if in.Spec.Iops != 0 {
+		out.Iops = aws.Int64(in.Spec.Iops)
+	}
+	if in.Spec.Port != 0 {
+		out.Port = aws.Int64(in.Spec.Port)
+	}
+	if in.Spec.StorageType != "" {
+		out.StorageType = aws.String(in.Spec.StorageType)
+	}
+	if in.Spec.DBSubnetGroupName != "" {
+		out.DBSubnetGroupName = aws.String(in.Spec.DBSubnetGroupName)
+	}
+	if in.Spec.DBParameterGroupName != "" {
+		out.DBParameterGroupName = aws.String(in.Spec.DBParameterGroupName)
+	}
+	if in.Spec.OptionGroupName != "" {
+		out.OptionGroupName = aws.String(in.Spec.OptionGroupName)
+	}
+	if in.Spec.LicenseModel != "" {
+		out.LicenseModel = aws.String(in.Spec.LicenseModel)
+	}
+	return out
+}
+
+func restoreDBInstanceToPointInTimeInput(in *v1alpha1.DBInstance, sourceID string, restoreTime *metav1.Time, tags map[string]string) *rds.RestoreDBInstanceToPointInTimeInput {
+	out := &rds.RestoreDBInstanceToPointInTimeInput{
+		TargetDBInstanceIdentifier:  aws.String(in.GetDBInstanceID()),
+		SourceDBInstanceIdentifier:  aws.String(sourceID),
+		RestoreTime:                 aws.Time(restoreTime.Time),
+		AutoMinorVersionUpgrade:     aws.Bool(in.Spec.AutoMinorVersionUpgrade),
+		CopyTagsToSnapshot:          aws.Bool(in.Spec.CopyTagsToSnapshot),
+		DBInstanceClass:             aws.String(in.Spec.DBInstanceClass),
+		DeletionProtection:          aws.Bool(in.Spec.DeletionProtection),
+		EnableCloudwatchLogsExports: aws.StringSlice(in.Spec.CloudwatchLogsExports),
+		Engine:                      aws.String(in.Spec.Engine),
+		MultiAZ:                     aws.Bool(in.Spec.MultiAZ),
+		PubliclyAccessible:          aws.Bool(in.Spec.PubliclyAccessible),
+		Tags:                        mapToRdsTags(tags),
+		VpcSecurityGroupIds:         aws.StringSlice(in.Spec.VpcSecurityGroupIds),
+	}
+	if in.Spec.AvailabilityZone != "" && !in.Spec.MultiAZ {
+		out.AvailabilityZone = aws.String(in.Spec.AvailabilityZone)
+	}
+	if in.Spec.Iops != 0 {
+		out.Iops = aws.Int64(in.Spec.Iops)
+	}
+	if in.Spec.Port != 0 {
+		out.Port = aws.Int64(in.Spec.Port)
+	}
+	if in.Spec.StorageType != "" {
+		out.StorageType = aws.String(in.Spec.StorageType)
+	}
+	if in.Spec.DBSubnetGroupName != "" {
+		out.DBSubnetGroupName = aws.String(in.Spec.DBSubnetGroupName)
+	}
+	if in.Spec.DBParameterGroupName != "" {
+		out.DBParameterGroupName = aws.String(in.Spec.DBParameterGroupName)
+	}
+	if in.Spec.OptionGroupName != "" {
+		out.OptionGroupName = aws.String(in.Spec.OptionGroupName)
+	}
+	if in.Spec.LicenseModel != "" {
+		out.LicenseModel = aws.String(in.Spec.LicenseModel)
+	}
+	return out
+}
+
+func createDBInstanceReadReplicaInput(in *v1alpha1.DBInstance, sourceID, sourceRegion, region string,
+	tags map[string]string) *rds.CreateDBInstanceReadReplicaInput {
+	out := &rds.CreateDBInstanceReadReplicaInput{
+		DBInstanceIdentifier:        aws.String(in.GetDBInstanceID()),
+		SourceDBInstanceIdentifier:  aws.String(sourceID),
+		AutoMinorVersionUpgrade:     aws.Bool(in.Spec.AutoMinorVersionUpgrade),
+		CopyTagsToSnapshot:          aws.Bool(in.Spec.CopyTagsToSnapshot),
+		DBInstanceClass:             aws.String(in.Spec.DBInstanceClass),
+		DeletionProtection:          aws.Bool(in.Spec.DeletionProtection),
+		EnableCloudwatchLogsExports: aws.StringSlice(in.Spec.CloudwatchLogsExports),
+		EnablePerformanceInsights:   aws.Bool(in.Spec.EnablePerformanceInsights),
+		MultiAZ:                     aws.Bool(in.Spec.MultiAZ),
+		PubliclyAccessible:          aws.Bool(in.Spec.PubliclyAccessible),
+		Tags:                        mapToRdsTags(tags),
+		VpcSecurityGroupIds:         aws.StringSlice(in.Spec.VpcSecurityGroupIds),
+	}
+	// the sdk generates the presigned url required for cross region replicas from SourceRegion
+	if sourceRegion != "" && sourceRegion != region {
+		out.SourceRegion = aws.String(sourceRegion)
+	}
+	if in.Spec.AvailabilityZone != "" && !in.Spec.MultiAZ {
+		out.AvailabilityZone = aws.String(in.Spec.AvailabilityZone)
+	}
+	if in.Spec.Iops != 0 {
+		out.Iops = aws.Int64(in.Spec.Iops)
+	}
+	if in.Spec.Port != 0 {
+		out.Port = aws.Int64(in.Spec.Port)
+	}
+	if in.Spec.StorageType != "" {
+		out.StorageType = aws.String(in.Spec.StorageType)
+	}
+	if in.Spec.DBSubnetGroupName != "" {
+		out.DBSubnetGroupName = aws.String(in.Spec.DBSubnetGroupName)
+	}
+	if in.Spec.DBParameterGroupName != "" {
+		out.DBParameterGroupName = aws.String(in.Spec.DBParameterGroupName)
+	}
+	if in.Spec.OptionGroupName != "" {
+		out.OptionGroupName = aws.String(in.Spec.OptionGroupName)
+	}
+	if in.Spec.KmsKeyId != "" {
+		out.KmsKeyId = aws.String(in.Spec.KmsKeyId)
+	}
+	if in.Spec.MonitoringInterval != 0 {
+		out.MonitoringInterval = aws.Int64(in.Spec.MonitoringInterval)
+		out.MonitoringRoleArn = aws.String(in.Spec.MonitoringRoleArn)
+	}
+	return out
+}
+
 func deleteDbInstanceInput(instance *v1alpha1.DBInstance) *rds.DeleteDBInstanceInput {
-	timeNow := time.Now().Unix()
-	timeNowStr := strconv.FormatInt(timeNow, 10)
 	out := &rds.DeleteDBInstanceInput{
 		DBInstanceIdentifier:   aws.String(instance.GetDBInstanceID()),
-		DeleteAutomatedBackups: aws.Bool(false),
-		SkipFinalSnapshot:      aws.Bool(instance.Spec.SkipFinalSnapshot),
+		DeleteAutomatedBackups: aws.Bool(instance.Spec.DeleteAutomatedBackups),
+		SkipFinalSnapshot:      aws.Bool(instance.Status.FinalSnapshotIdentifier == ""),
 	}
 	if !*out.SkipFinalSnapshot {
-		out.FinalDBSnapshotIdentifier = aws.String(fmt.Sprintf("%s-%s", instance.GetDBInstanceID(), timeNowStr))
+		out.FinalDBSnapshotIdentifier = aws.String(instance.Status.FinalSnapshotIdentifier)
 	}
 	return out
 }
@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+func (i InternalAwsClients) CreateOptionGroup(input *v1alpha1.OptionGroup) error {
+	_, err := i.rdsClient.CreateOptionGroup(&rds.CreateOptionGroupInput{
+		OptionGroupName:        aws.String(input.GetOptionGroupName()),
+		EngineName:             aws.String(input.Spec.EngineName),
+		MajorEngineVersion:     aws.String(input.Spec.MajorEngineVersion),
+		OptionGroupDescription: aws.String(input.Spec.Description),
+		Tags:                   mapToRdsTags(i.cloudTags(input.GetCloudTags())),
+	})
+	return err
+}
+
+func (i InternalAwsClients) OptionGroupExists(name string) (*v1alpha1.OptionGroupState, error) {
+	resp, err := i.rdsClient.DescribeOptionGroups(&rds.DescribeOptionGroupsInput{
+		OptionGroupName: aws.String(name),
+	})
+	out := &v1alpha1.OptionGroupState{}
+	if err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeOptionGroupNotFoundFault {
+			return out, nil
+		}
+		return nil, err
+	}
+	if resp == nil || len(resp.OptionGroupsList) != 1 {
+		return out, nil
+	}
+	optionGroup := resp.OptionGroupsList[0]
+	out.Exists = true
+	out.Arn = aws.StringValue(optionGroup.OptionGroupArn)
+	out.EngineName = aws.StringValue(optionGroup.EngineName)
+	out.MajorEngineVersion = aws.StringValue(optionGroup.MajorEngineVersion)
+	out.Description = aws.StringValue(optionGroup.OptionGroupDescription)
+	for _, option := range optionGroup.Options {
+		state := v1alpha1.OptionState{
+			OptionStatus: v1alpha1.OptionStatus{
+				Name:       aws.StringValue(option.OptionName),
+				Version:    aws.StringValue(option.OptionVersion),
+				Port:       option.Port,
+				Persistent: aws.BoolValue(option.Persistent),
+				Permanent:  aws.BoolValue(option.Permanent),
+			},
+			Settings: map[string]string{},
+		}
+		for _, sg := range option.VpcSecurityGroupMemberships {
+			state.VpcSecurityGroupIds = append(state.VpcSecurityGroupIds, aws.StringValue(sg.VpcSecurityGroupId))
+		}
+		for _, setting := range option.OptionSettings {
+			state.Settings[aws.StringValue(setting.Name)] = aws.StringValue(setting.Value)
+		}
+		out.Options = append(out.Options, state)
+	}
+	return out, nil
+}
+
+// ModifyOptionGroup adds or updates the include options and removes the remove options, the change
+// is applied to the databases using the option group right away
+func (i InternalAwsClients) ModifyOptionGroup(name string, include []v1alpha1.Option, remove []string) error {
+	modifyIn := &rds.ModifyOptionGroupInput{
+		OptionGroupName:  aws.String(name),
+		ApplyImmediately: aws.Bool(true),
+	}
+	for _, option := range include {
+		configuration := &rds.OptionConfiguration{
+			OptionName: aws.String(option.Name),
+			Port:       option.Port,
+		}
+		if option.Version != "" {
+			configuration.OptionVersion = aws.String(option.Version)
+		}
+		if len(option.VpcSecurityGroupIds) > 0 {
+			configuration.VpcSecurityGroupMemberships = aws.StringSlice(option.VpcSecurityGroupIds)
+		}
+		for settingName, value := range option.Settings {
+			configuration.OptionSettings = append(configuration.OptionSettings, &rds.OptionSetting{
+				Name:  aws.String(settingName),
+				Value: aws.String(value),
+			})
+		}
+		modifyIn.OptionsToInclude = append(modifyIn.OptionsToInclude, configuration)
+	}
+	if len(remove) > 0 {
+		modifyIn.OptionsToRemove = aws.StringSlice(remove)
+	}
+	_, err := i.rdsClient.ModifyOptionGroup(modifyIn)
+	return err
+}
+
+func (i InternalAwsClients) DeleteOptionGroup(name string) error {
+	if _, err := i.rdsClient.DeleteOptionGroup(&rds.DeleteOptionGroupInput{
+		OptionGroupName: aws.String(name),
+	}); err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeOptionGroupNotFoundFault {
+			i.logger.Info(fmt.Sprintf("%s - option group does not exist, nothing to delete.", name))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
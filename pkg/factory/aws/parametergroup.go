@@ -0,0 +1,225 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"sort"
+)
+
+// maxParametersPerRequest is the most parameters the modify and reset parameter group calls accept at once
+const maxParametersPerRequest = 20
+
+func (i InternalAwsClients) CreateDBParameterGroup(input *v1alpha1.DBParameterGroup) error {
+	_, err := i.rdsClient.CreateDBParameterGroup(&rds.CreateDBParameterGroupInput{
+		DBParameterGroupName:   aws.String(input.GetDBParameterGroupName()),
+		DBParameterGroupFamily: aws.String(input.Spec.Family),
+		Description:            aws.String(input.Spec.Description),
+		Tags:                   mapToRdsTags(i.cloudTags(input.GetCloudTags())),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DBParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error) {
+	resp, err := i.rdsClient.DescribeDBParameterGroups(&rds.DescribeDBParameterGroupsInput{
+		DBParameterGroupName: aws.String(name),
+	})
+	out := &v1alpha1.ParameterGroupState{}
+	if err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBParameterGroupNotFoundFault {
+			return out, nil
+		}
+		return nil, err
+	}
+	if resp == nil || len(resp.DBParameterGroups) != 1 {
+		return out, nil
+	}
+	parameterGroup := resp.DBParameterGroups[0]
+	out.Exists = true
+	out.Arn = aws.StringValue(parameterGroup.DBParameterGroupArn)
+	out.Family = aws.StringValue(parameterGroup.DBParameterGroupFamily)
+	out.Description = aws.StringValue(parameterGroup.Description)
+	out.Parameters = map[string]v1alpha1.ParameterState{}
+	if err := i.rdsClient.DescribeDBParametersPages(&rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(name),
+	}, func(page *rds.DescribeDBParametersOutput, lastPage bool) bool {
+		addParameterStates(out.Parameters, page.Parameters)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (i InternalAwsClients) ModifyDBParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error {
+	for _, batch := range rdsParameterBatches(modifiedRdsParameters(parameters)) {
+		if _, err := i.rdsClient.ModifyDBParameterGroup(&rds.ModifyDBParameterGroupInput{
+			DBParameterGroupName: aws.String(name),
+			Parameters:           batch,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i InternalAwsClients) ResetDBParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error {
+	for _, batch := range rdsParameterBatches(resetRdsParameters(parameters)) {
+		if _, err := i.rdsClient.ResetDBParameterGroup(&rds.ResetDBParameterGroupInput{
+			DBParameterGroupName: aws.String(name),
+			Parameters:           batch,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i InternalAwsClients) DeleteDBParameterGroup(name string) error {
+	if _, err := i.rdsClient.DeleteDBParameterGroup(&rds.DeleteDBParameterGroupInput{
+		DBParameterGroupName: aws.String(name),
+	}); err != nil {
+		if awsErr, isAwsErr := err.(awserr.Error); isAwsErr && awsErr.Code() == rds.ErrCodeDBParameterGroupNotFoundFault {
+			i.logger.Info(fmt.Sprintf("%s - parameter group does not exist, nothing to delete.", name))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (i InternalAwsClients) CreateDBClusterParameterGroup(input *v1alpha1.DBClusterParameterGroup) error {
+	_, err := i.rdsClient.CreateDBClusterParameterGroup(&rds.CreateDBClusterParameterGroupInput{
+		DBClusterParameterGroupName: aws.String(input.GetDBClusterParameterGroupName()),
+		DBParameterGroupFamily:      aws.String(input.Spec.Family),
+		Description:                 aws.String(input.Spec.Description),
+		Tags:                        mapToRdsTags(i.cloudTags(input.GetCloudTags())),
+	})
+	return err
+}
+
+func (i InternalAwsClients) DBClusterParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error) {
+	resp, err := i.rdsClient.DescribeDBClusterParameterGroups(&rds.DescribeDBClusterParameterGroupsInput{
+		DBClusterParameterGroupName: aws.String(name),
+	})
+	out := &v1alpha1.ParameterGroupState{}
+	if err != nil {
+		if isClusterParameterGroupNotFound(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+	if resp == nil || len(resp.DBClusterParameterGroups) != 1 {
+		return out, nil
+	}
+	parameterGroup := resp.DBClusterParameterGroups[0]
+	out.Exists = true
+	out.Arn = aws.StringValue(parameterGroup.DBClusterParameterGroupArn)
+	out.Family = aws.StringValue(parameterGroup.DBParameterGroupFamily)
+	out.Description = aws.StringValue(parameterGroup.Description)
+	out.Parameters = map[string]v1alpha1.ParameterState{}
+	if err := i.rdsClient.DescribeDBClusterParametersPages(&rds.DescribeDBClusterParametersInput{
+		DBClusterParameterGroupName: aws.String(name),
+	}, func(page *rds.DescribeDBClusterParametersOutput, lastPage bool) bool {
+		addParameterStates(out.Parameters, page.Parameters)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (i InternalAwsClients) ModifyDBClusterParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error {
+	for _, batch := range rdsParameterBatches(modifiedRdsParameters(parameters)) {
+		if _, err := i.rdsClient.ModifyDBClusterParameterGroup(&rds.ModifyDBClusterParameterGroupInput{
+			DBClusterParameterGroupName: aws.String(name),
+			Parameters:                  batch,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i InternalAwsClients) ResetDBClusterParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error {
+	for _, batch := range rdsParameterBatches(resetRdsParameters(parameters)) {
+		if _, err := i.rdsClient.ResetDBClusterParameterGroup(&rds.ResetDBClusterParameterGroupInput{
+			DBClusterParameterGroupName: aws.String(name),
+			Parameters:                  batch,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i InternalAwsClients) DeleteDBClusterParameterGroup(name string) error {
+	if _, err := i.rdsClient.DeleteDBClusterParameterGroup(&rds.DeleteDBClusterParameterGroupInput{
+		DBClusterParameterGroupName: aws.String(name),
+	}); err != nil {
+		if isClusterParameterGroupNotFound(err) {
+			i.logger.Info(fmt.Sprintf("%s - cluster parameter group does not exist, nothing to delete.", name))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// isClusterParameterGroupNotFound is true for both codes RDS uses when a cluster parameter group does not exist
+func isClusterParameterGroupNotFound(err error) bool {
+	awsErr, isAwsErr := err.(awserr.Error)
+	return isAwsErr && (awsErr.Code() == rds.ErrCodeDBParameterGroupNotFoundFault ||
+		awsErr.Code() == rds.ErrCodeDBClusterParameterGroupNotFoundFault)
+}
+
+func addParameterStates(out map[string]v1alpha1.ParameterState, parameters []*rds.Parameter) {
+	for _, parameter := range parameters {
+		out[aws.StringValue(parameter.ParameterName)] = v1alpha1.ParameterState{
+			Value:     aws.StringValue(parameter.ParameterValue),
+			ApplyType: aws.StringValue(parameter.ApplyType),
+			Source:    aws.StringValue(parameter.Source),
+		}
+	}
+}
+
+func modifiedRdsParameters(parameters map[string]v1alpha1.Parameter) []*rds.Parameter {
+	var out []*rds.Parameter
+	for name, parameter := range parameters {
+		out = append(out, &rds.Parameter{
+			ParameterName:  aws.String(name),
+			ParameterValue: aws.String(parameter.Value),
+			ApplyMethod:    aws.String(string(parameter.ApplyMethod)),
+		})
+	}
+	return out
+}
+
+func resetRdsParameters(parameters map[string]v1alpha1.ParameterApplyMethod) []*rds.Parameter {
+	var out []*rds.Parameter
+	for name, applyMethod := range parameters {
+		out = append(out, &rds.Parameter{
+			ParameterName: aws.String(name),
+			ApplyMethod:   aws.String(string(applyMethod)),
+		})
+	}
+	return out
+}
+
+// rdsParameterBatches splits parameters, sorted by name, into batches the modify and reset calls accept
+func rdsParameterBatches(parameters []*rds.Parameter) [][]*rds.Parameter {
+	sort.Slice(parameters, func(a, b int) bool {
+		return aws.StringValue(parameters[a].ParameterName) < aws.StringValue(parameters[b].ParameterName)
+	})
+	var batches [][]*rds.Parameter
+	for len(parameters) > maxParametersPerRequest {
+		batches = append(batches, parameters[:maxParametersPerRequest])
+		parameters = parameters[maxParametersPerRequest:]
+	}
+	if len(parameters) > 0 {
+		batches = append(batches, parameters)
+	}
+	return batches
+}
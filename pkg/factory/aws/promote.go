@@ -0,0 +1,32 @@
+package aws
+
+import (
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// PromoteDBInstanceReadReplica promotes a read replica to a standalone db instance
+func (i InternalAwsClients) PromoteDBInstanceReadReplica(input *v1alpha1.DBInstance) error {
+	_, err := i.rdsClient.PromoteReadReplica(promoteReadReplicaInput(input))
+	return err
+}
+
+// PromoteDBClusterReadReplica promotes a replica db cluster to a standalone db cluster
+func (i InternalAwsClients) PromoteDBClusterReadReplica(input *v1alpha1.DBCluster) error {
+	_, err := i.rdsClient.PromoteReadReplicaDBCluster(&rds.PromoteReadReplicaDBClusterInput{
+		DBClusterIdentifier: aws.String(input.GetDBClusterID()),
+	})
+	return err
+}
+
+func promoteReadReplicaInput(in *v1alpha1.DBInstance) *rds.PromoteReadReplicaInput {
+	out := &rds.PromoteReadReplicaInput{
+		DBInstanceIdentifier: aws.String(in.GetDBInstanceID()),
+	}
+	// replicas usually run without backups, enable them right away instead of in a later modify
+	if in.Spec.BackupRetentionPeriod != 0 {
+		out.BackupRetentionPeriod = aws.Int64(in.Spec.BackupRetentionPeriod)
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"time"
+)
+
+// status type of the replication entry in DBInstance.StatusInfos
+const readReplicationStatusType = "read replication"
+
+func (i InternalAwsClients) CreateDBInstanceReadReplica(input *v1alpha1.DBInstance, sourceID, sourceRegion string) error {
+	_, err := i.rdsClient.CreateDBInstanceReadReplica(createDBInstanceReadReplicaInput(input, sourceID, sourceRegion, i.region,
+		i.cloudTags(input.GetCloudTags())))
+	return err
+}
+
+func readReplicationStatus(infos []*rds.DBInstanceStatusInfo) string {
+	for _, info := range infos {
+		if aws.StringValue(info.StatusType) == readReplicationStatusType {
+			return aws.StringValue(info.Status)
+		}
+	}
+	return ""
+}
+
+// replicaLagSeconds returns the latest ReplicaLag metric of a read replica. The lag is informational,
+// so it is nil rather than an error when it cannot be read.
+func (i InternalAwsClients) replicaLagSeconds(dbInstanceID string) *int64 {
+	now := time.Now()
+	out, err := i.cwClient.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/RDS"),
+		MetricName: aws.String("ReplicaLag"),
+		Dimensions: []*cloudwatch.Dimension{{
+			Name:  aws.String("DBInstanceIdentifier"),
+			Value: aws.String(dbInstanceID),
+		}},
+		StartTime:  aws.Time(now.Add(-5 * time.Minute)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int64(60),
+		Statistics: aws.StringSlice([]string{cloudwatch.StatisticMaximum}),
+	})
+	if err != nil {
+		i.logger.Info(fmt.Sprintf("%s - could not get replica lag: %v", dbInstanceID, err))
+		return nil
+	}
+	var latest *cloudwatch.Datapoint
+	for _, dp := range out.Datapoints {
+		if latest == nil || dp.Timestamp.After(*latest.Timestamp) {
+			latest = dp
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	return aws.Int64(int64(aws.Float64Value(latest.Maximum)))
+}
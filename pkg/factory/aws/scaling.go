@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// scalingConfiguration returns the serverless v1 scaling configuration of the spec, fields left empty use the
+// defaults of RDS
+func scalingConfiguration(in *v1alpha1.ScalingConfiguration) *rds.ScalingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := &rds.ScalingConfiguration{AutoPause: aws.Bool(in.AutoPause)}
+	if in.MinCapacity != 0 {
+		out.MinCapacity = aws.Int64(in.MinCapacity)
+	}
+	if in.MaxCapacity != 0 {
+		out.MaxCapacity = aws.Int64(in.MaxCapacity)
+	}
+	if in.SecondsUntilAutoPause != 0 {
+		out.SecondsUntilAutoPause = aws.Int64(in.SecondsUntilAutoPause)
+	}
+	if in.TimeoutAction != "" {
+		out.TimeoutAction = aws.String(in.TimeoutAction)
+	}
+	if in.SecondsBeforeTimeout != 0 {
+		out.SecondsBeforeTimeout = aws.Int64(in.SecondsBeforeTimeout)
+	}
+	return out
+}
+
+// serverlessV2ScalingConfiguration returns the serverless v2 scaling configuration of the spec, the capacities
+// are validated before the spec is applied
+func serverlessV2ScalingConfiguration(in *v1alpha1.ServerlessV2ScalingConfiguration) *rds.ServerlessV2ScalingConfiguration {
+	if in == nil {
+		return nil
+	}
+	minCapacity, maxCapacity, err := in.Capacity()
+	if err != nil {
+		return nil
+	}
+	return &rds.ServerlessV2ScalingConfiguration{
+		MinCapacity: aws.Float64(minCapacity),
+		MaxCapacity: aws.Float64(maxCapacity),
+	}
+}
+
+// scalingConfigurationUpToDate compares the fields set in desired, the others are left to RDS
+func scalingConfigurationUpToDate(desired *rds.ScalingConfiguration, current *rds.ScalingConfigurationInfo) bool {
+	if current == nil {
+		return false
+	}
+	return aws.BoolValue(desired.AutoPause) == aws.BoolValue(current.AutoPause) &&
+		(desired.MinCapacity == nil || *desired.MinCapacity == aws.Int64Value(current.MinCapacity)) &&
+		(desired.MaxCapacity == nil || *desired.MaxCapacity == aws.Int64Value(current.MaxCapacity)) &&
+		(desired.SecondsUntilAutoPause == nil || *desired.SecondsUntilAutoPause == aws.Int64Value(current.SecondsUntilAutoPause)) &&
+		(desired.TimeoutAction == nil || *desired.TimeoutAction == aws.StringValue(current.TimeoutAction)) &&
+		(desired.SecondsBeforeTimeout == nil || *desired.SecondsBeforeTimeout == aws.Int64Value(current.SecondsBeforeTimeout))
+}
+
+func serverlessV2ScalingConfigurationUpToDate(desired *rds.ServerlessV2ScalingConfiguration, current *rds.ServerlessV2ScalingConfigurationInfo) bool {
+	if current == nil {
+		return false
+	}
+	return aws.Float64Value(desired.MinCapacity) == aws.Float64Value(current.MinCapacity) &&
+		aws.Float64Value(desired.MaxCapacity) == aws.Float64Value(current.MaxCapacity)
+}
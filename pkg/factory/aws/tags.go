@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"strings"
+)
+
+func (i InternalAwsClients) AddTagsToResource(arn string, tags map[string]string) error {
+	_, err := i.rdsClient.AddTagsToResource(&rds.AddTagsToResourceInput{
+		ResourceName: aws.String(arn),
+		Tags:         mapToRdsTags(tags),
+	})
+	return err
+}
+
+// cloudTags returns the tags of a resource plus the default tags of the provider
+func (i InternalAwsClients) cloudTags(tags map[string]string) map[string]string {
+	return utils.MergeTags(i.defaultTags, tags)
+}
+
+func rdsTagsToMap(tags []*rds.Tag) map[string]string {
+	out := map[string]string{}
+	for _, t := range tags {
+		out[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return out
+}
+
+// userTags drops tags reserved by AWS and by the operator and the default tags of the provider, which do
+// not belong in spec.tags
+func (i InternalAwsClients) userTags(tags map[string]string, ownerTagKey string) map[string]string {
+	out := map[string]string{}
+	for k, v := range tags {
+		if k == ownerTagKey || strings.HasPrefix(k, "aws:") {
+			continue
+		}
+		out[k] = v
+	}
+	return utils.WithoutDefaultTags(out, i.defaultTags)
+}
@@ -0,0 +1,56 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// do sends a request to Azure Resource Manager, path is the id of the resource. out is filled from
+// the response body when it is not nil, error bodies are returned as *apiError. Long running
+// operations are not awaited, their progress shows in the state of the resource.
+func (a *InternalAzureClient) do(method, path, apiVersion string, in, out interface{}) error {
+	u := a.endpoint + path + "?" + url.Values{"api-version": []string{apiVersion}}.Encode()
+	var body *bytes.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	} else {
+		body = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		errBody := struct {
+			Error *apiError `json:"error"`
+		}{}
+		if json.Unmarshal(raw, &errBody) != nil || errBody.Error == nil {
+			return &apiError{HTTPCode: resp.StatusCode, Msg: string(raw)}
+		}
+		errBody.Error.HTTPCode = resp.StatusCode
+		return errBody.Error
+	}
+	if out == nil || len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, out)
+}
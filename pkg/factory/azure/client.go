@@ -0,0 +1,145 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	MockAzureEndpoint = "MOCK_AZURE_ENDPOINT"
+
+	// provider secret keys, the same names as the environment variables of the azure sdks. They are
+	// read from the environment of the operator when the provider uses the injected identity.
+	TenantIDVar       = "AZURE_TENANT_ID"
+	ClientIDVar       = "AZURE_CLIENT_ID"
+	ClientSecretVar   = "AZURE_CLIENT_SECRET"
+	SubscriptionIDVar = "AZURE_SUBSCRIPTION_ID"
+	ResourceGroupVar  = "AZURE_RESOURCE_GROUP"
+	// AuthorityHostVar overrides the azure active directory host, e.g for sovereign clouds
+	AuthorityHostVar = "AZURE_AUTHORITY_HOST"
+
+	resourceManagerEndpoint = "https://management.azure.com/"
+	resourceManagerScope    = "https://management.azure.com/.default"
+	defaultAuthorityHost    = "https://login.microsoftonline.com/"
+	subscriptionsAPIVersion = "2020-01-01"
+)
+
+// InternalAzureClient manages Azure Database flexible servers through the Azure Resource Manager API.
+// Only factory.Provider is implemented, the factory fills in the rest as not supported.
+type InternalAzureClient struct {
+	httpClient     *http.Client
+	endpoint       string
+	subscriptionID string
+	resourceGroup  string
+	region         string
+	logger         logr.Logger
+	// defaultTags of the provider, put on every server together with its own tags
+	defaultTags map[string]string
+	// cacheVersion is the version of the provider the cached client was created for
+	cacheVersion string
+}
+
+var azureClientCache sync.Map
+
+func getAzureClientCacheKey(pName, region string) string {
+	return fmt.Sprintf("azure-%v-%v", pName, region)
+}
+
+// NewInternalAzureClient returns the cached client of the provider pName in region, a client cached for another
+// pVersion is replaced so the cache holds one client per provider and region.
+func NewInternalAzureClient(logger logr.Logger, region string, pName, pVersion string, providerCredentials map[string][]byte,
+	defaultTags map[string]string) (*InternalAzureClient, error) {
+	cacheKeyName := getAzureClientCacheKey(pName, region)
+	if cached, ok := azureClientCache.Load(cacheKeyName); ok && cached.(*InternalAzureClient).cacheVersion == pVersion {
+		return cached.(*InternalAzureClient), nil
+	}
+
+	lookup := func(key string) string { return string(providerCredentials[key]) }
+	if providerCredentials == nil {
+		lookup = os.Getenv
+	}
+	subscriptionID, resourceGroup := lookup(SubscriptionIDVar), lookup(ResourceGroupVar)
+	if subscriptionID == "" || resourceGroup == "" {
+		return nil, ErrMissingAzureCredentials{Message: fmt.Sprintf("Azure provider credentials missing %s or %s",
+			SubscriptionIDVar, ResourceGroupVar)}
+	}
+	var tokenSource oauth2.TokenSource
+	switch {
+	case lookup(ClientSecretVar) != "":
+		tokenSource = clientSecretTokenSource(lookup)
+	case providerCredentials == nil:
+		// without a client secret the managed identity assigned to the node or pod is used
+		tokenSource = oauth2.ReuseTokenSource(nil, newManagedIdentity(lookup(ClientIDVar)))
+	default:
+		return nil, ErrMissingAzureCredentials{Message: fmt.Sprintf("Azure provider credentials missing %s", ClientSecretVar)}
+	}
+	if tokenSource == nil {
+		return nil, ErrMissingAzureCredentials{Message: fmt.Sprintf("Azure provider credentials missing %s or %s",
+			TenantIDVar, ClientIDVar)}
+	}
+
+	endpoint := resourceManagerEndpoint
+	if val, ok := os.LookupEnv(MockAzureEndpoint); ok {
+		endpoint = val
+	}
+	c := newInternalAzureClient(logger, oauth2.NewClient(context.Background(), tokenSource), endpoint,
+		subscriptionID, resourceGroup, region)
+	c.defaultTags = defaultTags
+	c.cacheVersion = pVersion
+	azureClientCache.Store(cacheKeyName, c)
+	return c, nil
+}
+
+func newInternalAzureClient(logger logr.Logger, httpClient *http.Client, endpoint, subscriptionID, resourceGroup,
+	region string) *InternalAzureClient {
+	return &InternalAzureClient{
+		httpClient:     httpClient,
+		endpoint:       strings.TrimSuffix(endpoint, "/"),
+		subscriptionID: subscriptionID,
+		resourceGroup:  resourceGroup,
+		region:         region,
+		logger:         logger,
+	}
+}
+
+// clientSecretTokenSource returns the client credentials flow of a service principal, nil when the
+// tenant or client id is missing
+func clientSecretTokenSource(lookup func(string) string) oauth2.TokenSource {
+	tenantID, clientID := lookup(TenantIDVar), lookup(ClientIDVar)
+	if tenantID == "" || clientID == "" {
+		return nil
+	}
+	authorityHost := defaultAuthorityHost
+	if val := lookup(AuthorityHostVar); val != "" {
+		authorityHost = val
+	}
+	if !strings.HasSuffix(authorityHost, "/") {
+		authorityHost += "/"
+	}
+	config := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: lookup(ClientSecretVar),
+		TokenURL:     fmt.Sprintf("%s%s/oauth2/v2.0/token", authorityHost, tenantID),
+		Scopes:       []string{resourceManagerScope},
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	return config.TokenSource(context.Background())
+}
+
+// GetAccountID returns the subscription id once the subscription can be read with the credentials
+func (a *InternalAzureClient) GetAccountID() (string, error) {
+	subscription := struct {
+		SubscriptionID string `json:"subscriptionId"`
+	}{}
+	if err := a.do(http.MethodGet, "/subscriptions/"+a.subscriptionID, subscriptionsAPIVersion, nil, &subscription); err != nil {
+		return "", err
+	}
+	return subscription.SubscriptionID, nil
+}
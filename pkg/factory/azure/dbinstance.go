@@ -0,0 +1,252 @@
+package azure
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"net/http"
+	"strings"
+)
+
+func (a *InternalAzureClient) CreateDBInstance(input *v1alpha1.DBInstance, password string) error {
+	sType, err := serverType(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	if input.Spec.MasterUsername == "" {
+		return ErrMissingMasterUsername{Message: fmt.Sprintf("%s/%s - masterUsername is required by flexible servers",
+			input.Namespace, input.Name)}
+	}
+	version, err := serverVersion(input.Spec.Engine, input.Spec.EngineVersion)
+	if err != nil {
+		return err
+	}
+	desired := desiredServer(input, a.cloudTags(input))
+	desired.Location = a.region
+	desired.Properties.AdministratorLogin = input.Spec.MasterUsername
+	desired.Properties.AdministratorLoginPassword = password
+	desired.Properties.Version = version
+	desired.Properties.CreateMode = "Default"
+	desired.Properties.AvailabilityZone = input.Spec.AvailabilityZone
+	desired.Properties.Network = &network{PublicNetworkAccess: publicNetworkAccess(input.Spec.PubliclyAccessible)}
+	return a.do(http.MethodPut, a.serverID(sType, input), sType.apiVersion, desired, nil)
+}
+
+func (a *InternalAzureClient) DeleteDBInstance(input *v1alpha1.DBInstance) error {
+	nsName := fmt.Sprintf("%s/%s", input.Namespace, input.Name)
+	if input.Spec.DeletionProtection {
+		msg := fmt.Sprintf("%s - cannot delete instance when deletion protection is enabled", nsName)
+		return ErrDBInstanceDeletionProtectionEnabled{Message: msg}
+	}
+	sType, err := serverType(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	if err := a.do(http.MethodDelete, a.serverID(sType, input), sType.apiVersion, nil, nil); err != nil {
+		if isNotFound(err) {
+			a.logger.Info(fmt.Sprintf("%s - server not found, skipping cleanup", nsName))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (a *InternalAzureClient) ModifyDBInstance(modifyIn interface{}) error {
+	patch, ok := modifyIn.(*serverPatch)
+	if !ok {
+		return fmt.Errorf("expected *azure.serverPatch but got: %T", modifyIn)
+	}
+	return a.do(http.MethodPatch, patch.id, patch.apiVersion, patch.body, nil)
+}
+
+func (a *InternalAzureClient) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error) {
+	sType, err := serverType(input.Spec.Engine)
+	if err != nil {
+		return nil, err
+	}
+	live, err := a.getServer(a.serverID(sType, input), sType.apiVersion)
+	if err != nil {
+		if isNotFound(err) {
+			return &v1alpha1.DBStatus{}, nil
+		}
+		return nil, err
+	}
+	status := &v1alpha1.DBStatus{
+		Exists: true,
+		Arn:    live.ID,
+		Port:   sType.port,
+		Tags:   fromAzureTags(live.Tags),
+	}
+	if live.Sku != nil {
+		status.InstanceClass = live.Sku.Name
+	}
+	if props := live.Properties; props != nil {
+		status.CurrentPhase = serverPhase(props.State)
+		status.Endpoint = props.FullyQualifiedDomainName
+		status.EngineVersion = props.Version
+		if props.Storage != nil {
+			status.AllocatedStorage = props.Storage.StorageSizeGB
+		}
+		status.MultiAZ = props.HighAvailability != nil && props.HighAvailability.Mode == "ZoneRedundant"
+	}
+	return status, nil
+}
+
+// IsDBInstanceUpToDate compares the live server with the spec, the returned *serverPatch only holds
+// the fields that differ. Storage only grows, network access is only set on create and only postgres
+// servers upgrade their major version in place.
+func (a *InternalAzureClient) IsDBInstanceUpToDate(input *v1alpha1.DBInstance) (bool, interface{}, error) {
+	sType, err := serverType(input.Spec.Engine)
+	if err != nil {
+		return false, nil, err
+	}
+	id := a.serverID(sType, input)
+	live, err := a.getServer(id, sType.apiVersion)
+	if err != nil {
+		return false, nil, err
+	}
+	current := live.Properties
+	if current == nil {
+		current = &serverProperties{}
+	}
+	desired := desiredServer(input, a.cloudTags(input))
+	patch := &server{}
+	changes := &serverProperties{}
+	propertiesChanged := false
+
+	if desired.Sku != nil && (live.Sku == nil || live.Sku.Name != desired.Sku.Name) {
+		patch.Sku = desired.Sku
+	}
+	if tags, tagsDiffer := tagsChanged(live.Tags, desired.Tags); tagsDiffer {
+		patch.Tags = tags
+	}
+	if sType == postgresServers && input.Spec.EngineVersion != "" {
+		version, err := serverVersion(input.Spec.Engine, input.Spec.EngineVersion)
+		if err != nil {
+			return false, nil, err
+		}
+		if version != current.Version {
+			changes.Version = version
+			changes.CreateMode = "Update"
+			propertiesChanged = true
+		}
+	}
+	if desired.Properties.Storage != nil && (current.Storage == nil ||
+		desired.Properties.Storage.StorageSizeGB > current.Storage.StorageSizeGB) {
+		changes.Storage = desired.Properties.Storage
+		propertiesChanged = true
+	}
+	if desired.Properties.Backup != nil && (current.Backup == nil ||
+		desired.Properties.Backup.BackupRetentionDays != current.Backup.BackupRetentionDays) {
+		changes.Backup = desired.Properties.Backup
+		propertiesChanged = true
+	}
+	if current.HighAvailability == nil || desired.Properties.HighAvailability.Mode != current.HighAvailability.Mode {
+		changes.HighAvailability = desired.Properties.HighAvailability
+		propertiesChanged = true
+	}
+	if propertiesChanged {
+		patch.Properties = changes
+	}
+	if patch.Sku == nil && patch.Tags == nil && !propertiesChanged {
+		return true, nil, nil
+	}
+	return false, &serverPatch{id: id, apiVersion: sType.apiVersion, body: patch}, nil
+}
+
+// UpdateDBInstancePassword sets the password of the administrator login
+func (a *InternalAzureClient) UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error {
+	sType, err := serverType(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	return a.do(http.MethodPatch, a.serverID(sType, input), sType.apiVersion,
+		&server{Properties: &serverProperties{AdministratorLoginPassword: password}}, nil)
+}
+
+// BackfillDBInstanceSpec fills the spec fields left empty from the live server. Boolean
+// fields that are false in the spec take the live value.
+func (a *InternalAzureClient) BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error {
+	sType, err := serverType(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	live, err := a.getServer(a.serverID(sType, input), sType.apiVersion)
+	if err != nil {
+		return err
+	}
+	spec := &input.Spec
+	if spec.DBInstanceClass == "" && live.Sku != nil {
+		spec.DBInstanceClass = live.Sku.Name
+	}
+	if len(spec.Tags) == 0 {
+		spec.Tags = utils.WithoutDefaultTags(fromAzureTags(live.Tags), a.defaultTags)
+		delete(spec.Tags, v1alpha1.OwnerTagKey)
+	}
+	props := live.Properties
+	if props == nil {
+		return nil
+	}
+	if spec.MasterUsername == "" {
+		spec.MasterUsername = props.AdministratorLogin
+	}
+	if spec.AllocatedStorage == 0 && props.Storage != nil {
+		spec.AllocatedStorage = props.Storage.StorageSizeGB
+	}
+	if spec.BackupRetentionPeriod == 0 && props.Backup != nil {
+		spec.BackupRetentionPeriod = props.Backup.BackupRetentionDays
+	}
+	spec.MultiAZ = spec.MultiAZ || (props.HighAvailability != nil && props.HighAvailability.Mode == "ZoneRedundant")
+	spec.PubliclyAccessible = spec.PubliclyAccessible || (props.Network != nil && props.Network.PublicNetworkAccess == "Enabled")
+	return nil
+}
+
+// AddTagsToResource adds tags to the server, arn is the resource id of the server
+func (a *InternalAzureClient) AddTagsToResource(arn string, tags map[string]string) error {
+	sType := serverTypeOfID(arn)
+	live, err := a.getServer(arn, sType.apiVersion)
+	if err != nil {
+		return err
+	}
+	merged, changed := tagsChanged(live.Tags, toAzureTags(tags))
+	if !changed {
+		return nil
+	}
+	return a.do(http.MethodPatch, arn, sType.apiVersion, &server{Tags: merged}, nil)
+}
+
+func (a *InternalAzureClient) getServer(id, apiVersion string) (*server, error) {
+	out := &server{}
+	if err := a.do(http.MethodGet, id, apiVersion, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// serverID returns the resource id of the flexible server of a DBInstance, server names only
+// allow lowercase letters, digits and hyphens
+func (a *InternalAzureClient) serverID(sType flexibleServerType, input *v1alpha1.DBInstance) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s/flexibleServers/%s", a.subscriptionID,
+		a.resourceGroup, sType.namespace, strings.ToLower(input.GetDBInstanceID()))
+}
+
+// desiredServer returns the sku, tags and settings the spec asks for that can change after create
+func desiredServer(input *v1alpha1.DBInstance, tags map[string]string) *server {
+	out := &server{
+		Tags: toAzureTags(tags),
+		Properties: &serverProperties{
+			HighAvailability: &highAvailability{Mode: highAvailabilityMode(input.Spec.MultiAZ)},
+		},
+	}
+	if input.Spec.DBInstanceClass != "" {
+		out.Sku = &sku{Name: input.Spec.DBInstanceClass, Tier: skuTier(input.Spec.DBInstanceClass)}
+	}
+	if input.Spec.AllocatedStorage > 0 {
+		out.Properties.Storage = &storage{StorageSizeGB: input.Spec.AllocatedStorage}
+	}
+	if input.Spec.BackupRetentionPeriod > 0 {
+		out.Properties.Backup = &backup{BackupRetentionDays: input.Spec.BackupRetentionPeriod}
+	}
+	return out
+}
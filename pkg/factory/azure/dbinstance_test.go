@@ -0,0 +1,414 @@
+package azure
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/go-logr/logr"
+	"io/ioutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testTenant       = "tenant"
+	testSubscription = "00000000-0000-0000-0000-000000000000"
+	testGroup        = "databases"
+)
+
+// fakeResourceManager is a minimal in memory Azure Resource Manager for flexible servers plus the
+// token endpoint of azure active directory
+type fakeResourceManager struct {
+	sync.Mutex
+	servers map[string]*server
+	// denied makes every API call fail with AuthorizationFailed
+	denied bool
+	tokens int
+	// apiVersions records the api-version used per resource provider namespace
+	apiVersions map[string]string
+}
+
+func newFakeResourceManager() *fakeResourceManager {
+	return &fakeResourceManager{servers: map[string]*server{}, apiVersions: map[string]string{}}
+}
+
+func (f *fakeResourceManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if r.URL.Path == "/"+testTenant+"/oauth2/v2.0/token" {
+		_ = r.ParseForm()
+		if r.PostForm.Get("client_secret") != "secret" || r.PostForm.Get("scope") != resourceManagerScope {
+			writeError(w, http.StatusUnauthorized, "invalid_client")
+			return
+		}
+		f.tokens++
+		writeJSON(w, map[string]interface{}{"access_token": "fake-token", "token_type": "Bearer", "expires_in": 3600})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer fake-token" {
+		writeError(w, http.StatusUnauthorized, "InvalidAuthenticationToken")
+		return
+	}
+	if f.denied {
+		writeError(w, http.StatusForbidden, "AuthorizationFailed")
+		return
+	}
+	if r.URL.Path == "/subscriptions/"+testSubscription {
+		writeJSON(w, map[string]string{"subscriptionId": testSubscription})
+		return
+	}
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 9 || parts[4] != testGroup || parts[7] != "flexibleServers" {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound")
+		return
+	}
+	f.apiVersions[parts[6]] = r.URL.Query().Get("api-version")
+	body, _ := ioutil.ReadAll(r.Body)
+	live, ok := f.servers[r.URL.Path]
+	switch r.Method {
+	case http.MethodPut:
+		in := &server{}
+		_ = json.Unmarshal(body, in)
+		in.ID = r.URL.Path
+		in.Name = parts[8]
+		in.Properties.State = "Ready"
+		in.Properties.FullyQualifiedDomainName = parts[8] + ".postgres.database.azure.com"
+		if in.Properties.Backup == nil {
+			in.Properties.Backup = &backup{BackupRetentionDays: 7}
+		}
+		f.servers[r.URL.Path] = in
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceNotFound")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		out := *live
+		props := *live.Properties
+		props.AdministratorLoginPassword = ""
+		out.Properties = &props
+		writeJSON(w, &out)
+	case http.MethodDelete:
+		delete(f.servers, r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPatch:
+		patch := &server{}
+		_ = json.Unmarshal(body, patch)
+		if patch.Sku != nil {
+			live.Sku = patch.Sku
+		}
+		if patch.Tags != nil {
+			live.Tags = patch.Tags
+		}
+		if patch.Properties != nil {
+			raw, _ := json.Marshal(patch.Properties)
+			_ = json.Unmarshal(raw, live.Properties)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, out interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func writeError(w http.ResponseWriter, code int, errCode string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"code": errCode, "message": "fake " + errCode},
+	})
+}
+
+// newTestClient returns a client that authenticates with a client secret against the fake
+func newTestClient(t *testing.T, fake *fakeResourceManager) *InternalAzureClient {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	_ = os.Setenv(MockAzureEndpoint, server.URL)
+	t.Cleanup(func() { _ = os.Unsetenv(MockAzureEndpoint) })
+
+	c, err := NewInternalAzureClient(logr.Discard(), "eastus", t.Name(), "1", map[string][]byte{
+		TenantIDVar:       []byte(testTenant),
+		ClientIDVar:       []byte("client"),
+		ClientSecretVar:   []byte("secret"),
+		SubscriptionIDVar: []byte(testSubscription),
+		ResourceGroupVar:  []byte(testGroup),
+		AuthorityHostVar:  []byte(server.URL),
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewInternalAzureClient() error = %v", err)
+	}
+	return c
+}
+
+func newTestDBInstance() *v1alpha1.DBInstance {
+	return &v1alpha1.DBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "App", Namespace: "team"},
+		Spec: v1alpha1.DBInstanceSpec{
+			Engine:                "postgres",
+			EngineVersion:         "13.4",
+			DBInstanceClass:       "Standard_B1ms",
+			MasterUsername:        "dbadmin",
+			AllocatedStorage:      32,
+			BackupRetentionPeriod: 14,
+			Tags:                  map[string]string{"team/name": "payments"},
+		},
+	}
+}
+
+func TestDBInstanceLifecycle(t *testing.T) {
+	fake := newFakeResourceManager()
+	c := newTestClient(t, fake)
+	cr := newTestDBInstance()
+	id := "/subscriptions/" + testSubscription + "/resourceGroups/" + testGroup +
+		"/providers/Microsoft.DBforPostgreSQL/flexibleServers/team-app"
+
+	if account, err := c.GetAccountID(); err != nil || account != testSubscription {
+		t.Fatalf("GetAccountID() = %v, %v, want %v", account, err, testSubscription)
+	}
+	status, err := c.DBInstanceExists(cr)
+	if err != nil || status.Exists {
+		t.Fatalf("DBInstanceExists() = %v, %v, want not found", status, err)
+	}
+	if err := c.CreateDBInstance(cr, "password"); err != nil {
+		t.Fatalf("CreateDBInstance() error = %v", err)
+	}
+	created := fake.servers[id]
+	if created == nil {
+		t.Fatalf("CreateDBInstance() did not create %v", id)
+	}
+	if created.Location != "eastus" || created.Sku.Tier != "Burstable" || created.Properties.Version != "13" ||
+		created.Properties.AdministratorLoginPassword != "password" || created.Properties.HighAvailability.Mode != "Disabled" ||
+		created.Properties.Network.PublicNetworkAccess != "Disabled" || created.Tags["team_name"] != "payments" {
+		t.Errorf("CreateDBInstance() created %+v with properties %+v", created, created.Properties)
+	}
+	if fake.apiVersions["Microsoft.DBforPostgreSQL"] != postgresServers.apiVersion {
+		t.Errorf("CreateDBInstance() api-version = %v", fake.apiVersions)
+	}
+
+	status, err = c.DBInstanceExists(cr)
+	if err != nil {
+		t.Fatalf("DBInstanceExists() error = %v", err)
+	}
+	if !status.Exists || status.CurrentPhase != "available" || status.Endpoint != "team-app.postgres.database.azure.com" ||
+		status.Port != 5432 || status.Arn != id || status.Tags[v1alpha1.OwnerTagKey] != cr.GetOwnerTagValue() {
+		t.Errorf("DBInstanceExists() = %+v", status)
+	}
+
+	upToDate, _, err := c.IsDBInstanceUpToDate(cr)
+	if err != nil || !upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want up to date after create", upToDate, err)
+	}
+
+	cr.Spec.DBInstanceClass = "Standard_D2ds_v4"
+	cr.Spec.AllocatedStorage = 64
+	cr.Spec.MultiAZ = true
+	upToDate, modifyIn, err := c.IsDBInstanceUpToDate(cr)
+	if err != nil || upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want a modification", upToDate, err)
+	}
+	patch := modifyIn.(*serverPatch)
+	if patch.body.Tags != nil || patch.body.Properties.Backup != nil || patch.body.Sku.Tier != "GeneralPurpose" {
+		t.Errorf("IsDBInstanceUpToDate() patch %+v should only hold the changed fields", patch.body)
+	}
+	if err := c.ModifyDBInstance(modifyIn); err != nil {
+		t.Fatalf("ModifyDBInstance() error = %v", err)
+	}
+	if upToDate, _, err := c.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want up to date after modify", upToDate, err)
+	}
+	if status, _ := c.DBInstanceExists(cr); !status.MultiAZ || status.AllocatedStorage != 64 {
+		t.Errorf("DBInstanceExists() after modify = %+v", status)
+	}
+
+	// storage cannot shrink, a smaller spec is not a modification
+	cr.Spec.AllocatedStorage = 32
+	if upToDate, _, err := c.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Errorf("IsDBInstanceUpToDate() = %v, %v, want up to date when storage shrinks", upToDate, err)
+	}
+
+	cr.Spec.EngineVersion = "14"
+	if _, modifyIn, _ := c.IsDBInstanceUpToDate(cr); modifyIn == nil ||
+		modifyIn.(*serverPatch).body.Properties.Version != "14" || modifyIn.(*serverPatch).body.Properties.CreateMode != "Update" {
+		t.Errorf("IsDBInstanceUpToDate() = %+v, want a major version upgrade", modifyIn)
+	}
+
+	if err := c.UpdateDBInstancePassword(cr, "rotated"); err != nil {
+		t.Fatalf("UpdateDBInstancePassword() error = %v", err)
+	}
+	if got := fake.servers[id].Properties.AdministratorLoginPassword; got != "rotated" {
+		t.Errorf("UpdateDBInstancePassword() set %v, want rotated", got)
+	}
+
+	if err := c.AddTagsToResource(status.Arn, map[string]string{"cost-center": "42"}); err != nil {
+		t.Fatalf("AddTagsToResource() error = %v", err)
+	}
+	if tags := fake.servers[id].Tags; tags["cost-center"] != "42" || tags["team_name"] != "payments" {
+		t.Errorf("AddTagsToResource() tags = %v", tags)
+	}
+
+	if err := c.DeleteDBInstance(cr); err != nil {
+		t.Fatalf("DeleteDBInstance() error = %v", err)
+	}
+	if err := c.DeleteDBInstance(cr); err != nil {
+		t.Errorf("DeleteDBInstance() of a deleted server error = %v, want nil", err)
+	}
+	if status, err := c.DBInstanceExists(cr); err != nil || status.Exists {
+		t.Errorf("DBInstanceExists() after delete = %v, %v", status, err)
+	}
+	if fake.tokens != 1 {
+		t.Errorf("token endpoint called %v times, want the token to be reused", fake.tokens)
+	}
+}
+
+func TestBackfillDBInstanceSpec(t *testing.T) {
+	fake := newFakeResourceManager()
+	c := newTestClient(t, fake)
+	created := newTestDBInstance()
+	created.Spec.Engine = "mysql"
+	created.Spec.EngineVersion = "8.0.28"
+	created.Spec.PubliclyAccessible = true
+	if err := c.CreateDBInstance(created, "password"); err != nil {
+		t.Fatal(err)
+	}
+	if fake.apiVersions["Microsoft.DBforMySQL"] != mysqlServers.apiVersion {
+		t.Errorf("CreateDBInstance() api-version = %v", fake.apiVersions)
+	}
+	cr := &v1alpha1.DBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "App", Namespace: "team"},
+		Spec:       v1alpha1.DBInstanceSpec{Engine: "mysql"},
+	}
+	if err := c.BackfillDBInstanceSpec(cr); err != nil {
+		t.Fatalf("BackfillDBInstanceSpec() error = %v", err)
+	}
+	if cr.Spec.DBInstanceClass != "Standard_B1ms" || cr.Spec.AllocatedStorage != 32 || cr.Spec.MasterUsername != "dbadmin" ||
+		cr.Spec.BackupRetentionPeriod != 14 || !cr.Spec.PubliclyAccessible || cr.Spec.Tags["team_name"] != "payments" {
+		t.Errorf("BackfillDBInstanceSpec() spec = %+v", cr.Spec)
+	}
+	if _, ok := cr.Spec.Tags[v1alpha1.OwnerTagKey]; ok {
+		t.Errorf("BackfillDBInstanceSpec() should not copy the owner tag into tags")
+	}
+	if upToDate, _, err := c.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Errorf("IsDBInstanceUpToDate() = %v, %v, want up to date after backfill", upToDate, err)
+	}
+}
+
+func TestDBInstanceErrors(t *testing.T) {
+	fake := newFakeResourceManager()
+	c := newTestClient(t, fake)
+
+	cr := newTestDBInstance()
+	cr.Spec.MasterUsername = ""
+	if err := c.CreateDBInstance(cr, "password"); !errors.As(err, &ErrMissingMasterUsername{}) {
+		t.Errorf("CreateDBInstance() without masterUsername error = %v, want ErrMissingMasterUsername", err)
+	}
+
+	cr = newTestDBInstance()
+	cr.Spec.Engine = "sqlserver-ex"
+	if err := c.CreateDBInstance(cr, "password"); !errors.As(err, &ErrUnsupportedEngine{}) {
+		t.Errorf("CreateDBInstance() of sqlserver error = %v, want ErrUnsupportedEngine", err)
+	}
+
+	cr = newTestDBInstance()
+	cr.Spec.DeletionProtection = true
+	if err := c.DeleteDBInstance(cr); !errors.As(err, &ErrDBInstanceDeletionProtectionEnabled{}) {
+		t.Errorf("DeleteDBInstance() error = %v, want ErrDBInstanceDeletionProtectionEnabled", err)
+	}
+
+	fake.denied = true
+	_, err := c.DBInstanceExists(newTestDBInstance())
+	if !utils.IsCredentialsError(err) {
+		t.Errorf("DBInstanceExists() error = %v, want a credentials error", err)
+	}
+	if reason, _ := utils.ReasonFromError(err, "Fallback"); reason != "AuthorizationFailed" {
+		t.Errorf("ReasonFromError() = %v, want AuthorizationFailed", reason)
+	}
+}
+
+func TestNewInternalAzureClientCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials map[string][]byte
+	}{
+		{name: "missing resource group", credentials: map[string][]byte{
+			TenantIDVar: []byte(testTenant), ClientIDVar: []byte("client"), ClientSecretVar: []byte("secret"),
+			SubscriptionIDVar: []byte(testSubscription),
+		}},
+		{name: "missing client secret", credentials: map[string][]byte{
+			TenantIDVar: []byte(testTenant), ClientIDVar: []byte("client"),
+			SubscriptionIDVar: []byte(testSubscription), ResourceGroupVar: []byte(testGroup),
+		}},
+		{name: "missing tenant", credentials: map[string][]byte{
+			ClientIDVar: []byte("client"), ClientSecretVar: []byte("secret"),
+			SubscriptionIDVar: []byte(testSubscription), ResourceGroupVar: []byte(testGroup),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewInternalAzureClient(logr.Discard(), "eastus", t.Name(), "1", tt.credentials, nil)
+			if !errors.As(err, &ErrMissingAzureCredentials{}) {
+				t.Errorf("NewInternalAzureClient() error = %v, want ErrMissingAzureCredentials", err)
+			}
+		})
+	}
+}
+
+func TestNewInternalAzureClientCache(t *testing.T) {
+	credentials := map[string][]byte{
+		TenantIDVar: []byte(testTenant), ClientIDVar: []byte("client"), ClientSecretVar: []byte("secret"),
+		SubscriptionIDVar: []byte(testSubscription), ResourceGroupVar: []byte(testGroup),
+	}
+	first, err := NewInternalAzureClient(logr.Discard(), "eastus", t.Name(), "1", credentials, nil)
+	if err != nil {
+		t.Fatalf("NewInternalAzureClient() error = %v", err)
+	}
+	if cached, _ := NewInternalAzureClient(logr.Discard(), "eastus", t.Name(), "1", credentials, nil); cached != first {
+		t.Errorf("NewInternalAzureClient() of the same version did not return the cached client")
+	}
+	rotated, err := NewInternalAzureClient(logr.Discard(), "eastus", t.Name(), "2", credentials, nil)
+	if err != nil || rotated == first {
+		t.Fatalf("NewInternalAzureClient() of a new version = %p, %v, want a new client", rotated, err)
+	}
+	if cached, _ := azureClientCache.Load(getAzureClientCacheKey(t.Name(), "eastus")); cached != rotated {
+		t.Errorf("cached client = %p, want the client of the new version %p replacing the old one", cached, rotated)
+	}
+}
+
+func TestServerVersion(t *testing.T) {
+	tests := []struct {
+		engine  string
+		version string
+		want    string
+		wantErr bool
+	}{
+		{engine: "postgres", version: "", want: "14"},
+		{engine: "postgres", version: "13.4", want: "13"},
+		{engine: "mysql", version: "", want: "8.0.21"},
+		{engine: "mysql", version: "8.0.28", want: "8.0.21"},
+		{engine: "mysql", version: "5.7.38", want: "5.7"},
+		{engine: "mysql", version: "5.6", wantErr: true},
+		{engine: "mysql", version: "8", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engine+"-"+tt.version, func(t *testing.T) {
+			got, err := serverVersion(tt.engine, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("serverVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("serverVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
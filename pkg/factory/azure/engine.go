@@ -0,0 +1,105 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+)
+
+// flexibleServerType describes the resource type and api version of the flexible servers of an engine
+type flexibleServerType struct {
+	namespace  string
+	apiVersion string
+	port       int64
+}
+
+var (
+	postgresServers = flexibleServerType{namespace: "Microsoft.DBforPostgreSQL", apiVersion: "2022-12-01", port: 5432}
+	mysqlServers    = flexibleServerType{namespace: "Microsoft.DBforMySQL", apiVersion: "2021-05-01", port: 3306}
+)
+
+func serverType(engine string) (flexibleServerType, error) {
+	switch engine {
+	case "postgres":
+		return postgresServers, nil
+	case "mysql":
+		return mysqlServers, nil
+	}
+	return flexibleServerType{}, ErrUnsupportedEngine{Message: fmt.Sprintf("engine %s is not supported by Azure Database "+
+		"flexible servers, use postgres or mysql", engine)}
+}
+
+// serverTypeOfID returns the server type from the resource provider namespace in a resource id
+func serverTypeOfID(id string) flexibleServerType {
+	if strings.Contains(strings.ToLower(id), strings.ToLower(mysqlServers.namespace)) {
+		return mysqlServers
+	}
+	return postgresServers
+}
+
+// serverVersion returns the flexible server version of an engine version, postgres only keeps the
+// major version ( 13.4 is 13 ) and mysql 8.0 is always 8.0.21
+func serverVersion(engine, engineVersion string) (string, error) {
+	parts := strings.Split(engineVersion, ".")
+	if engine == "postgres" {
+		if engineVersion == "" {
+			return "14", nil
+		}
+		return parts[0], nil
+	}
+	if engineVersion == "" {
+		return "8.0.21", nil
+	}
+	if len(parts) < 2 {
+		return "", ErrUnsupportedEngine{Message: fmt.Sprintf("mysql engineVersion %s needs a major and minor version, e.g 8.0", engineVersion)}
+	}
+	switch parts[0] + "." + parts[1] {
+	case "5.7":
+		return "5.7", nil
+	case "8.0":
+		return "8.0.21", nil
+	}
+	return "", ErrUnsupportedEngine{Message: fmt.Sprintf("mysql engineVersion %s is not supported by flexible servers", engineVersion)}
+}
+
+// skuTier returns the pricing tier of a sku, e.g Standard_B1ms is Burstable and Standard_E4ds_v4
+// is MemoryOptimized
+func skuTier(name string) string {
+	switch {
+	case strings.HasPrefix(name, "Standard_B"):
+		return "Burstable"
+	case strings.HasPrefix(name, "Standard_E"):
+		return "MemoryOptimized"
+	}
+	return "GeneralPurpose"
+}
+
+// highAvailabilityMode maps multiAZ onto a zone redundant standby
+func highAvailabilityMode(multiAZ bool) string {
+	if multiAZ {
+		return "ZoneRedundant"
+	}
+	return "Disabled"
+}
+
+// publicNetworkAccess maps publiclyAccessible onto the network access of the server
+func publicNetworkAccess(publiclyAccessible bool) string {
+	if publiclyAccessible {
+		return "Enabled"
+	}
+	return "Disabled"
+}
+
+// serverPhase converts the state of a server into the phase reported by the factories
+func serverPhase(state string) string {
+	switch state {
+	case "Ready":
+		return "available"
+	case "Updating", "Starting", "Stopping", "Restarting":
+		return "modifying"
+	case "Provisioning", "Creating":
+		return "creating"
+	case "Dropping":
+		return "deleting"
+	}
+	return strings.ToLower(state)
+}
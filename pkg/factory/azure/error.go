@@ -0,0 +1,66 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type ErrMissingAzureCredentials struct {
+	Message string
+}
+
+func (e ErrMissingAzureCredentials) Error() string {
+	return e.Message
+}
+
+type ErrUnsupportedEngine struct {
+	Message string
+}
+
+func (e ErrUnsupportedEngine) Error() string {
+	return e.Message
+}
+
+type ErrMissingMasterUsername struct {
+	Message string
+}
+
+func (e ErrMissingMasterUsername) Error() string {
+	return e.Message
+}
+
+type ErrDBInstanceDeletionProtectionEnabled struct {
+	Message string
+}
+
+func (e ErrDBInstanceDeletionProtectionEnabled) Error() string {
+	return e.Message
+}
+
+// apiError is the error body returned by Azure Resource Manager, it carries the error code
+// ( e.g ResourceNotFound, AuthorizationFailed ) used as condition reason
+type apiError struct {
+	HTTPCode int    `json:"-"`
+	ErrCode  string `json:"code"`
+	Msg      string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code(), e.Msg)
+}
+
+func (e *apiError) Code() string {
+	if e.ErrCode != "" {
+		return e.ErrCode
+	}
+	return http.StatusText(e.HTTPCode)
+}
+
+func (e *apiError) Message() string {
+	return e.Msg
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.HTTPCode == http.StatusNotFound
+}
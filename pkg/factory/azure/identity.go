@@ -0,0 +1,79 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/oauth2"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// IMDSEndpointVar overrides the instance metadata service handing out managed identity tokens
+	IMDSEndpointVar     = "AZURE_IMDS_ENDPOINT"
+	defaultIMDSEndpoint = "http://169.254.169.254"
+	imdsAPIVersion      = "2018-02-01"
+)
+
+// managedIdentity reads tokens of the managed identity assigned to the node from the instance
+// metadata service, clientID selects a user assigned identity
+type managedIdentity struct {
+	endpoint   string
+	clientID   string
+	httpClient *http.Client
+}
+
+func newManagedIdentity(clientID string) *managedIdentity {
+	endpoint := defaultIMDSEndpoint
+	if val, ok := os.LookupEnv(IMDSEndpointVar); ok {
+		endpoint = val
+	}
+	return &managedIdentity{endpoint: endpoint, clientID: clientID, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// Token implements oauth2.TokenSource
+func (m *managedIdentity) Token() (*oauth2.Token, error) {
+	query := url.Values{
+		"api-version": []string{imdsAPIVersion},
+		"resource":    []string{resourceManagerEndpoint},
+	}
+	if m.clientID != "" {
+		query.Set("client_id", m.clientID)
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/metadata/identity/oauth2/token?%s", m.endpoint, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("instance metadata service returned %d: %s", resp.StatusCode, body)
+	}
+	// expires_in is a string in the responses of the instance metadata service
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   string `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, err
+	}
+	expiresIn, _ := strconv.ParseInt(token.ExpiresIn, 10, 64)
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      time.Now().Add(time.Duration(expiresIn) * time.Second),
+	}, nil
+}
@@ -0,0 +1,60 @@
+package azure
+
+import (
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"strings"
+)
+
+// ownerTagName replaces the owner tag key, azure tag names cannot hold its "/"
+const ownerTagName = "db-operator-owner"
+
+var tagNameReplacer = strings.NewReplacer("<", "_", ">", "_", "%", "_", "&", "_", "\\", "_", "?", "_", "/", "_")
+
+// cloudTags returns the tags of the server plus the default tags of the provider
+func (a *InternalAzureClient) cloudTags(input *v1alpha1.DBInstance) map[string]string {
+	return utils.MergeTags(a.defaultTags, input.GetCloudTags())
+}
+
+// toAzureTags converts tags into azure tags
+func toAzureTags(tags map[string]string) map[string]string {
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if k == v1alpha1.OwnerTagKey {
+			out[ownerTagName] = v
+			continue
+		}
+		out[tagNameReplacer.Replace(k)] = v
+	}
+	return out
+}
+
+// fromAzureTags converts azure tags back into tags
+func fromAzureTags(tags map[string]string) map[string]string {
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if k == ownerTagName {
+			out[v1alpha1.OwnerTagKey] = v
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// tagsChanged returns current plus desired when a desired tag is missing or differs, tags
+// set outside of the operator are kept
+func tagsChanged(current, desired map[string]string) (map[string]string, bool) {
+	changed := false
+	out := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		out[k] = v
+	}
+	for k, v := range desired {
+		if current[k] != v {
+			changed = true
+		}
+		out[k] = v
+	}
+	return out, changed
+}
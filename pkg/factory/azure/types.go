@@ -0,0 +1,55 @@
+package azure
+
+// subset of the flexible server resource shared by Microsoft.DBforPostgreSQL and Microsoft.DBforMySQL,
+// optional fields are pointers so a patch only carries the fields that change
+
+type server struct {
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Location   string            `json:"location,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Sku        *sku              `json:"sku,omitempty"`
+	Properties *serverProperties `json:"properties,omitempty"`
+}
+
+type sku struct {
+	Name string `json:"name"`
+	Tier string `json:"tier"`
+}
+
+type serverProperties struct {
+	AdministratorLogin         string            `json:"administratorLogin,omitempty"`
+	AdministratorLoginPassword string            `json:"administratorLoginPassword,omitempty"`
+	Version                    string            `json:"version,omitempty"`
+	State                      string            `json:"state,omitempty"`
+	FullyQualifiedDomainName   string            `json:"fullyQualifiedDomainName,omitempty"`
+	AvailabilityZone           string            `json:"availabilityZone,omitempty"`
+	CreateMode                 string            `json:"createMode,omitempty"`
+	Storage                    *storage          `json:"storage,omitempty"`
+	Backup                     *backup           `json:"backup,omitempty"`
+	HighAvailability           *highAvailability `json:"highAvailability,omitempty"`
+	Network                    *network          `json:"network,omitempty"`
+}
+
+type storage struct {
+	StorageSizeGB int64 `json:"storageSizeGB,omitempty"`
+}
+
+type backup struct {
+	BackupRetentionDays int64 `json:"backupRetentionDays,omitempty"`
+}
+
+type highAvailability struct {
+	Mode string `json:"mode,omitempty"`
+}
+
+type network struct {
+	PublicNetworkAccess string `json:"publicNetworkAccess,omitempty"`
+}
+
+// serverPatch is the modifyIn returned by IsDBInstanceUpToDate and applied by ModifyDBInstance
+type serverPatch struct {
+	id         string
+	apiVersion string
+	body       *server
+}
@@ -0,0 +1,48 @@
+package factory
+
+import (
+	"github.com/agill17/db-operator/api/v1alpha1"
+	internalAwsImpl "github.com/agill17/db-operator/pkg/factory/aws"
+	internalAzureImpl "github.com/agill17/db-operator/pkg/factory/azure"
+	internalGcpImpl "github.com/agill17/db-operator/pkg/factory/gcp"
+	internalLocalImpl "github.com/agill17/db-operator/pkg/factory/local"
+	"github.com/go-logr/logr"
+)
+
+// the providers of this repository, gcp, azure and local only implement DBInstance so far and get
+// utils.ErrNotSupported from everything else
+func init() {
+	Register(v1alpha1.AWS, Capabilities{Clusters: true, Snapshots: true, Replicas: true, Serverless: true,
+		SubnetGroups: true, ParameterGroups: true, OptionGroups: true},
+		func(logger logr.Logger, options ProviderOptions) (Provider, error) {
+			c, err := internalAwsImpl.NewInternalAwsClient(logger, options.Region, options.Name, options.Version,
+				options.Credentials, options.DefaultTags)
+			if err != nil {
+				return nil, err
+			}
+			return c, nil
+		})
+	Register(v1alpha1.GCP, Capabilities{}, func(logger logr.Logger, options ProviderOptions) (Provider, error) {
+		c, err := internalGcpImpl.NewInternalGcpClient(logger, options.Region, options.Name, options.Version,
+			options.Credentials, options.DefaultTags)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+	Register(v1alpha1.Azure, Capabilities{}, func(logger logr.Logger, options ProviderOptions) (Provider, error) {
+		c, err := internalAzureImpl.NewInternalAzureClient(logger, options.Region, options.Name, options.Version,
+			options.Credentials, options.DefaultTags)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+	Register(v1alpha1.Local, Capabilities{}, func(logger logr.Logger, options ProviderOptions) (Provider, error) {
+		c, err := internalLocalImpl.NewInternalLocalClient(logger, options.KubeClient, options.DefaultTags)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+}
@@ -0,0 +1,59 @@
+package gcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// do sends a request to the Cloud SQL Admin API, path is relative to the project. out is filled from
+// the response body when it is not nil, error bodies are returned as *apiError.
+func (g *InternalGcpClient) do(method, path string, query url.Values, in, out interface{}) error {
+	u := fmt.Sprintf("%sprojects/%s/%s", g.endpoint, url.PathEscape(g.project), path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var body *bytes.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	} else {
+		body = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		errBody := struct {
+			Error *apiError `json:"error"`
+		}{}
+		if json.Unmarshal(raw, &errBody) != nil || errBody.Error == nil {
+			return &apiError{HTTPCode: resp.StatusCode, Msg: string(raw)}
+		}
+		errBody.Error.HTTPCode = resp.StatusCode
+		return errBody.Error
+	}
+	if out == nil || len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, out)
+}
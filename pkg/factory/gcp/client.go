@@ -0,0 +1,164 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	MockGcpEndpoint = "MOCK_GCP_ENDPOINT"
+
+	// ServiceAccountJSONVar is the provider secret key holding a service account key file
+	ServiceAccountJSONVar = "GCP_SERVICE_ACCOUNT_JSON"
+	// ProjectVar optionally overrides the project of the service account, also read from the
+	// environment when the identity of the operator pod is used
+	ProjectVar = "GCP_PROJECT"
+
+	sqlAdminEndpoint = "https://sqladmin.googleapis.com/v1/"
+	sqlAdminScope    = "https://www.googleapis.com/auth/sqlservice.admin"
+	defaultTokenURL  = "https://oauth2.googleapis.com/token"
+)
+
+// InternalGcpClient manages Cloud SQL instances through the Cloud SQL Admin API.
+// Only factory.Provider is implemented, the factory fills in the rest as not supported.
+type InternalGcpClient struct {
+	httpClient  *http.Client
+	tokenSource oauth2.TokenSource
+	endpoint    string
+	project     string
+	region      string
+	account     func() (string, error)
+	logger      logr.Logger
+	// defaultTags of the provider, set as labels on every instance together with its own tags
+	defaultTags map[string]string
+	// cacheVersion is the version of the provider the cached client was created for
+	cacheVersion string
+}
+
+// serviceAccountKey is the subset of a service account key file needed to authenticate
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+var gcpClientCache sync.Map
+
+func getGcpClientCacheKey(pName, region string) string {
+	return fmt.Sprintf("gcp-%v-%v", pName, region)
+}
+
+// NewInternalGcpClient returns the cached client of the provider pName in region, a client cached for another
+// pVersion is replaced so the cache holds one client per provider and region.
+func NewInternalGcpClient(logger logr.Logger, region string, pName, pVersion string, providerCredentials map[string][]byte,
+	defaultTags map[string]string) (*InternalGcpClient, error) {
+	cacheKeyName := getGcpClientCacheKey(pName, region)
+	if cached, ok := gcpClientCache.Load(cacheKeyName); ok && cached.(*InternalGcpClient).cacheVersion == pVersion {
+		return cached.(*InternalGcpClient), nil
+	}
+
+	endpoint := sqlAdminEndpoint
+	if val, ok := os.LookupEnv(MockGcpEndpoint); ok {
+		endpoint = val
+	}
+	var tokenSource oauth2.TokenSource
+	var project string
+	var account func() (string, error)
+	if providerCredentials == nil {
+		// without provider credentials the service account attached to the operator pod is used
+		metadata := newMetadataClient()
+		tokenSource = oauth2.ReuseTokenSource(nil, metadata)
+		project = os.Getenv(ProjectVar)
+		if project == "" {
+			var err error
+			if project, err = metadata.projectID(); err != nil {
+				return nil, err
+			}
+		}
+		account = metadata.email
+	} else {
+		key, err := getServiceAccountKey(providerCredentials)
+		if err != nil {
+			return nil, err
+		}
+		tokenSource = key.jwtConfig().TokenSource(context.Background())
+		project = key.ProjectID
+		if val, ok := providerCredentials[ProjectVar]; ok {
+			project = string(val)
+		}
+		account = func() (string, error) { return key.ClientEmail, nil }
+	}
+	if project == "" {
+		return nil, ErrMissingGcpProject{Message: "GCP project is not set in the service account key nor in " + ProjectVar}
+	}
+	c := newInternalGcpClient(logger, oauth2.NewClient(context.Background(), tokenSource), tokenSource,
+		endpoint, project, region, account)
+	c.defaultTags = defaultTags
+	c.cacheVersion = pVersion
+	gcpClientCache.Store(cacheKeyName, c)
+	return c, nil
+}
+
+func newInternalGcpClient(logger logr.Logger, httpClient *http.Client, tokenSource oauth2.TokenSource,
+	endpoint, project, region string, account func() (string, error)) *InternalGcpClient {
+	if !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	return &InternalGcpClient{
+		httpClient:  httpClient,
+		tokenSource: tokenSource,
+		endpoint:    endpoint,
+		project:     project,
+		region:      region,
+		account:     account,
+		logger:      logger,
+	}
+}
+
+func getServiceAccountKey(providerCredentials map[string][]byte) (*serviceAccountKey, error) {
+	raw, ok := providerCredentials[ServiceAccountJSONVar]
+	if !ok {
+		return nil, ErrMissingServiceAccountKey{Message: "GCP provider credentials missing " + ServiceAccountJSONVar}
+	}
+	key := &serviceAccountKey{}
+	if err := json.Unmarshal(raw, key); err != nil {
+		return nil, ErrMissingServiceAccountKey{Message: fmt.Sprintf("%s is not a valid service account key: %v", ServiceAccountJSONVar, err)}
+	}
+	if key.Type != "service_account" || key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, ErrMissingServiceAccountKey{Message: fmt.Sprintf("%s is not a service account key", ServiceAccountJSONVar)}
+	}
+	return key, nil
+}
+
+func (k *serviceAccountKey) jwtConfig() *jwt.Config {
+	tokenURL := k.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultTokenURL
+	}
+	return &jwt.Config{
+		Email:        k.ClientEmail,
+		PrivateKey:   []byte(k.PrivateKey),
+		PrivateKeyID: k.PrivateKeyID,
+		Scopes:       []string{sqlAdminScope},
+		TokenURL:     tokenURL,
+	}
+}
+
+// GetAccountID returns the email of the service account once a token was issued for it
+func (g *InternalGcpClient) GetAccountID() (string, error) {
+	if _, err := g.tokenSource.Token(); err != nil {
+		return "", err
+	}
+	return g.account()
+}
@@ -0,0 +1,325 @@
+package gcp
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (g *InternalGcpClient) CreateDBInstance(input *v1alpha1.DBInstance, password string) error {
+	family, err := engineFamily(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	// the root password belongs to the built-in user, other users need an instance that is already running
+	if input.Spec.MasterUsername != "" && input.Spec.MasterUsername != builtInUser(family) {
+		return ErrUnsupportedEngine{Message: fmt.Sprintf("Cloud SQL only supports the built-in user %s as masterUsername of %s",
+			builtInUser(family), input.Spec.Engine)}
+	}
+	version, err := databaseVersion(input.Spec.Engine, input.Spec.EngineVersion)
+	if err != nil {
+		return err
+	}
+	instance := &databaseInstance{
+		Name:            input.GetDBInstanceID(),
+		DatabaseVersion: version,
+		Region:          g.region,
+		RootPassword:    password,
+		Settings:        desiredSettings(input, g.cloudTags(input)),
+	}
+	if input.Spec.StorageType != "" {
+		instance.Settings.DataDiskType = dataDiskType(input.Spec.StorageType)
+	}
+	if input.Spec.AvailabilityZone != "" {
+		instance.Settings.LocationPreference = &locationPreference{Zone: input.Spec.AvailabilityZone}
+	}
+	if input.Spec.KmsKeyId != "" {
+		instance.DiskEncryptionConfiguration = &diskEncryptionConfiguration{KmsKeyName: input.Spec.KmsKeyId}
+	}
+	return g.do(http.MethodPost, "instances", nil, instance, nil)
+}
+
+func (g *InternalGcpClient) DeleteDBInstance(input *v1alpha1.DBInstance) error {
+	nsName := fmt.Sprintf("%s/%s", input.Namespace, input.Name)
+	if input.Spec.DeletionProtection {
+		msg := fmt.Sprintf("%s - cannot delete instance when deletion protection is enabled", nsName)
+		return ErrDBInstanceDeletionProtectionEnabled{Message: msg}
+	}
+	if err := g.do(http.MethodDelete, "instances/"+url.PathEscape(input.GetDBInstanceID()), nil, nil, nil); err != nil {
+		if isNotFound(err) {
+			g.logger.Info(fmt.Sprintf("%s - instance not found, skipping cleanup", nsName))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (g *InternalGcpClient) ModifyDBInstance(modifyIn interface{}) error {
+	patch, ok := modifyIn.(*instancePatch)
+	if !ok {
+		return fmt.Errorf("expected *gcp.instancePatch but got: %T", modifyIn)
+	}
+	return g.do(http.MethodPatch, "instances/"+url.PathEscape(patch.name), nil, patch.body, nil)
+}
+
+func (g *InternalGcpClient) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error) {
+	instance, err := g.getInstance(input.GetDBInstanceID())
+	if err != nil {
+		if isNotFound(err) {
+			return &v1alpha1.DBStatus{}, nil
+		}
+		return nil, err
+	}
+	operationPending, err := g.operationPending(instance.Name)
+	if err != nil {
+		return nil, err
+	}
+	status := &v1alpha1.DBStatus{
+		Exists:        true,
+		CurrentPhase:  instancePhase(instance.State, operationPending),
+		Endpoint:      instanceEndpoint(instance),
+		Arn:           instance.SelfLink,
+		ResourceID:    instance.ConnectionName,
+		EngineVersion: instance.DatabaseVersion,
+	}
+	if family, errFamily := engineFamily(input.Spec.Engine); errFamily == nil {
+		status.Port = enginePort(family)
+	}
+	if instance.Settings != nil {
+		status.InstanceClass = instance.Settings.Tier
+		status.AllocatedStorage = instance.Settings.DataDiskSizeGb
+		status.MultiAZ = instance.Settings.AvailabilityType == "REGIONAL"
+		status.Tags = fromLabels(instance.Settings.UserLabels, input.GetOwnerTagValue())
+	}
+	return status, nil
+}
+
+// IsDBInstanceUpToDate compares the live instance with the spec, the returned *instancePatch only
+// holds the settings that differ. Storage only grows and the disk type cannot change after create.
+func (g *InternalGcpClient) IsDBInstanceUpToDate(input *v1alpha1.DBInstance) (bool, interface{}, error) {
+	instance, err := g.getInstance(input.GetDBInstanceID())
+	if err != nil {
+		return false, nil, err
+	}
+	current := instance.Settings
+	if current == nil {
+		current = &settings{}
+	}
+	desired := desiredSettings(input, g.cloudTags(input))
+	patch := &databaseInstance{}
+	changes := &settings{}
+	settingsChanged := false
+
+	if input.Spec.EngineVersion != "" {
+		version, err := databaseVersion(input.Spec.Engine, input.Spec.EngineVersion)
+		if err != nil {
+			return false, nil, err
+		}
+		if version != instance.DatabaseVersion {
+			patch.DatabaseVersion = version
+		}
+	}
+	if desired.Tier != "" && desired.Tier != current.Tier {
+		changes.Tier = desired.Tier
+		settingsChanged = true
+	}
+	if desired.DataDiskSizeGb > current.DataDiskSizeGb {
+		changes.DataDiskSizeGb = desired.DataDiskSizeGb
+		settingsChanged = true
+	}
+	if desired.AvailabilityType != current.AvailabilityType {
+		changes.AvailabilityType = desired.AvailabilityType
+		settingsChanged = true
+	}
+	if boolValue(desired.DeletionProtectionEnabled) != boolValue(current.DeletionProtectionEnabled) {
+		changes.DeletionProtectionEnabled = desired.DeletionProtectionEnabled
+		settingsChanged = true
+	}
+	if !backupConfigurationEqual(desired.BackupConfiguration, current.BackupConfiguration) {
+		changes.BackupConfiguration = desired.BackupConfiguration
+		settingsChanged = true
+	}
+	if current.IPConfiguration == nil || boolValue(desired.IPConfiguration.Ipv4Enabled) != boolValue(current.IPConfiguration.Ipv4Enabled) {
+		changes.IPConfiguration = desired.IPConfiguration
+		settingsChanged = true
+	}
+	if labels, labelsDiffer := labelsChanged(current.UserLabels, desired.UserLabels); labelsDiffer {
+		changes.UserLabels = labels
+		settingsChanged = true
+	}
+	if !settingsChanged && patch.DatabaseVersion == "" {
+		return true, nil, nil
+	}
+	if settingsChanged {
+		patch.Settings = changes
+	}
+	return false, &instancePatch{name: instance.Name, body: patch}, nil
+}
+
+// UpdateDBInstancePassword sets the password of the built-in user the instance was created with
+func (g *InternalGcpClient) UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error {
+	family, err := engineFamily(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	query := url.Values{"name": []string{builtInUser(family)}}
+	if family == familyMySQL {
+		query.Set("host", "%")
+	}
+	return g.do(http.MethodPut, fmt.Sprintf("instances/%s/users", url.PathEscape(input.GetDBInstanceID())), query,
+		&user{Name: builtInUser(family), Password: password}, nil)
+}
+
+// BackfillDBInstanceSpec fills the spec fields left empty from the live instance. Boolean
+// fields that are false in the spec take the live value.
+func (g *InternalGcpClient) BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error {
+	instance, err := g.getInstance(input.GetDBInstanceID())
+	if err != nil {
+		return err
+	}
+	spec := &input.Spec
+	if instance.Settings == nil {
+		return nil
+	}
+	live := instance.Settings
+	if spec.DBInstanceClass == "" {
+		spec.DBInstanceClass = live.Tier
+	}
+	if spec.AllocatedStorage == 0 {
+		spec.AllocatedStorage = live.DataDiskSizeGb
+	}
+	if spec.StorageType == "" && live.DataDiskType != "" {
+		spec.StorageType = storageType(live.DataDiskType)
+	}
+	if spec.BackupRetentionPeriod == 0 && live.BackupConfiguration != nil && boolValue(live.BackupConfiguration.Enabled) &&
+		live.BackupConfiguration.BackupRetentionSettings != nil {
+		spec.BackupRetentionPeriod = live.BackupConfiguration.BackupRetentionSettings.RetainedBackups
+	}
+	if len(spec.Tags) == 0 {
+		spec.Tags = utils.WithoutDefaultTags(fromLabels(live.UserLabels, ""), g.defaultTags)
+		delete(spec.Tags, v1alpha1.OwnerTagKey)
+	}
+	spec.MultiAZ = spec.MultiAZ || live.AvailabilityType == "REGIONAL"
+	spec.DeletionProtection = spec.DeletionProtection || boolValue(live.DeletionProtectionEnabled)
+	spec.PubliclyAccessible = spec.PubliclyAccessible || (live.IPConfiguration != nil && boolValue(live.IPConfiguration.Ipv4Enabled))
+	return nil
+}
+
+// AddTagsToResource adds tags as user labels of the instance, arn is the self link of the instance
+func (g *InternalGcpClient) AddTagsToResource(arn string, tags map[string]string) error {
+	name := arn[strings.LastIndex(arn, "/")+1:]
+	instance, err := g.getInstance(name)
+	if err != nil {
+		return err
+	}
+	var current map[string]string
+	if instance.Settings != nil {
+		current = instance.Settings.UserLabels
+	}
+	labels, changed := labelsChanged(current, toLabels(tags))
+	if !changed {
+		return nil
+	}
+	return g.do(http.MethodPatch, "instances/"+url.PathEscape(name), nil,
+		&databaseInstance{Settings: &settings{UserLabels: labels}}, nil)
+}
+
+func (g *InternalGcpClient) getInstance(name string) (*databaseInstance, error) {
+	instance := &databaseInstance{}
+	if err := g.do(http.MethodGet, "instances/"+url.PathEscape(name), nil, nil, instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// operationPending is true while the latest operation on the instance is not done
+func (g *InternalGcpClient) operationPending(name string) (bool, error) {
+	operations := &operationsList{}
+	if err := g.do(http.MethodGet, "operations", url.Values{"instance": []string{name}, "maxResults": []string{"1"}},
+		nil, operations); err != nil {
+		return false, err
+	}
+	return len(operations.Items) > 0 && operations.Items[0].Status != "DONE", nil
+}
+
+// desiredSettings returns the settings the spec asks for, the disk type and zone are only set on create
+func desiredSettings(input *v1alpha1.DBInstance, tags map[string]string) *settings {
+	availabilityType := "ZONAL"
+	if input.Spec.MultiAZ {
+		availabilityType = "REGIONAL"
+	}
+	backups := &backupConfiguration{Enabled: boolPtr(input.Spec.BackupRetentionPeriod > 0)}
+	if input.Spec.BackupRetentionPeriod > 0 {
+		backups.BackupRetentionSettings = &backupRetentionSettings{
+			RetentionUnit:   "COUNT",
+			RetainedBackups: input.Spec.BackupRetentionPeriod,
+		}
+	}
+	return &settings{
+		Tier:                      input.Spec.DBInstanceClass,
+		DataDiskSizeGb:            input.Spec.AllocatedStorage,
+		AvailabilityType:          availabilityType,
+		DeletionProtectionEnabled: boolPtr(input.Spec.DeletionProtection),
+		UserLabels:                toLabels(tags),
+		BackupConfiguration:       backups,
+		IPConfiguration:           &ipConfiguration{Ipv4Enabled: boolPtr(input.Spec.PubliclyAccessible)},
+	}
+}
+
+// dataDiskType maps the storageType of the spec onto a disk type, standard is a hdd and
+// gp2 and io1 are ssds
+func dataDiskType(storageType string) string {
+	if storageType == "standard" {
+		return "PD_HDD"
+	}
+	return "PD_SSD"
+}
+
+// storageType is the reverse of dataDiskType
+func storageType(dataDiskType string) string {
+	if dataDiskType == "PD_HDD" {
+		return "standard"
+	}
+	return "gp2"
+}
+
+func backupConfigurationEqual(desired, current *backupConfiguration) bool {
+	if current == nil {
+		return !boolValue(desired.Enabled)
+	}
+	if boolValue(desired.Enabled) != boolValue(current.Enabled) {
+		return false
+	}
+	if !boolValue(desired.Enabled) {
+		return true
+	}
+	return current.BackupRetentionSettings != nil &&
+		current.BackupRetentionSettings.RetainedBackups == desired.BackupRetentionSettings.RetainedBackups
+}
+
+// instanceEndpoint prefers the dns name of the instance, then its public and its private address
+func instanceEndpoint(instance *databaseInstance) string {
+	if instance.DNSName != "" {
+		return instance.DNSName
+	}
+	for _, ipType := range []string{"PRIMARY", "PRIVATE"} {
+		for _, ip := range instance.IPAddresses {
+			if ip.Type == ipType {
+				return ip.IPAddress
+			}
+		}
+	}
+	return ""
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,350 @@
+package gcp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/go-logr/logr"
+	"io/ioutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testProject = "test-project"
+
+// fakeSQLAdmin is a minimal in memory Cloud SQL Admin API plus the token endpoint of a service account
+type fakeSQLAdmin struct {
+	sync.Mutex
+	instances map[string]*databaseInstance
+	passwords map[string]string
+	// denied makes every API call fail with PERMISSION_DENIED
+	denied bool
+	tokens int
+}
+
+func newFakeSQLAdmin() *fakeSQLAdmin {
+	return &fakeSQLAdmin{instances: map[string]*databaseInstance{}, passwords: map[string]string{}}
+}
+
+func (f *fakeSQLAdmin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	if r.URL.Path == "/token" {
+		f.tokens++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"fake-token","token_type":"Bearer","expires_in":3600}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer fake-token" {
+		writeError(w, http.StatusUnauthorized, "UNAUTHENTICATED")
+		return
+	}
+	if f.denied {
+		writeError(w, http.StatusForbidden, "PERMISSION_DENIED")
+		return
+	}
+	prefix := "/projects/" + testProject + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound, "NOT_FOUND")
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	body, _ := ioutil.ReadAll(r.Body)
+	switch {
+	case parts[0] == "operations":
+		writeJSON(w, &operationsList{Items: []operation{{Name: "op", Status: "DONE"}}})
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		in := &databaseInstance{}
+		_ = json.Unmarshal(body, in)
+		if _, ok := f.instances[in.Name]; ok {
+			writeError(w, http.StatusConflict, "ALREADY_EXISTS")
+			return
+		}
+		f.passwords[in.Name] = in.RootPassword
+		in.RootPassword = ""
+		in.State = "RUNNABLE"
+		in.SelfLink = "https://sqladmin.googleapis.com/v1/projects/" + testProject + "/instances/" + in.Name
+		in.ConnectionName = testProject + ":" + in.Region + ":" + in.Name
+		in.IPAddresses = []ipMapping{{Type: "PRIMARY", IPAddress: "10.0.0.1"}}
+		f.instances[in.Name] = in
+		writeJSON(w, &operation{Name: "op", Status: "PENDING"})
+	case len(parts) == 2:
+		instance, ok := f.instances[parts[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, instance)
+		case http.MethodDelete:
+			delete(f.instances, parts[1])
+			writeJSON(w, &operation{Name: "op", Status: "PENDING"})
+		case http.MethodPatch:
+			patch := &databaseInstance{}
+			_ = json.Unmarshal(body, patch)
+			applyPatch(instance, patch)
+			writeJSON(w, &operation{Name: "op", Status: "PENDING"})
+		}
+	case len(parts) == 3 && parts[2] == "users" && r.Method == http.MethodPut:
+		if _, ok := f.instances[parts[1]]; !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND")
+			return
+		}
+		in := &user{}
+		_ = json.Unmarshal(body, in)
+		f.passwords[parts[1]] = r.URL.Query().Get("name") + ":" + r.URL.Query().Get("host") + ":" + in.Password
+		writeJSON(w, &operation{Name: "op", Status: "PENDING"})
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND")
+	}
+}
+
+// applyPatch merges the set fields of a patch the way the PATCH method of the API does
+func applyPatch(instance, patch *databaseInstance) {
+	if patch.DatabaseVersion != "" {
+		instance.DatabaseVersion = patch.DatabaseVersion
+	}
+	if patch.Settings == nil {
+		return
+	}
+	raw, _ := json.Marshal(patch.Settings)
+	_ = json.Unmarshal(raw, instance.Settings)
+	if patch.Settings.UserLabels != nil {
+		instance.Settings.UserLabels = patch.Settings.UserLabels
+	}
+}
+
+func writeJSON(w http.ResponseWriter, out interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func writeError(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": "fake " + status, "status": status},
+	})
+}
+
+// newTestClient returns a client that authenticates with a service account key against the fake
+func newTestClient(t *testing.T, fake *fakeSQLAdmin) *InternalGcpClient {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	_ = os.Setenv(MockGcpEndpoint, server.URL)
+	t.Cleanup(func() { _ = os.Unsetenv(MockGcpEndpoint) })
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	key, _ := json.Marshal(&serviceAccountKey{
+		Type:         "service_account",
+		ProjectID:    testProject,
+		PrivateKeyID: "key-id",
+		PrivateKey:   string(keyPEM),
+		ClientEmail:  "db-operator@" + testProject + ".iam.gserviceaccount.com",
+		TokenURI:     server.URL + "/token",
+	})
+	c, err := NewInternalGcpClient(logr.Discard(), "us-central1", t.Name(), "1", map[string][]byte{ServiceAccountJSONVar: key}, nil)
+	if err != nil {
+		t.Fatalf("NewInternalGcpClient() error = %v", err)
+	}
+	return c
+}
+
+func newTestDBInstance() *v1alpha1.DBInstance {
+	return &v1alpha1.DBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team"},
+		Spec: v1alpha1.DBInstanceSpec{
+			Engine:                "postgres",
+			EngineVersion:         "13.4",
+			DBInstanceClass:       "db-custom-1-3840",
+			AllocatedStorage:      20,
+			StorageType:           "gp2",
+			BackupRetentionPeriod: 7,
+			Tags:                  map[string]string{"Team": "Payments"},
+		},
+	}
+}
+
+func TestDBInstanceLifecycle(t *testing.T) {
+	fake := newFakeSQLAdmin()
+	c := newTestClient(t, fake)
+	cr := newTestDBInstance()
+
+	status, err := c.DBInstanceExists(cr)
+	if err != nil || status.Exists {
+		t.Fatalf("DBInstanceExists() = %v, %v, want not found", status, err)
+	}
+	if err := c.CreateDBInstance(cr, "secret"); err != nil {
+		t.Fatalf("CreateDBInstance() error = %v", err)
+	}
+	created := fake.instances["team-app"]
+	if created.DatabaseVersion != "POSTGRES_13" || created.Settings.DataDiskType != "PD_SSD" ||
+		created.Settings.AvailabilityType != "ZONAL" || fake.passwords["team-app"] != "secret" {
+		t.Errorf("CreateDBInstance() created %+v with settings %+v", created, created.Settings)
+	}
+
+	status, err = c.DBInstanceExists(cr)
+	if err != nil {
+		t.Fatalf("DBInstanceExists() error = %v", err)
+	}
+	if !status.Exists || status.CurrentPhase != "available" || status.Endpoint != "10.0.0.1" || status.Port != 5432 ||
+		status.AllocatedStorage != 20 || status.Tags[v1alpha1.OwnerTagKey] != cr.GetOwnerTagValue() || status.Tags["team"] != "payments" {
+		t.Errorf("DBInstanceExists() = %+v", status)
+	}
+
+	upToDate, _, err := c.IsDBInstanceUpToDate(cr)
+	if err != nil || !upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want up to date after create", upToDate, err)
+	}
+
+	cr.Spec.DBInstanceClass = "db-custom-2-7680"
+	cr.Spec.AllocatedStorage = 50
+	cr.Spec.MultiAZ = true
+	upToDate, modifyIn, err := c.IsDBInstanceUpToDate(cr)
+	if err != nil || upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want a modification", upToDate, err)
+	}
+	patch := modifyIn.(*instancePatch)
+	if patch.body.Settings.BackupConfiguration != nil || patch.body.Settings.UserLabels != nil {
+		t.Errorf("IsDBInstanceUpToDate() patch %+v should only hold the changed settings", patch.body.Settings)
+	}
+	if err := c.ModifyDBInstance(modifyIn); err != nil {
+		t.Fatalf("ModifyDBInstance() error = %v", err)
+	}
+	if upToDate, _, err := c.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want up to date after modify", upToDate, err)
+	}
+	if status, _ := c.DBInstanceExists(cr); !status.MultiAZ || status.InstanceClass != "db-custom-2-7680" {
+		t.Errorf("DBInstanceExists() after modify = %+v", status)
+	}
+
+	// storage cannot shrink, a smaller spec is not a modification
+	cr.Spec.AllocatedStorage = 10
+	if upToDate, _, err := c.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Errorf("IsDBInstanceUpToDate() = %v, %v, want up to date when storage shrinks", upToDate, err)
+	}
+
+	if err := c.UpdateDBInstancePassword(cr, "rotated"); err != nil {
+		t.Fatalf("UpdateDBInstancePassword() error = %v", err)
+	}
+	if got := fake.passwords["team-app"]; got != "postgres::rotated" {
+		t.Errorf("UpdateDBInstancePassword() set %v, want postgres::rotated", got)
+	}
+
+	if err := c.AddTagsToResource(status.Arn, map[string]string{"cost-center": "42"}); err != nil {
+		t.Fatalf("AddTagsToResource() error = %v", err)
+	}
+	if labels := fake.instances["team-app"].Settings.UserLabels; labels["cost-center"] != "42" || labels["team"] != "payments" {
+		t.Errorf("AddTagsToResource() labels = %v", labels)
+	}
+
+	if err := c.DeleteDBInstance(cr); err != nil {
+		t.Fatalf("DeleteDBInstance() error = %v", err)
+	}
+	if err := c.DeleteDBInstance(cr); err != nil {
+		t.Errorf("DeleteDBInstance() of a deleted instance error = %v, want nil", err)
+	}
+	if status, err := c.DBInstanceExists(cr); err != nil || status.Exists {
+		t.Errorf("DBInstanceExists() after delete = %v, %v", status, err)
+	}
+	if fake.tokens != 1 {
+		t.Errorf("token endpoint called %v times, want the token to be reused", fake.tokens)
+	}
+}
+
+func TestBackfillDBInstanceSpec(t *testing.T) {
+	fake := newFakeSQLAdmin()
+	c := newTestClient(t, fake)
+	if err := c.CreateDBInstance(newTestDBInstance(), "secret"); err != nil {
+		t.Fatal(err)
+	}
+	cr := &v1alpha1.DBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team"},
+		Spec:       v1alpha1.DBInstanceSpec{Engine: "postgres"},
+	}
+	if err := c.BackfillDBInstanceSpec(cr); err != nil {
+		t.Fatalf("BackfillDBInstanceSpec() error = %v", err)
+	}
+	if cr.Spec.DBInstanceClass != "db-custom-1-3840" || cr.Spec.AllocatedStorage != 20 || cr.Spec.StorageType != "gp2" ||
+		cr.Spec.BackupRetentionPeriod != 7 || cr.Spec.Tags["team"] != "payments" {
+		t.Errorf("BackfillDBInstanceSpec() spec = %+v", cr.Spec)
+	}
+	if _, ok := cr.Spec.Tags[v1alpha1.OwnerTagKey]; ok {
+		t.Errorf("BackfillDBInstanceSpec() should not copy the owner label into tags")
+	}
+	if upToDate, _, err := c.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Errorf("IsDBInstanceUpToDate() = %v, %v, want up to date after backfill", upToDate, err)
+	}
+}
+
+func TestDBInstanceErrors(t *testing.T) {
+	fake := newFakeSQLAdmin()
+	c := newTestClient(t, fake)
+
+	cr := newTestDBInstance()
+	cr.Spec.MasterUsername = "admin"
+	if err := c.CreateDBInstance(cr, "secret"); !errors.As(err, &ErrUnsupportedEngine{}) {
+		t.Errorf("CreateDBInstance() with a custom master user error = %v, want ErrUnsupportedEngine", err)
+	}
+
+	cr = newTestDBInstance()
+	cr.Spec.DeletionProtection = true
+	if err := c.DeleteDBInstance(cr); !errors.As(err, &ErrDBInstanceDeletionProtectionEnabled{}) {
+		t.Errorf("DeleteDBInstance() error = %v, want ErrDBInstanceDeletionProtectionEnabled", err)
+	}
+
+	fake.denied = true
+	_, err := c.DBInstanceExists(newTestDBInstance())
+	if !utils.IsCredentialsError(err) {
+		t.Errorf("DBInstanceExists() error = %v, want a credentials error", err)
+	}
+	if reason, _ := utils.ReasonFromError(err, "Fallback"); reason != "PERMISSIONDENIED" {
+		t.Errorf("ReasonFromError() = %v, want PERMISSIONDENIED", reason)
+	}
+}
+
+func TestDatabaseVersion(t *testing.T) {
+	tests := []struct {
+		engine  string
+		version string
+		want    string
+		wantErr bool
+	}{
+		{engine: "postgres", version: "", want: "POSTGRES_14"},
+		{engine: "postgres", version: "13.4", want: "POSTGRES_13"},
+		{engine: "postgres", version: "9.6.22", want: "POSTGRES_9_6"},
+		{engine: "mysql", version: "5.7.38", want: "MYSQL_5_7"},
+		{engine: "mysql", version: "8", wantErr: true},
+		{engine: "sqlserver-se", version: "15.00.4073.23.v1", want: "SQLSERVER_2019_STANDARD"},
+		{engine: "sqlserver-ex", version: "", want: "SQLSERVER_2019_EXPRESS"},
+		{engine: "sqlserver-ee", version: "13.00", wantErr: true},
+		{engine: "oracle-ee", version: "19", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engine+"-"+tt.version, func(t *testing.T) {
+			got, err := databaseVersion(tt.engine, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("databaseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("databaseVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
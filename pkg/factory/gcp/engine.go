@@ -0,0 +1,118 @@
+package gcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cloud SQL engine family of a DBInstance engine
+const (
+	familyPostgres  = "postgres"
+	familyMySQL     = "mysql"
+	familySQLServer = "sqlserver"
+)
+
+// sqlServerEditions maps the RDS style sqlserver engines onto Cloud SQL editions
+var sqlServerEditions = map[string]string{
+	"sqlserver-ee":  "ENTERPRISE",
+	"sqlserver-se":  "STANDARD",
+	"sqlserver-ex":  "EXPRESS",
+	"sqlserver-web": "WEB",
+}
+
+// sqlServerReleases maps the major version of sqlserver onto its release year
+var sqlServerReleases = map[string]string{
+	"14": "2017",
+	"15": "2019",
+	"16": "2022",
+}
+
+func engineFamily(engine string) (string, error) {
+	switch {
+	case engine == "postgres":
+		return familyPostgres, nil
+	case engine == "mysql":
+		return familyMySQL, nil
+	case sqlServerEditions[engine] != "":
+		return familySQLServer, nil
+	}
+	return "", ErrUnsupportedEngine{Message: fmt.Sprintf("engine %s is not supported by Cloud SQL, "+
+		"use postgres, mysql or sqlserver-ee/se/ex/web", engine)}
+}
+
+// databaseVersion returns the Cloud SQL databaseVersion of an engine and engine version, e.g
+// postgres 13.4 is POSTGRES_13 and sqlserver-se 15.00 is SQLSERVER_2019_STANDARD. Only the major
+// version is kept, Cloud SQL picks the minor version itself.
+func databaseVersion(engine, engineVersion string) (string, error) {
+	family, err := engineFamily(engine)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(engineVersion, ".")
+	switch family {
+	case familyPostgres:
+		if engineVersion == "" {
+			return "POSTGRES_14", nil
+		}
+		// 9.6 and older have a two part major version
+		if parts[0] == "9" && len(parts) > 1 {
+			return fmt.Sprintf("POSTGRES_9_%s", parts[1]), nil
+		}
+		return fmt.Sprintf("POSTGRES_%s", parts[0]), nil
+	case familyMySQL:
+		if engineVersion == "" {
+			return "MYSQL_8_0", nil
+		}
+		if len(parts) < 2 {
+			return "", ErrUnsupportedEngine{Message: fmt.Sprintf("mysql engineVersion %s needs a major and minor version, e.g 8.0", engineVersion)}
+		}
+		return fmt.Sprintf("MYSQL_%s_%s", parts[0], parts[1]), nil
+	}
+	release := "2019"
+	if engineVersion != "" {
+		release = sqlServerReleases[strings.TrimLeft(parts[0], "0")]
+		if release == "" {
+			return "", ErrUnsupportedEngine{Message: fmt.Sprintf("sqlserver engineVersion %s is not supported by Cloud SQL", engineVersion)}
+		}
+	}
+	return fmt.Sprintf("SQLSERVER_%s_%s", release, sqlServerEditions[engine]), nil
+}
+
+// builtInUser is the user Cloud SQL creates with the root password of a new instance
+func builtInUser(family string) string {
+	switch family {
+	case familyPostgres:
+		return "postgres"
+	case familyMySQL:
+		return "root"
+	}
+	return "sqlserver"
+}
+
+// enginePort is the port Cloud SQL serves an engine on, it cannot be changed
+func enginePort(family string) int64 {
+	switch family {
+	case familyPostgres:
+		return 5432
+	case familyMySQL:
+		return 3306
+	}
+	return 1433
+}
+
+// instancePhase converts the state of an instance into the phase reported by the factories,
+// a running instance with an unfinished operation is still being modified
+func instancePhase(state string, operationPending bool) string {
+	switch state {
+	case "RUNNABLE":
+		if operationPending {
+			return "modifying"
+		}
+		return "available"
+	case "PENDING_CREATE":
+		return "creating"
+	case "PENDING_DELETE":
+		return "deleting"
+	}
+	return strings.ToLower(state)
+}
@@ -0,0 +1,66 @@
+package gcp
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type ErrMissingServiceAccountKey struct {
+	Message string
+}
+
+func (e ErrMissingServiceAccountKey) Error() string {
+	return e.Message
+}
+
+type ErrMissingGcpProject struct {
+	Message string
+}
+
+func (e ErrMissingGcpProject) Error() string {
+	return e.Message
+}
+
+type ErrUnsupportedEngine struct {
+	Message string
+}
+
+func (e ErrUnsupportedEngine) Error() string {
+	return e.Message
+}
+
+type ErrDBInstanceDeletionProtectionEnabled struct {
+	Message string
+}
+
+func (e ErrDBInstanceDeletionProtectionEnabled) Error() string {
+	return e.Message
+}
+
+// apiError is the error body returned by Google APIs, it carries the canonical status
+// ( e.g NOT_FOUND, PERMISSION_DENIED ) used as condition reason
+type apiError struct {
+	HTTPCode int    `json:"code"`
+	Msg      string `json:"message"`
+	Status   string `json:"status"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code(), e.Msg)
+}
+
+func (e *apiError) Code() string {
+	if e.Status != "" {
+		return e.Status
+	}
+	return http.StatusText(e.HTTPCode)
+}
+
+func (e *apiError) Message() string {
+	return e.Msg
+}
+
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*apiError)
+	return ok && apiErr.HTTPCode == http.StatusNotFound
+}
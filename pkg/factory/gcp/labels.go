@@ -0,0 +1,86 @@
+package gcp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"strings"
+)
+
+const (
+	// ownerLabelKey holds a hash of the owner tag, label values cannot hold the "/" of the owner tag
+	ownerLabelKey  = "db-operator-owner"
+	maxLabelLength = 63
+)
+
+func ownerLabelValue(owner string) string {
+	sum := sha256.Sum256([]byte(owner))
+	return hex.EncodeToString(sum[:])[:32]
+}
+
+// sanitizeLabel lowercases s and replaces the characters labels do not allow with "_"
+func sanitizeLabel(s string) string {
+	out := []rune(strings.ToLower(s))
+	for i, r := range out {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			out[i] = '_'
+		}
+	}
+	if len(out) > maxLabelLength {
+		out = out[:maxLabelLength]
+	}
+	return string(out)
+}
+
+// toLabels converts tags into Cloud SQL user labels
+// cloudTags returns the tags of the instance plus the default tags of the provider
+func (g *InternalGcpClient) cloudTags(input *v1alpha1.DBInstance) map[string]string {
+	return utils.MergeTags(g.defaultTags, input.GetCloudTags())
+}
+
+func toLabels(tags map[string]string) map[string]string {
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if k == v1alpha1.OwnerTagKey {
+			out[ownerLabelKey] = ownerLabelValue(v)
+			continue
+		}
+		out[sanitizeLabel(k)] = sanitizeLabel(v)
+	}
+	return out
+}
+
+// fromLabels converts user labels back into tags. The owner label is reported as owner when it holds
+// its hash, otherwise as the raw label value so the ownership conflict shows the foreign owner.
+func fromLabels(labels map[string]string, owner string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k == ownerLabelKey {
+			if v == ownerLabelValue(owner) {
+				v = owner
+			}
+			out[v1alpha1.OwnerTagKey] = v
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// labelsChanged returns current plus desired when a desired label is missing or differs, labels
+// set outside of the operator are kept
+func labelsChanged(current, desired map[string]string) (map[string]string, bool) {
+	changed := false
+	out := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		out[k] = v
+	}
+	for k, v := range desired {
+		if current[k] != v {
+			changed = true
+		}
+		out[k] = v
+	}
+	return out, changed
+}
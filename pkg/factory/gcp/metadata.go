@@ -0,0 +1,84 @@
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/oauth2"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	// MetadataHostVar overrides the metadata server, same as the google client libraries
+	MetadataHostVar     = "GCE_METADATA_HOST"
+	defaultMetadataHost = "metadata.google.internal"
+)
+
+// metadataClient reads the project and the token of the service account attached to the pod
+// from the metadata server, this is how workload identity hands out credentials
+type metadataClient struct {
+	host       string
+	httpClient *http.Client
+}
+
+func newMetadataClient() *metadataClient {
+	host := defaultMetadataHost
+	if val, ok := os.LookupEnv(MetadataHostVar); ok {
+		host = val
+	}
+	return &metadataClient{host: host, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (m *metadataClient) get(path string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/computeMetadata/v1/%s", m.host, path), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata server returned %d for %s: %s", resp.StatusCode, path, body)
+	}
+	return body, nil
+}
+
+// Token implements oauth2.TokenSource
+func (m *metadataClient) Token() (*oauth2.Token, error) {
+	body, err := m.get("instance/service-accounts/default/token")
+	if err != nil {
+		return nil, err
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
+
+func (m *metadataClient) projectID() (string, error) {
+	body, err := m.get("project/project-id")
+	return string(body), err
+}
+
+func (m *metadataClient) email() (string, error) {
+	body, err := m.get("instance/service-accounts/default/email")
+	return string(body), err
+}
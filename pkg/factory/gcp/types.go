@@ -0,0 +1,79 @@
+package gcp
+
+// subset of the Cloud SQL Admin API v1 resources used by the operator, optional fields are
+// pointers so a patch only carries the fields that change
+
+type databaseInstance struct {
+	Name                        string                       `json:"name,omitempty"`
+	DatabaseVersion             string                       `json:"databaseVersion,omitempty"`
+	Region                      string                       `json:"region,omitempty"`
+	State                       string                       `json:"state,omitempty"`
+	ConnectionName              string                       `json:"connectionName,omitempty"`
+	SelfLink                    string                       `json:"selfLink,omitempty"`
+	DNSName                     string                       `json:"dnsName,omitempty"`
+	RootPassword                string                       `json:"rootPassword,omitempty"`
+	IPAddresses                 []ipMapping                  `json:"ipAddresses,omitempty"`
+	Settings                    *settings                    `json:"settings,omitempty"`
+	DiskEncryptionConfiguration *diskEncryptionConfiguration `json:"diskEncryptionConfiguration,omitempty"`
+}
+
+type settings struct {
+	Tier                      string               `json:"tier,omitempty"`
+	DataDiskSizeGb            int64                `json:"dataDiskSizeGb,omitempty,string"`
+	DataDiskType              string               `json:"dataDiskType,omitempty"`
+	AvailabilityType          string               `json:"availabilityType,omitempty"`
+	DeletionProtectionEnabled *bool                `json:"deletionProtectionEnabled,omitempty"`
+	UserLabels                map[string]string    `json:"userLabels,omitempty"`
+	BackupConfiguration       *backupConfiguration `json:"backupConfiguration,omitempty"`
+	IPConfiguration           *ipConfiguration     `json:"ipConfiguration,omitempty"`
+	LocationPreference        *locationPreference  `json:"locationPreference,omitempty"`
+}
+
+type backupConfiguration struct {
+	Enabled                 *bool                    `json:"enabled,omitempty"`
+	BackupRetentionSettings *backupRetentionSettings `json:"backupRetentionSettings,omitempty"`
+}
+
+type backupRetentionSettings struct {
+	RetentionUnit   string `json:"retentionUnit,omitempty"`
+	RetainedBackups int64  `json:"retainedBackups,omitempty"`
+}
+
+type ipConfiguration struct {
+	Ipv4Enabled *bool `json:"ipv4Enabled,omitempty"`
+}
+
+type locationPreference struct {
+	Zone string `json:"zone,omitempty"`
+}
+
+type diskEncryptionConfiguration struct {
+	KmsKeyName string `json:"kmsKeyName,omitempty"`
+}
+
+type ipMapping struct {
+	Type      string `json:"type"`
+	IPAddress string `json:"ipAddress"`
+}
+
+type operation struct {
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	OperationType string `json:"operationType"`
+}
+
+type operationsList struct {
+	Items []operation `json:"items"`
+}
+
+type user struct {
+	Name     string `json:"name"`
+	Host     string `json:"host,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// instancePatch is the modifyIn returned by IsDBInstanceUpToDate and applied by ModifyDBInstance
+type instancePatch struct {
+	name string
+	body *databaseInstance
+}
@@ -1,12 +1,13 @@
 package factory
 
 import (
-	"errors"
 	"fmt"
 	"github.com/agill17/db-operator/api/v1alpha1"
-	internalAwsImpl "github.com/agill17/db-operator/pkg/factory/aws"
+	"github.com/agill17/db-operator/pkg/factory/unsupported"
 	"github.com/go-logr/logr"
-	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
 )
 
 type DBCluster interface {
@@ -15,6 +16,11 @@ type DBCluster interface {
 	IsDBClusterUpToDate(input *v1alpha1.DBCluster) (bool, interface{}, error)
 	DeleteDBCluster(input *v1alpha1.DBCluster) error
 	DBClusterExists(dbClusterID string) (*v1alpha1.DBStatus, error)
+	UpdateDBClusterPassword(input *v1alpha1.DBCluster, password string) error
+	RestoreDBClusterFromSnapshot(input *v1alpha1.DBCluster, snapshotID string) error
+	RestoreDBClusterToPointInTime(input *v1alpha1.DBCluster, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error)
+	BackfillDBClusterSpec(input *v1alpha1.DBCluster) error
+	PromoteDBClusterReadReplica(input *v1alpha1.DBCluster) error
 }
 
 type DBInstance interface {
@@ -23,17 +29,201 @@ type DBInstance interface {
 	ModifyDBInstance(modifyIn interface{}) error
 	DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error)
 	IsDBInstanceUpToDate(input *v1alpha1.DBInstance) (bool, interface{}, error)
+	UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error
+	BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error
 }
 
+type DBInstanceRestore interface {
+	RestoreDBInstanceFromSnapshot(input *v1alpha1.DBInstance, snapshotID string) error
+	RestoreDBInstanceToPointInTime(input *v1alpha1.DBInstance, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error)
+}
+
+type DBInstanceReplica interface {
+	CreateDBInstanceReadReplica(input *v1alpha1.DBInstance, sourceID, sourceRegion string) error
+	PromoteDBInstanceReadReplica(input *v1alpha1.DBInstance) error
+}
+
+type DBSnapshot interface {
+	CreateDBSnapshot(input *v1alpha1.DBSnapshot, dbInstanceID string) error
+	DeleteDBSnapshot(snapshotID string) error
+	DBSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error)
+	CreateDBClusterSnapshot(input *v1alpha1.DBClusterSnapshot, dbClusterID string) error
+	DeleteDBClusterSnapshot(snapshotID string) error
+	DBClusterSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error)
+}
+
+type DBClusterEndpoint interface {
+	DBClusterEndpoints(dbClusterID string) ([]v1alpha1.DBClusterEndpointState, error)
+	CreateDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error
+	ModifyDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error
+	DeleteDBClusterEndpoint(endpointID string) error
+}
+
+type GlobalCluster interface {
+	CreateGlobalCluster(input *v1alpha1.GlobalCluster, sourceDBClusterArn string) error
+	GlobalClusterExists(globalClusterID string) (*v1alpha1.GlobalClusterState, error)
+	ModifyGlobalCluster(input *v1alpha1.GlobalCluster) error
+	FailoverGlobalCluster(globalClusterID, targetDBClusterArn string) error
+	RemoveFromGlobalCluster(globalClusterID, dbClusterArn string) error
+	DeleteGlobalCluster(globalClusterID string) error
+}
+
+type DBSubnetGroup interface {
+	CreateDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error
+	DBSubnetGroupExists(name string) (*v1alpha1.DBSubnetGroupState, error)
+	ModifyDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error
+	DeleteDBSubnetGroup(name string) error
+}
+
+type DBParameterGroup interface {
+	CreateDBParameterGroup(input *v1alpha1.DBParameterGroup) error
+	DBParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error)
+	ModifyDBParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error
+	ResetDBParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error
+	DeleteDBParameterGroup(name string) error
+	CreateDBClusterParameterGroup(input *v1alpha1.DBClusterParameterGroup) error
+	DBClusterParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error)
+	ModifyDBClusterParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error
+	ResetDBClusterParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error
+	DeleteDBClusterParameterGroup(name string) error
+}
+
+type OptionGroup interface {
+	CreateOptionGroup(input *v1alpha1.OptionGroup) error
+	OptionGroupExists(name string) (*v1alpha1.OptionGroupState, error)
+	ModifyOptionGroup(name string, include []v1alpha1.Option, remove []string) error
+	DeleteOptionGroup(name string) error
+}
+
+type Tagger interface {
+	AddTagsToResource(arn string, tags map[string]string) error
+}
+
+type Identity interface {
+	// GetAccountID returns the account the credentials resolve to, it fails when the provider rejects them
+	GetAccountID() (string, error)
+}
+
+// Provider is what every provider implements, the interfaces of its capabilities are checked by NewCloudDB
+type Provider interface {
+	DBInstance
+	Tagger
+	Identity
+}
+
+// CloudDB is the client the controllers use, NewCloudDB fills the interfaces a provider does not implement
+// with methods returning utils.ErrNotSupported
 type CloudDB interface {
 	DBCluster
 	DBInstance
+	DBInstanceRestore
+	DBInstanceReplica
+	DBSnapshot
+	DBClusterEndpoint
+	GlobalCluster
+	DBSubnetGroup
+	DBParameterGroup
+	OptionGroup
+	Tagger
+	Identity
+}
+
+// ProviderOptions is everything needed to build a cloud client, resolved from a Provider or an inline provider config
+type ProviderOptions struct {
+	Type v1alpha1.ProviderType
+	// Name identifies the credentials, clients are cached per name and region
+	Name string
+	// Version of the credentials and provider settings, a cached client of another version is replaced
+	Version string
+	// Credentials read from the provider secret, nil when the identity of the operator pod is used
+	Credentials map[string][]byte
+	Region      string
+	DefaultTags map[string]string
+	// KubeClient of the operator, the local provider manages its databases through it
+	KubeClient client.Client
 }
 
-func NewCloudDB(logger logr.Logger, pType v1alpha1.ProviderType, providerSecret *v1.Secret, region string) (CloudDB, error) {
-	if pType == v1alpha1.AWS {
-		return internalAwsImpl.NewInternalAwsClient(logger, region, string(pType), providerSecret.Data)
+// NewCloudDB builds the client of the provider registered for options.Type. It fails when the client does not
+// implement the interfaces of a capability the provider declared.
+func NewCloudDB(logger logr.Logger, options ProviderOptions) (CloudDB, error) {
+	registryLock.RLock()
+	r, ok := registry[options.Type]
+	registryLock.RUnlock()
+	if !ok {
+		return nil, unknownProvider(options.Type)
+	}
+	p, err := r.constructor(logger, options)
+	if err != nil {
+		return nil, err
+	}
+	if missing := r.capabilities.notImplementedBy(p); len(missing) > 0 {
+		return nil, fmt.Errorf("the %s provider declares %s but its client does not implement them", options.Type,
+			strings.Join(missing, ", "))
+	}
+	if c, ok := p.(CloudDB); ok {
+		return c, nil
 	}
+	return fillNotSupported(options.Type, p), nil
+}
+
+// cloudDB is a provider completed with unsupported.CloudDB
+type cloudDB struct {
+	DBCluster
+	DBInstance
+	DBInstanceRestore
+	DBInstanceReplica
+	DBSnapshot
+	DBClusterEndpoint
+	GlobalCluster
+	DBSubnetGroup
+	DBParameterGroup
+	OptionGroup
+	Tagger
+	Identity
+}
 
-	return nil, errors.New(fmt.Sprintf("Provider %v is not yet supported..", pType))
+func fillNotSupported(pType v1alpha1.ProviderType, p Provider) CloudDB {
+	stub := unsupported.CloudDB{Provider: pType}
+	c := &cloudDB{
+		DBCluster:         stub,
+		DBInstance:        p,
+		DBInstanceRestore: stub,
+		DBInstanceReplica: stub,
+		DBSnapshot:        stub,
+		DBClusterEndpoint: stub,
+		GlobalCluster:     stub,
+		DBSubnetGroup:     stub,
+		DBParameterGroup:  stub,
+		OptionGroup:       stub,
+		Tagger:            p,
+		Identity:          p,
+	}
+	if i, ok := p.(DBCluster); ok {
+		c.DBCluster = i
+	}
+	if i, ok := p.(DBInstanceRestore); ok {
+		c.DBInstanceRestore = i
+	}
+	if i, ok := p.(DBInstanceReplica); ok {
+		c.DBInstanceReplica = i
+	}
+	if i, ok := p.(DBSnapshot); ok {
+		c.DBSnapshot = i
+	}
+	if i, ok := p.(DBClusterEndpoint); ok {
+		c.DBClusterEndpoint = i
+	}
+	if i, ok := p.(GlobalCluster); ok {
+		c.GlobalCluster = i
+	}
+	if i, ok := p.(DBSubnetGroup); ok {
+		c.DBSubnetGroup = i
+	}
+	if i, ok := p.(DBParameterGroup); ok {
+		c.DBParameterGroup = i
+	}
+	if i, ok := p.(OptionGroup); ok {
+		c.OptionGroup = i
+	}
+	return c
 }
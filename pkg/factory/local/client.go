@@ -0,0 +1,48 @@
+package local
+
+import (
+	"github.com/go-logr/logr"
+	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ClusterDomainVar overrides the dns domain of the cluster used in the endpoint of the databases
+	ClusterDomainVar     = "CLUSTER_DOMAIN"
+	defaultClusterDomain = "cluster.local"
+
+	// accountID is reported by GetAccountID, the local provider only ever manages the cluster it runs in
+	accountID = "in-cluster"
+)
+
+// InternalLocalClient runs DBInstances inside the cluster as a StatefulSet with a volume claim and a
+// Service, meant for development namespaces. Only factory.Provider is implemented, the factory
+// fills in the rest as not supported.
+type InternalLocalClient struct {
+	client        client.Client
+	clusterDomain string
+	logger        logr.Logger
+	// defaultTags of the provider, put on every statefulset together with its own tags
+	defaultTags map[string]string
+}
+
+func NewInternalLocalClient(logger logr.Logger, kubeClient client.Client, defaultTags map[string]string) (*InternalLocalClient, error) {
+	if kubeClient == nil {
+		return nil, ErrMissingKubeClient{Message: "the local provider needs a kubernetes client"}
+	}
+	clusterDomain := defaultClusterDomain
+	if val, ok := os.LookupEnv(ClusterDomainVar); ok {
+		clusterDomain = val
+	}
+	return &InternalLocalClient{
+		client:        kubeClient,
+		clusterDomain: clusterDomain,
+		logger:        logger,
+		defaultTags:   defaultTags,
+	}, nil
+}
+
+// GetAccountID returns a fixed id, there are no credentials to check
+func (l *InternalLocalClient) GetAccountID() (string, error) {
+	return accountID, nil
+}
@@ -0,0 +1,423 @@
+package local
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"strings"
+)
+
+const (
+	// tagsAnnotation holds the tags of the instance as json, tag keys are not always valid label keys
+	tagsAnnotation = "agill.apps.db-operator/tags"
+	// passwordAnnotation is a hash of the password on the pod template, a new password restarts the pod
+	passwordAnnotation = "agill.apps.db-operator/password-hash"
+
+	passwordKey = "password"
+	initSQLKey  = "init.sql"
+	dataVolume  = "data"
+	initVolume  = "init"
+	// defaultStorageGi is the size of the volume when allocatedStorage is not set
+	defaultStorageGi = 1
+)
+
+func (l *InternalLocalClient) CreateDBInstance(input *v1alpha1.DBInstance, password string) error {
+	e, err := getEngine(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	// the secret and service of a create that failed half way are updated
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: resourceName(input), Namespace: input.GetNamespace()}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), l.client, secret, func() error {
+		secret.Labels = labels(input)
+		secret.Data = secretData(e, masterUsername(e, input), password)
+		return nil
+	}); err != nil {
+		return err
+	}
+	svc := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: resourceName(input), Namespace: input.GetNamespace()}}
+	if _, err := controllerutil.CreateOrUpdate(context.TODO(), l.client, svc, func() error {
+		svc.Labels = labels(input)
+		svc.Spec.Selector = labels(input)
+		svc.Spec.Ports = []v1.ServicePort{{Name: "db", Port: e.port, TargetPort: intstr.FromInt(int(e.port))}}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return l.client.Create(context.TODO(), statefulSet(e, input, password, l.cloudTags(input)))
+}
+
+// DeleteDBInstance deletes the statefulset, its volume, service and secret. There are no final
+// snapshots, deletionPolicy Retain keeps everything in place instead.
+func (l *InternalLocalClient) DeleteDBInstance(input *v1alpha1.DBInstance) error {
+	nsName := fmt.Sprintf("%s/%s", input.Namespace, input.Name)
+	if input.Spec.DeletionProtection {
+		msg := fmt.Sprintf("%s - cannot delete instance when deletion protection is enabled", nsName)
+		return ErrDBInstanceDeletionProtectionEnabled{Message: msg}
+	}
+	if input.Status.FinalSnapshotIdentifier != "" {
+		return utils.ErrNotSupported{Message: "final snapshots are not supported by the local provider, use deletionPolicy Delete or Retain"}
+	}
+	name, namespace := resourceName(input), input.GetNamespace()
+	meta := metav1.ObjectMeta{Name: name, Namespace: namespace}
+	for _, object := range []client.Object{
+		&appsv1.StatefulSet{ObjectMeta: meta},
+		&v1.Service{ObjectMeta: meta},
+		&v1.Secret{ObjectMeta: meta},
+		&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s-0", dataVolume, name), Namespace: namespace}},
+	} {
+		if err := l.client.Delete(context.TODO(), object); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *InternalLocalClient) ModifyDBInstance(modifyIn interface{}) error {
+	sts, ok := modifyIn.(*appsv1.StatefulSet)
+	if !ok {
+		return fmt.Errorf("expected *appsv1.StatefulSet but got: %T", modifyIn)
+	}
+	return l.client.Update(context.TODO(), sts)
+}
+
+func (l *InternalLocalClient) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error) {
+	e, err := getEngine(input.Spec.Engine)
+	if err != nil {
+		return nil, err
+	}
+	sts, err := l.getStatefulSet(input.GetNamespace(), resourceName(input))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return &v1alpha1.DBStatus{}, nil
+		}
+		return nil, err
+	}
+	return &v1alpha1.DBStatus{
+		Exists:           true,
+		CurrentPhase:     statefulSetPhase(sts),
+		Endpoint:         fmt.Sprintf("%s.%s.svc.%s", sts.GetName(), sts.GetNamespace(), l.clusterDomain),
+		Port:             int64(e.port),
+		Arn:              fmt.Sprintf("%s/%s", sts.GetNamespace(), sts.GetName()),
+		EngineVersion:    imageTag(sts),
+		AllocatedStorage: storageGi(sts),
+		Tags:             tags(sts),
+	}, nil
+}
+
+// IsDBInstanceUpToDate compares the image and tags of the statefulset with the spec. The volume
+// claim template cannot change, storage is only set on create.
+func (l *InternalLocalClient) IsDBInstanceUpToDate(input *v1alpha1.DBInstance) (bool, interface{}, error) {
+	e, err := getEngine(input.Spec.Engine)
+	if err != nil {
+		return false, nil, err
+	}
+	sts, err := l.getStatefulSet(input.GetNamespace(), resourceName(input))
+	if err != nil {
+		return false, nil, err
+	}
+	upToDate := true
+	container := &sts.Spec.Template.Spec.Containers[0]
+	if input.Spec.EngineVersion != "" && container.Image != e.imageFor(input.Spec.EngineVersion) {
+		container.Image = e.imageFor(input.Spec.EngineVersion)
+		upToDate = false
+	}
+	if merged, changed := tagsChanged(tags(sts), l.cloudTags(input)); changed {
+		if err := setTags(sts, merged); err != nil {
+			return false, nil, err
+		}
+		upToDate = false
+	}
+	if upToDate {
+		return true, nil, nil
+	}
+	return false, sts, nil
+}
+
+// UpdateDBInstancePassword writes the new password into the init.sql of the instance and restarts it,
+// the password is applied when the database starts
+func (l *InternalLocalClient) UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error {
+	e, err := getEngine(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	secret := &v1.Secret{}
+	if err := l.client.Get(context.TODO(), types.NamespacedName{Namespace: input.GetNamespace(), Name: resourceName(input)}, secret); err != nil {
+		return err
+	}
+	secret.Data = secretData(e, masterUsername(e, input), password)
+	if err := l.client.Update(context.TODO(), secret); err != nil {
+		return err
+	}
+	sts, err := l.getStatefulSet(input.GetNamespace(), resourceName(input))
+	if err != nil {
+		return err
+	}
+	if sts.Spec.Template.Annotations == nil {
+		sts.Spec.Template.Annotations = map[string]string{}
+	}
+	sts.Spec.Template.Annotations[passwordAnnotation] = passwordHash(password)
+	return l.client.Update(context.TODO(), sts)
+}
+
+// BackfillDBInstanceSpec fills the spec fields left empty from the statefulset
+func (l *InternalLocalClient) BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error {
+	e, err := getEngine(input.Spec.Engine)
+	if err != nil {
+		return err
+	}
+	sts, err := l.getStatefulSet(input.GetNamespace(), resourceName(input))
+	if err != nil {
+		return err
+	}
+	spec := &input.Spec
+	if spec.EngineVersion == "" {
+		spec.EngineVersion = imageTag(sts)
+	}
+	if spec.AllocatedStorage == 0 {
+		spec.AllocatedStorage = storageGi(sts)
+	}
+	if len(spec.Tags) == 0 {
+		spec.Tags = utils.WithoutDefaultTags(tags(sts), l.defaultTags)
+		delete(spec.Tags, v1alpha1.OwnerTagKey)
+	}
+	for _, env := range sts.Spec.Template.Spec.Containers[0].Env {
+		if spec.MasterUsername == "" && e.userEnv != "" && env.Name == e.userEnv {
+			spec.MasterUsername = env.Value
+		}
+		if spec.DBName == "" && env.Name == e.dbNameEnv {
+			spec.DBName = env.Value
+		}
+	}
+	return nil
+}
+
+// AddTagsToResource adds tags to the statefulset, arn is the namespace/name of the statefulset
+func (l *InternalLocalClient) AddTagsToResource(arn string, newTags map[string]string) error {
+	parts := strings.SplitN(arn, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("expected namespace/name but got: %s", arn)
+	}
+	sts, err := l.getStatefulSet(parts[0], parts[1])
+	if err != nil {
+		return err
+	}
+	merged, changed := tagsChanged(tags(sts), newTags)
+	if !changed {
+		return nil
+	}
+	if err := setTags(sts, merged); err != nil {
+		return err
+	}
+	return l.client.Update(context.TODO(), sts)
+}
+
+func (l *InternalLocalClient) getStatefulSet(namespace, name string) (*appsv1.StatefulSet, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := l.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, sts); err != nil {
+		return nil, err
+	}
+	return sts, nil
+}
+
+// resourceName is the name of the statefulset, service and secret of an instance. The suffix keeps
+// the service apart from the ExternalName service named after the DBInstance.
+func resourceName(input *v1alpha1.DBInstance) string {
+	return strings.ToLower(input.GetDBInstanceID()) + "-local"
+}
+
+func labels(input *v1alpha1.DBInstance) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       input.Spec.Engine,
+		"app.kubernetes.io/instance":   resourceName(input),
+		"app.kubernetes.io/managed-by": "db-operator",
+	}
+}
+
+// masterUsername defaults to the superuser of the image
+func masterUsername(e engine, input *v1alpha1.DBInstance) string {
+	if input.Spec.MasterUsername != "" {
+		return input.Spec.MasterUsername
+	}
+	return e.rootUser
+}
+
+func secretData(e engine, user, password string) map[string][]byte {
+	return map[string][]byte{
+		passwordKey: []byte(password),
+		initSQLKey:  []byte(e.initSQL(user, password)),
+	}
+}
+
+func statefulSet(e engine, input *v1alpha1.DBInstance, password string, cloudTags map[string]string) *appsv1.StatefulSet {
+	name := resourceName(input)
+	passwordRef := &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: name},
+		Key:                  passwordKey,
+	}}
+	env := []v1.EnvVar{{Name: e.passwordEnv, ValueFrom: passwordRef}}
+	if e.userEnv != "" {
+		env = append(env, v1.EnvVar{Name: e.userEnv, Value: masterUsername(e, input)})
+	}
+	if input.Spec.DBName != "" {
+		env = append(env, v1.EnvVar{Name: e.dbNameEnv, Value: input.Spec.DBName})
+	}
+	for k, v := range e.extraEnv {
+		env = append(env, v1.EnvVar{Name: k, Value: v})
+	}
+	mounts := []v1.VolumeMount{
+		{Name: dataVolume, MountPath: e.dataPath},
+		{Name: initVolume, MountPath: initDir, ReadOnly: true},
+	}
+	if !e.isPostgres() {
+		// creates the master user when the data directory is initialized
+		mounts = append(mounts, v1.VolumeMount{Name: initVolume, MountPath: "/docker-entrypoint-initdb.d", ReadOnly: true})
+	}
+	container := v1.Container{
+		Name:         "database",
+		Image:        e.imageFor(input.Spec.EngineVersion),
+		Command:      e.command(),
+		Env:          env,
+		Ports:        []v1.ContainerPort{{Name: "db", ContainerPort: e.port}},
+		VolumeMounts: mounts,
+		ReadinessProbe: &v1.Probe{
+			Handler:       v1.Handler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(int(e.port))}},
+			PeriodSeconds: 5,
+		},
+	}
+	if hook := e.postStart(); hook != nil {
+		container.Lifecycle = &v1.Lifecycle{PostStart: &v1.Handler{Exec: &v1.ExecAction{Command: hook}}}
+	}
+
+	storageGi := input.Spec.AllocatedStorage
+	if storageGi == 0 {
+		storageGi = defaultStorageGi
+	}
+	replicas := int32(1)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: input.GetNamespace(), Labels: labels(input)},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: name,
+			Selector:    &metav1.LabelSelector{MatchLabels: labels(input)},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels(input),
+					Annotations: map[string]string{passwordAnnotation: passwordHash(password)},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{container},
+					Volumes: []v1.Volume{{
+						Name: initVolume,
+						VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{
+							SecretName: name,
+							Items:      []v1.KeyToPath{{Key: initSQLKey, Path: initSQLKey}},
+						}},
+					}},
+				},
+			},
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: dataVolume, Labels: labels(input)},
+				Spec: v1.PersistentVolumeClaimSpec{
+					AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+					Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+						v1.ResourceStorage: resource.MustParse(fmt.Sprintf("%dGi", storageGi)),
+					}},
+				},
+			}},
+		},
+	}
+	_ = setTags(sts, cloudTags)
+	return sts
+}
+
+// statefulSetPhase converts the status of the statefulset into the phase reported by the factories,
+// a rollout that is not done yet is a modification
+func statefulSetPhase(sts *appsv1.StatefulSet) string {
+	switch {
+	case sts.GetDeletionTimestamp() != nil:
+		return "deleting"
+	case sts.Status.ObservedGeneration < sts.GetGeneration() ||
+		sts.Status.UpdateRevision != sts.Status.CurrentRevision:
+		return "modifying"
+	case sts.Status.ReadyReplicas < 1:
+		return "creating"
+	}
+	return "available"
+}
+
+func imageTag(sts *appsv1.StatefulSet) string {
+	image := sts.Spec.Template.Spec.Containers[0].Image
+	return image[strings.LastIndex(image, ":")+1:]
+}
+
+func storageGi(sts *appsv1.StatefulSet) int64 {
+	for _, claim := range sts.Spec.VolumeClaimTemplates {
+		if claim.Name == dataVolume {
+			size := claim.Spec.Resources.Requests[v1.ResourceStorage]
+			return size.Value() / (1 << 30)
+		}
+	}
+	return 0
+}
+
+// cloudTags returns the tags of the instance plus the default tags of the provider
+func (l *InternalLocalClient) cloudTags(input *v1alpha1.DBInstance) map[string]string {
+	return utils.MergeTags(l.defaultTags, input.GetCloudTags())
+}
+
+func tags(sts *appsv1.StatefulSet) map[string]string {
+	out := map[string]string{}
+	if raw, ok := sts.GetAnnotations()[tagsAnnotation]; ok {
+		_ = json.Unmarshal([]byte(raw), &out)
+	}
+	return out
+}
+
+func setTags(sts *appsv1.StatefulSet, tags map[string]string) error {
+	raw, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+	annotations := sts.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[tagsAnnotation] = string(raw)
+	sts.SetAnnotations(annotations)
+	return nil
+}
+
+// tagsChanged returns current plus desired when a desired tag is missing or differs
+func tagsChanged(current, desired map[string]string) (map[string]string, bool) {
+	changed := false
+	out := make(map[string]string, len(current)+len(desired))
+	for k, v := range current {
+		out[k] = v
+	}
+	for k, v := range desired {
+		if current[k] != v {
+			changed = true
+		}
+		out[k] = v
+	}
+	return out, changed
+}
+
+func passwordHash(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,235 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T) (*InternalLocalClient, client.Client) {
+	t.Helper()
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	c := fake.NewFakeClientWithScheme(testScheme)
+	l, err := NewInternalLocalClient(logr.Discard(), c, nil)
+	if err != nil {
+		t.Fatalf("NewInternalLocalClient() error = %v", err)
+	}
+	return l, c
+}
+
+func newTestDBInstance(engine string) *v1alpha1.DBInstance {
+	return &v1alpha1.DBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "dev"},
+		Spec: v1alpha1.DBInstanceSpec{
+			Engine:           engine,
+			MasterUsername:   "app",
+			DBName:           "app",
+			AllocatedStorage: 5,
+			Tags:             map[string]string{"team": "payments"},
+		},
+	}
+}
+
+// markReady sets the status a statefulset controller reports once its pod is ready
+func markReady(t *testing.T, c client.Client, name string) {
+	t.Helper()
+	sts := &appsv1.StatefulSet{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: name}, sts); err != nil {
+		t.Fatal(err)
+	}
+	sts.Status = appsv1.StatefulSetStatus{ObservedGeneration: sts.GetGeneration(), ReadyReplicas: 1,
+		CurrentRevision: "r1", UpdateRevision: "r1"}
+	if err := c.Status().Update(context.TODO(), sts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDBInstanceLifecycle(t *testing.T) {
+	l, c := newTestClient(t)
+	cr := newTestDBInstance("postgres")
+
+	status, err := l.DBInstanceExists(cr)
+	if err != nil || status.Exists {
+		t.Fatalf("DBInstanceExists() = %v, %v, want not found", status, err)
+	}
+	if err := l.CreateDBInstance(cr, "it's-secret"); err != nil {
+		t.Fatalf("CreateDBInstance() error = %v", err)
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, sts); err != nil {
+		t.Fatalf("CreateDBInstance() did not create the statefulset: %v", err)
+	}
+	container := sts.Spec.Template.Spec.Containers[0]
+	if container.Image != "postgres:14" || container.Lifecycle == nil || sts.Spec.ServiceName != "dev-app-local" {
+		t.Errorf("CreateDBInstance() created container %+v", container)
+	}
+	env := map[string]v1.EnvVar{}
+	for _, e := range container.Env {
+		env[e.Name] = e
+	}
+	if env["POSTGRES_USER"].Value != "app" || env["POSTGRES_DB"].Value != "app" ||
+		env["POSTGRES_PASSWORD"].ValueFrom.SecretKeyRef.Name != "dev-app-local" {
+		t.Errorf("CreateDBInstance() created env %+v", env)
+	}
+	if storageGi(sts) != 5 {
+		t.Errorf("CreateDBInstance() created a %vGi volume, want 5Gi", storageGi(sts))
+	}
+	secret := &v1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, secret); err != nil {
+		t.Fatalf("CreateDBInstance() did not create the secret: %v", err)
+	}
+	if got := string(secret.Data[initSQLKey]); got != "ALTER USER \"app\" WITH PASSWORD 'it''s-secret';\n" {
+		t.Errorf("CreateDBInstance() init.sql = %q", got)
+	}
+	svc := &v1.Service{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, svc); err != nil {
+		t.Fatalf("CreateDBInstance() did not create the service: %v", err)
+	}
+
+	status, err = l.DBInstanceExists(cr)
+	if err != nil || status.CurrentPhase != "creating" {
+		t.Fatalf("DBInstanceExists() = %+v, %v, want creating until the pod is ready", status, err)
+	}
+	markReady(t, c, "dev-app-local")
+	status, err = l.DBInstanceExists(cr)
+	if err != nil {
+		t.Fatalf("DBInstanceExists() error = %v", err)
+	}
+	if !status.Exists || status.CurrentPhase != "available" || status.Endpoint != "dev-app-local.dev.svc.cluster.local" ||
+		status.Port != 5432 || status.Arn != "dev/dev-app-local" || status.Tags[v1alpha1.OwnerTagKey] != cr.GetOwnerTagValue() {
+		t.Errorf("DBInstanceExists() = %+v", status)
+	}
+
+	if upToDate, _, err := l.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want up to date after create", upToDate, err)
+	}
+	cr.Spec.EngineVersion = "15.2"
+	upToDate, modifyIn, err := l.IsDBInstanceUpToDate(cr)
+	if err != nil || upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want a modification", upToDate, err)
+	}
+	if err := l.ModifyDBInstance(modifyIn); err != nil {
+		t.Fatalf("ModifyDBInstance() error = %v", err)
+	}
+	if upToDate, _, err := l.IsDBInstanceUpToDate(cr); err != nil || !upToDate {
+		t.Fatalf("IsDBInstanceUpToDate() = %v, %v, want up to date after modify", upToDate, err)
+	}
+
+	if err := l.UpdateDBInstancePassword(cr, "rotated"); err != nil {
+		t.Fatalf("UpdateDBInstancePassword() error = %v", err)
+	}
+	_ = c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, secret)
+	_ = c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, sts)
+	if string(secret.Data[passwordKey]) != "rotated" || !strings.Contains(string(secret.Data[initSQLKey]), "'rotated'") ||
+		sts.Spec.Template.Annotations[passwordAnnotation] != passwordHash("rotated") {
+		t.Errorf("UpdateDBInstancePassword() did not update the secret and restart the pod")
+	}
+
+	if err := l.AddTagsToResource(status.Arn, map[string]string{"cost-center": "42"}); err != nil {
+		t.Fatalf("AddTagsToResource() error = %v", err)
+	}
+	_ = c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, sts)
+	if got := tags(sts); got["cost-center"] != "42" || got["team"] != "payments" {
+		t.Errorf("AddTagsToResource() tags = %v", got)
+	}
+
+	if err := l.DeleteDBInstance(cr); err != nil {
+		t.Fatalf("DeleteDBInstance() error = %v", err)
+	}
+	if err := l.DeleteDBInstance(cr); err != nil {
+		t.Errorf("DeleteDBInstance() of a deleted instance error = %v, want nil", err)
+	}
+	if status, err := l.DBInstanceExists(cr); err != nil || status.Exists {
+		t.Errorf("DBInstanceExists() after delete = %v, %v", status, err)
+	}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, secret); err == nil {
+		t.Errorf("DeleteDBInstance() did not delete the secret")
+	}
+}
+
+func TestMySQLInitSQL(t *testing.T) {
+	l, c := newTestClient(t)
+	cr := newTestDBInstance("mysql")
+	cr.Spec.EngineVersion = "8.0.28"
+	if err := l.CreateDBInstance(cr, `back\slash`); err != nil {
+		t.Fatalf("CreateDBInstance() error = %v", err)
+	}
+	secret := &v1.Secret{}
+	_ = c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, secret)
+	want := "CREATE USER IF NOT EXISTS 'app'@'%' IDENTIFIED BY 'back\\\\slash';\n" +
+		"ALTER USER 'app'@'%' IDENTIFIED BY 'back\\\\slash';\n" +
+		"GRANT ALL PRIVILEGES ON *.* TO 'app'@'%' WITH GRANT OPTION;\n"
+	if got := string(secret.Data[initSQLKey]); got != want {
+		t.Errorf("CreateDBInstance() init.sql = %q, want %q", got, want)
+	}
+	sts := &appsv1.StatefulSet{}
+	_ = c.Get(context.TODO(), types.NamespacedName{Namespace: "dev", Name: "dev-app-local"}, sts)
+	container := sts.Spec.Template.Spec.Containers[0]
+	if container.Image != "mysql:8.0.28" || len(container.Command) == 0 || len(container.VolumeMounts) != 3 {
+		t.Errorf("CreateDBInstance() created container %+v", container)
+	}
+
+	cr.Spec.MasterUsername = "root"
+	if got := engines["mysql"].initSQL(masterUsername(engines["mysql"], cr), "pw"); !strings.HasPrefix(got, "ALTER USER IF EXISTS 'root'@'localhost'") {
+		t.Errorf("initSQL() of root = %q", got)
+	}
+}
+
+func TestBackfillDBInstanceSpec(t *testing.T) {
+	l, _ := newTestClient(t)
+	if err := l.CreateDBInstance(newTestDBInstance("postgres"), "secret"); err != nil {
+		t.Fatal(err)
+	}
+	cr := &v1alpha1.DBInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "dev"},
+		Spec:       v1alpha1.DBInstanceSpec{Engine: "postgres"},
+	}
+	if err := l.BackfillDBInstanceSpec(cr); err != nil {
+		t.Fatalf("BackfillDBInstanceSpec() error = %v", err)
+	}
+	if cr.Spec.EngineVersion != "14" || cr.Spec.AllocatedStorage != 5 || cr.Spec.MasterUsername != "app" ||
+		cr.Spec.DBName != "app" || cr.Spec.Tags["team"] != "payments" {
+		t.Errorf("BackfillDBInstanceSpec() spec = %+v", cr.Spec)
+	}
+	if _, ok := cr.Spec.Tags[v1alpha1.OwnerTagKey]; ok {
+		t.Errorf("BackfillDBInstanceSpec() should not copy the owner tag into tags")
+	}
+}
+
+func TestDBInstanceErrors(t *testing.T) {
+	l, _ := newTestClient(t)
+
+	if err := l.CreateDBInstance(newTestDBInstance("oracle-ee"), "secret"); !errors.As(err, &ErrUnsupportedEngine{}) {
+		t.Errorf("CreateDBInstance() of oracle error = %v, want ErrUnsupportedEngine", err)
+	}
+
+	cr := newTestDBInstance("postgres")
+	cr.Spec.DeletionProtection = true
+	if err := l.DeleteDBInstance(cr); !errors.As(err, &ErrDBInstanceDeletionProtectionEnabled{}) {
+		t.Errorf("DeleteDBInstance() error = %v, want ErrDBInstanceDeletionProtectionEnabled", err)
+	}
+
+	cr = newTestDBInstance("postgres")
+	cr.Status.FinalSnapshotIdentifier = "app-final"
+	if err := l.DeleteDBInstance(cr); !errors.As(err, &utils.ErrNotSupported{}) {
+		t.Errorf("DeleteDBInstance() with a final snapshot error = %v, want ErrNotSupported", err)
+	}
+
+	if _, err := NewInternalLocalClient(logr.Discard(), nil, nil); !errors.As(err, &ErrMissingKubeClient{}) {
+		t.Errorf("NewInternalLocalClient() without a client error = %v, want ErrMissingKubeClient", err)
+	}
+}
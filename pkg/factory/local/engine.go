@@ -0,0 +1,125 @@
+package local
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// initDir holds the init.sql that sets the password of the master user
+	initDir = "/etc/db-operator"
+	// retries of the postgres post start hook, two seconds apart
+	postStartRetries = 90
+)
+
+// engine describes how the official image of an engine is run
+type engine struct {
+	image          string
+	defaultVersion string
+	port           int32
+	dataPath       string
+	// rootUser is the superuser the image creates, the master user defaults to it
+	rootUser string
+	// passwordEnv is set from the password secret and only read when the data directory is initialized
+	passwordEnv string
+	userEnv     string
+	dbNameEnv   string
+	extraEnv    map[string]string
+}
+
+var engines = map[string]engine{
+	"postgres": {
+		image:          "postgres",
+		defaultVersion: "14",
+		port:           5432,
+		dataPath:       "/var/lib/postgresql/data",
+		rootUser:       "postgres",
+		passwordEnv:    "POSTGRES_PASSWORD",
+		userEnv:        "POSTGRES_USER",
+		dbNameEnv:      "POSTGRES_DB",
+		// a sub directory keeps the lost+found of the volume out of the data directory
+		extraEnv: map[string]string{"PGDATA": "/var/lib/postgresql/data/pgdata"},
+	},
+	"mysql": {
+		image:          "mysql",
+		defaultVersion: "8.0",
+		port:           3306,
+		dataPath:       "/var/lib/mysql",
+		rootUser:       "root",
+		passwordEnv:    "MYSQL_ROOT_PASSWORD",
+		dbNameEnv:      "MYSQL_DATABASE",
+	},
+	"mariadb": {
+		image:          "mariadb",
+		defaultVersion: "10.6",
+		port:           3306,
+		dataPath:       "/var/lib/mysql",
+		rootUser:       "root",
+		passwordEnv:    "MYSQL_ROOT_PASSWORD",
+		dbNameEnv:      "MYSQL_DATABASE",
+	},
+}
+
+func getEngine(name string) (engine, error) {
+	e, ok := engines[name]
+	if !ok {
+		return engine{}, ErrUnsupportedEngine{Message: fmt.Sprintf("engine %s is not supported by the local provider, "+
+			"use postgres, mysql or mariadb", name)}
+	}
+	return e, nil
+}
+
+func (e engine) isPostgres() bool {
+	return e.image == "postgres"
+}
+
+// imageFor returns the image of an engine version, the version is used as image tag
+func (e engine) imageFor(engineVersion string) string {
+	if engineVersion == "" {
+		engineVersion = e.defaultVersion
+	}
+	return fmt.Sprintf("%s:%s", e.image, engineVersion)
+}
+
+// initSQL sets the password of the master user. Postgres runs it from a post start hook, mysql and
+// mariadb run it once when the data directory is initialized and then with --init-file on every start.
+func (e engine) initSQL(user, password string) string {
+	if e.isPostgres() {
+		// standard_conforming_strings is on, backslashes are literal
+		return fmt.Sprintf("ALTER USER \"%s\" WITH PASSWORD '%s';\n", strings.ReplaceAll(user, `"`, `""`),
+			strings.ReplaceAll(password, "'", "''"))
+	}
+	user, password = quoteSQL(user), quoteSQL(password)
+	if user == e.rootUser {
+		return fmt.Sprintf("ALTER USER IF EXISTS 'root'@'localhost' IDENTIFIED BY '%[1]s';\n"+
+			"ALTER USER IF EXISTS 'root'@'%%' IDENTIFIED BY '%[1]s';\n", password)
+	}
+	return fmt.Sprintf("CREATE USER IF NOT EXISTS '%[1]s'@'%%' IDENTIFIED BY '%[2]s';\n"+
+		"ALTER USER '%[1]s'@'%%' IDENTIFIED BY '%[2]s';\n"+
+		"GRANT ALL PRIVILEGES ON *.* TO '%[1]s'@'%%' WITH GRANT OPTION;\n", user, password)
+}
+
+// command returns the command of the container, nil keeps the entrypoint of the image. The init file
+// is only passed once the data directory exists, the entrypoint initializes it with the password env.
+func (e engine) command() []string {
+	if e.isPostgres() {
+		return nil
+	}
+	return []string{"sh", "-c", fmt.Sprintf("if [ -d %[1]s/mysql ]; then exec docker-entrypoint.sh mysqld --init-file=%[2]s/init.sql; fi; "+
+		"exec docker-entrypoint.sh mysqld", e.dataPath, initDir)}
+}
+
+// postStart returns the post start hook of the container, postgres has no init file and applies the
+// password through the local socket once the server accepts connections
+func (e engine) postStart() []string {
+	if !e.isPostgres() {
+		return nil
+	}
+	return []string{"sh", "-c", fmt.Sprintf("for i in $(seq %d); do pg_isready -q -h 127.0.0.1 && break; sleep 2; done; "+
+		"psql -q -U \"$POSTGRES_USER\" -d postgres -f %s/init.sql || true", postStartRetries, initDir)}
+}
+
+// quoteSQL escapes s for a single quoted mysql string
+func quoteSQL(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(s)
+}
@@ -0,0 +1,25 @@
+package local
+
+type ErrMissingKubeClient struct {
+	Message string
+}
+
+func (e ErrMissingKubeClient) Error() string {
+	return e.Message
+}
+
+type ErrUnsupportedEngine struct {
+	Message string
+}
+
+func (e ErrUnsupportedEngine) Error() string {
+	return e.Message
+}
+
+type ErrDBInstanceDeletionProtectionEnabled struct {
+	Message string
+}
+
+func (e ErrDBInstanceDeletionProtectionEnabled) Error() string {
+	return e.Message
+}
@@ -2,6 +2,7 @@ package factory
 
 import (
 	"github.com/agill17/db-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type MockCloudDB struct {
@@ -14,6 +15,57 @@ type MockCloudDB struct {
 	DBStatusResp                *v1alpha1.DBStatus
 	DBClusterExistsErr          error
 	ModifyDBClusterErr          error
+	UpdateDBClusterPasswordErr  error
+	RestoreDBClusterErr         error
+	RestoreTimeResp             *metav1.Time
+	SnapshotStateResp           *v1alpha1.SnapshotState
+	SnapshotExistsErr           error
+	CreateSnapshotErr           error
+	DeleteSnapshotErr           error
+	BackfillDBClusterSpecErr    error
+	AddTagsErr                  error
+	PromoteErr                  error
+	DBClusterEndpointsResp      []v1alpha1.DBClusterEndpointState
+	DBClusterEndpointsErr       error
+	CreateEndpointErr           error
+	ModifyEndpointErr           error
+	DeleteEndpointErr           error
+	GlobalClusterStateResp      *v1alpha1.GlobalClusterState
+	GlobalClusterExistsErr      error
+	CreateGlobalClusterErr      error
+	ModifyGlobalClusterErr      error
+	FailoverGlobalClusterErr    error
+	RemoveFromGlobalClusterErr  error
+	DeleteGlobalClusterErr      error
+	DBSubnetGroupStateResp      *v1alpha1.DBSubnetGroupState
+	DBSubnetGroupExistsErr      error
+	CreateDBSubnetGroupErr      error
+	ModifyDBSubnetGroupErr      error
+	DeleteDBSubnetGroupErr      error
+	ParameterGroupStateResp     *v1alpha1.ParameterGroupState
+	ParameterGroupExistsErr     error
+	CreateParameterGroupErr     error
+	ModifyParameterGroupErr     error
+	ResetParameterGroupErr      error
+	DeleteParameterGroupErr     error
+	OptionGroupStateResp        *v1alpha1.OptionGroupState
+	OptionGroupExistsErr        error
+	CreateOptionGroupErr        error
+	ModifyOptionGroupErr        error
+	DeleteOptionGroupErr        error
+	AccountIDResp               string
+	AccountIDErr                error
+	DBInstanceStatusResp        *v1alpha1.DBStatus
+	DBInstanceExistsErr         error
+	CreateDBInstanceErr         error
+	DeleteDBInstanceErr         error
+	ModifyDBInstanceErr         error
+	IsDBInstanceUpToDateResp    bool
+	IsDBInstanceUpToDateErr     error
+	UpdateDBInstancePasswordErr error
+	RestoreDBInstanceErr        error
+	BackfillDBInstanceSpecErr   error
+	CreateReadReplicaErr        error
 }
 
 func (m *MockCloudDB) CreateDBCluster(input *v1alpha1.DBCluster, password string) error {
@@ -31,6 +83,162 @@ func (m *MockCloudDB) DeleteDBCluster(input *v1alpha1.DBCluster) error {
 func (m *MockCloudDB) DBClusterExists(dbClusterID string) (*v1alpha1.DBStatus, error) {
 	return m.DBStatusResp, m.DBClusterExistsErr
 }
+func (m *MockCloudDB) UpdateDBClusterPassword(input *v1alpha1.DBCluster, password string) error {
+	return m.UpdateDBClusterPasswordErr
+}
+func (m *MockCloudDB) RestoreDBClusterFromSnapshot(input *v1alpha1.DBCluster, snapshotID string) error {
+	return m.RestoreDBClusterErr
+}
+func (m *MockCloudDB) RestoreDBClusterToPointInTime(input *v1alpha1.DBCluster, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error) {
+	return m.RestoreTimeResp, m.RestoreDBClusterErr
+}
+func (m *MockCloudDB) BackfillDBClusterSpec(input *v1alpha1.DBCluster) error {
+	return m.BackfillDBClusterSpecErr
+}
+func (m *MockCloudDB) PromoteDBClusterReadReplica(input *v1alpha1.DBCluster) error {
+	return m.PromoteErr
+}
+func (m *MockCloudDB) DBClusterEndpoints(dbClusterID string) ([]v1alpha1.DBClusterEndpointState, error) {
+	return m.DBClusterEndpointsResp, m.DBClusterEndpointsErr
+}
+func (m *MockCloudDB) CreateDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error {
+	return m.CreateEndpointErr
+}
+func (m *MockCloudDB) ModifyDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error {
+	return m.ModifyEndpointErr
+}
+func (m *MockCloudDB) DeleteDBClusterEndpoint(endpointID string) error {
+	return m.DeleteEndpointErr
+}
+func (m *MockCloudDB) CreateGlobalCluster(input *v1alpha1.GlobalCluster, sourceDBClusterArn string) error {
+	return m.CreateGlobalClusterErr
+}
+func (m *MockCloudDB) GlobalClusterExists(globalClusterID string) (*v1alpha1.GlobalClusterState, error) {
+	return m.GlobalClusterStateResp, m.GlobalClusterExistsErr
+}
+func (m *MockCloudDB) ModifyGlobalCluster(input *v1alpha1.GlobalCluster) error {
+	return m.ModifyGlobalClusterErr
+}
+func (m *MockCloudDB) FailoverGlobalCluster(globalClusterID, targetDBClusterArn string) error {
+	return m.FailoverGlobalClusterErr
+}
+func (m *MockCloudDB) RemoveFromGlobalCluster(globalClusterID, dbClusterArn string) error {
+	return m.RemoveFromGlobalClusterErr
+}
+func (m *MockCloudDB) DeleteGlobalCluster(globalClusterID string) error {
+	return m.DeleteGlobalClusterErr
+}
+func (m *MockCloudDB) CreateDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error {
+	return m.CreateDBSubnetGroupErr
+}
+func (m *MockCloudDB) DBSubnetGroupExists(name string) (*v1alpha1.DBSubnetGroupState, error) {
+	return m.DBSubnetGroupStateResp, m.DBSubnetGroupExistsErr
+}
+func (m *MockCloudDB) ModifyDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error {
+	return m.ModifyDBSubnetGroupErr
+}
+func (m *MockCloudDB) DeleteDBSubnetGroup(name string) error {
+	return m.DeleteDBSubnetGroupErr
+}
+func (m *MockCloudDB) CreateDBParameterGroup(input *v1alpha1.DBParameterGroup) error {
+	return m.CreateParameterGroupErr
+}
+func (m *MockCloudDB) DBParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error) {
+	return m.ParameterGroupStateResp, m.ParameterGroupExistsErr
+}
+func (m *MockCloudDB) ModifyDBParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error {
+	return m.ModifyParameterGroupErr
+}
+func (m *MockCloudDB) ResetDBParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error {
+	return m.ResetParameterGroupErr
+}
+func (m *MockCloudDB) DeleteDBParameterGroup(name string) error {
+	return m.DeleteParameterGroupErr
+}
+func (m *MockCloudDB) CreateDBClusterParameterGroup(input *v1alpha1.DBClusterParameterGroup) error {
+	return m.CreateParameterGroupErr
+}
+func (m *MockCloudDB) DBClusterParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error) {
+	return m.ParameterGroupStateResp, m.ParameterGroupExistsErr
+}
+func (m *MockCloudDB) ModifyDBClusterParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error {
+	return m.ModifyParameterGroupErr
+}
+func (m *MockCloudDB) ResetDBClusterParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error {
+	return m.ResetParameterGroupErr
+}
+func (m *MockCloudDB) DeleteDBClusterParameterGroup(name string) error {
+	return m.DeleteParameterGroupErr
+}
+func (m *MockCloudDB) CreateOptionGroup(input *v1alpha1.OptionGroup) error {
+	return m.CreateOptionGroupErr
+}
+func (m *MockCloudDB) OptionGroupExists(name string) (*v1alpha1.OptionGroupState, error) {
+	return m.OptionGroupStateResp, m.OptionGroupExistsErr
+}
+func (m *MockCloudDB) ModifyOptionGroup(name string, include []v1alpha1.Option, remove []string) error {
+	return m.ModifyOptionGroupErr
+}
+func (m *MockCloudDB) DeleteOptionGroup(name string) error {
+	return m.DeleteOptionGroupErr
+}
+func (m *MockCloudDB) CreateDBInstance(input *v1alpha1.DBInstance, password string) error {
+	return m.CreateDBInstanceErr
+}
+func (m *MockCloudDB) DeleteDBInstance(input *v1alpha1.DBInstance) error {
+	return m.DeleteDBInstanceErr
+}
+func (m *MockCloudDB) ModifyDBInstance(modifyIn interface{}) error {
+	return m.ModifyDBInstanceErr
+}
+func (m *MockCloudDB) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error) {
+	return m.DBInstanceStatusResp, m.DBInstanceExistsErr
+}
+func (m *MockCloudDB) IsDBInstanceUpToDate(input *v1alpha1.DBInstance) (bool, interface{}, error) {
+	return m.IsDBInstanceUpToDateResp, nil, m.IsDBInstanceUpToDateErr
+}
+func (m *MockCloudDB) UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error {
+	return m.UpdateDBInstancePasswordErr
+}
+func (m *MockCloudDB) RestoreDBInstanceFromSnapshot(input *v1alpha1.DBInstance, snapshotID string) error {
+	return m.RestoreDBInstanceErr
+}
+func (m *MockCloudDB) RestoreDBInstanceToPointInTime(input *v1alpha1.DBInstance, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error) {
+	return m.RestoreTimeResp, m.RestoreDBInstanceErr
+}
+func (m *MockCloudDB) BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error {
+	return m.BackfillDBInstanceSpecErr
+}
+func (m *MockCloudDB) CreateDBInstanceReadReplica(input *v1alpha1.DBInstance, sourceID, sourceRegion string) error {
+	return m.CreateReadReplicaErr
+}
+func (m *MockCloudDB) PromoteDBInstanceReadReplica(input *v1alpha1.DBInstance) error {
+	return m.PromoteErr
+}
+func (m *MockCloudDB) AddTagsToResource(arn string, tags map[string]string) error {
+	return m.AddTagsErr
+}
+func (m *MockCloudDB) GetAccountID() (string, error) {
+	return m.AccountIDResp, m.AccountIDErr
+}
+func (m *MockCloudDB) CreateDBSnapshot(input *v1alpha1.DBSnapshot, dbInstanceID string) error {
+	return m.CreateSnapshotErr
+}
+func (m *MockCloudDB) DeleteDBSnapshot(snapshotID string) error {
+	return m.DeleteSnapshotErr
+}
+func (m *MockCloudDB) DBSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error) {
+	return m.SnapshotStateResp, m.SnapshotExistsErr
+}
+func (m *MockCloudDB) CreateDBClusterSnapshot(input *v1alpha1.DBClusterSnapshot, dbClusterID string) error {
+	return m.CreateSnapshotErr
+}
+func (m *MockCloudDB) DeleteDBClusterSnapshot(snapshotID string) error {
+	return m.DeleteSnapshotErr
+}
+func (m *MockCloudDB) DBClusterSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error) {
+	return m.SnapshotStateResp, m.SnapshotExistsErr
+}
 
 //type MockRDS struct {
 //	rdsiface.RDSAPI
@@ -0,0 +1,168 @@
+package factory
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/go-logr/logr"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Constructor builds the client of a provider from the resolved provider options
+type Constructor func(logger logr.Logger, options ProviderOptions) (Provider, error)
+
+// Capabilities are the optional features a provider implements. Every provider implements Provider, the client
+// of a provider declaring a capability has to implement its interfaces as well.
+type Capabilities struct {
+	// Clusters needs DBCluster, DBClusterEndpoint and GlobalCluster
+	Clusters bool
+	// Snapshots needs DBSnapshot and DBInstanceRestore
+	Snapshots bool
+	// Replicas needs DBInstanceReplica
+	Replicas bool
+	// Serverless needs DBCluster
+	Serverless      bool
+	SubnetGroups    bool
+	ParameterGroups bool
+	OptionGroups    bool
+}
+
+// Supports is true when the provider declared the feature
+func (c Capabilities) Supports(feature v1alpha1.ProviderFeature) bool {
+	switch feature {
+	case v1alpha1.FeatureClusters:
+		return c.Clusters
+	case v1alpha1.FeatureSnapshots:
+		return c.Snapshots
+	case v1alpha1.FeatureReplicas:
+		return c.Replicas
+	case v1alpha1.FeatureServerless:
+		return c.Serverless
+	case v1alpha1.FeatureSubnetGroups:
+		return c.SubnetGroups
+	case v1alpha1.FeatureParameterGroups:
+		return c.ParameterGroups
+	case v1alpha1.FeatureOptionGroups:
+		return c.OptionGroups
+	}
+	return false
+}
+
+// capabilitiesOf returns the capabilities whose interfaces the client implements
+func capabilitiesOf(p Provider) Capabilities {
+	_, cluster := p.(DBCluster)
+	_, endpoint := p.(DBClusterEndpoint)
+	_, global := p.(GlobalCluster)
+	_, snapshot := p.(DBSnapshot)
+	_, restore := p.(DBInstanceRestore)
+	_, replica := p.(DBInstanceReplica)
+	_, subnetGroup := p.(DBSubnetGroup)
+	_, parameterGroup := p.(DBParameterGroup)
+	_, optionGroup := p.(OptionGroup)
+	return Capabilities{
+		Clusters:        cluster && endpoint && global,
+		Snapshots:       snapshot && restore,
+		Replicas:        replica,
+		Serverless:      cluster,
+		SubnetGroups:    subnetGroup,
+		ParameterGroups: parameterGroup,
+		OptionGroups:    optionGroup,
+	}
+}
+
+// notImplementedBy returns the declared capabilities whose interfaces the client does not implement
+func (c Capabilities) notImplementedBy(p Provider) []string {
+	implemented := capabilitiesOf(p)
+	var missing []string
+	for _, feature := range []v1alpha1.ProviderFeature{v1alpha1.FeatureClusters, v1alpha1.FeatureSnapshots,
+		v1alpha1.FeatureReplicas, v1alpha1.FeatureServerless, v1alpha1.FeatureSubnetGroups,
+		v1alpha1.FeatureParameterGroups, v1alpha1.FeatureOptionGroups} {
+		if c.Supports(feature) && !implemented.Supports(feature) {
+			missing = append(missing, string(feature))
+		}
+	}
+	return missing
+}
+
+type ErrUnknownProvider struct {
+	Message string
+}
+
+func (e ErrUnknownProvider) Error() string {
+	return e.Message
+}
+
+type registration struct {
+	constructor  Constructor
+	capabilities Capabilities
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[v1alpha1.ProviderType]registration{}
+)
+
+// Register makes a provider type available to NewCloudDB. Providers outside this repository call it from an
+// init func of their package, which is then imported by main. Registering a type twice panics.
+func Register(pType v1alpha1.ProviderType, capabilities Capabilities, constructor Constructor) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if pType == "" || constructor == nil {
+		panic("factory: Register needs a provider type and a constructor")
+	}
+	if _, exists := registry[pType]; exists {
+		panic(fmt.Sprintf("factory: provider %s is already registered", pType))
+	}
+	registry[pType] = registration{constructor: constructor, capabilities: capabilities}
+}
+
+// GetCapabilities returns the capabilities of a provider type, false when the type is not registered
+func GetCapabilities(pType v1alpha1.ProviderType) (Capabilities, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	r, ok := registry[pType]
+	return r.capabilities, ok
+}
+
+// RegisteredProviders returns the registered provider types in alphabetical order
+func RegisteredProviders() []v1alpha1.ProviderType {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	out := make([]v1alpha1.ProviderType, 0, len(registry))
+	for pType := range registry {
+		out = append(out, pType)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// ValidateFeatures returns ErrUnknownProvider when the provider type is not registered and
+// utils.ErrNotSupported naming every feature the provider does not support
+func ValidateFeatures(pType v1alpha1.ProviderType, features ...v1alpha1.ProviderFeature) error {
+	capabilities, ok := GetCapabilities(pType)
+	if !ok {
+		return unknownProvider(pType)
+	}
+	var unsupported []string
+	for _, feature := range features {
+		if !capabilities.Supports(feature) {
+			unsupported = append(unsupported, string(feature))
+		}
+	}
+	if len(unsupported) > 0 {
+		return utils.ErrNotSupported{Message: fmt.Sprintf("the %s provider does not support %s", pType,
+			strings.Join(unsupported, ", "))}
+	}
+	return nil
+}
+
+func unknownProvider(pType v1alpha1.ProviderType) error {
+	registered := make([]string, 0)
+	for _, p := range RegisteredProviders() {
+		registered = append(registered, string(p))
+	}
+	return ErrUnknownProvider{Message: fmt.Sprintf("provider %s is not registered, use one of %s", pType,
+		strings.Join(registered, ", "))}
+}
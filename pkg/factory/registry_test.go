@@ -0,0 +1,159 @@
+package factory
+
+import (
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	internalAwsImpl "github.com/agill17/db-operator/pkg/factory/aws"
+	internalAzureImpl "github.com/agill17/db-operator/pkg/factory/azure"
+	internalGcpImpl "github.com/agill17/db-operator/pkg/factory/gcp"
+	internalLocalImpl "github.com/agill17/db-operator/pkg/factory/local"
+	"github.com/agill17/db-operator/pkg/utils"
+	"github.com/go-logr/logr"
+	"reflect"
+	"testing"
+)
+
+func TestValidateFeatures(t *testing.T) {
+	tests := []struct {
+		name        string
+		pType       v1alpha1.ProviderType
+		features    []v1alpha1.ProviderFeature
+		wantErr     bool
+		wantUnknown bool
+	}{
+		{
+			name:     "aws supports every feature",
+			pType:    v1alpha1.AWS,
+			features: []v1alpha1.ProviderFeature{v1alpha1.FeatureClusters, v1alpha1.FeatureSnapshots, v1alpha1.FeatureReplicas, v1alpha1.FeatureServerless},
+		},
+		{
+			name:  "a plain db instance needs no feature",
+			pType: v1alpha1.Local,
+		},
+		{
+			name:     "local does not support replicas",
+			pType:    v1alpha1.Local,
+			features: []v1alpha1.ProviderFeature{v1alpha1.FeatureReplicas},
+			wantErr:  true,
+		},
+		{
+			name:        "unknown provider",
+			pType:       "openstack",
+			wantErr:     true,
+			wantUnknown: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFeatures(tt.pType, tt.features...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateFeatures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if gotUnknown := errors.As(err, &ErrUnknownProvider{}); gotUnknown != tt.wantUnknown {
+				t.Errorf("ValidateFeatures() error = %T, wantUnknown %v", err, tt.wantUnknown)
+			}
+			if !tt.wantUnknown && !errors.As(err, &utils.ErrNotSupported{}) {
+				t.Errorf("ValidateFeatures() error = %T, want utils.ErrNotSupported", err)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	const internal v1alpha1.ProviderType = "registry-test"
+	want := &MockCloudDB{}
+	Register(internal, Capabilities{Snapshots: true}, func(logger logr.Logger, options ProviderOptions) (Provider, error) {
+		return want, nil
+	})
+
+	got, err := NewCloudDB(logr.Discard(), ProviderOptions{Type: internal})
+	if err != nil || got != want {
+		t.Errorf("NewCloudDB() = %v, %v, want the registered client", got, err)
+	}
+	capabilities, ok := GetCapabilities(internal)
+	if !ok || !reflect.DeepEqual(capabilities, Capabilities{Snapshots: true}) {
+		t.Errorf("GetCapabilities() = %v, %v, want %v", capabilities, ok, Capabilities{Snapshots: true})
+	}
+	if _, err := NewCloudDB(logr.Discard(), ProviderOptions{Type: "openstack"}); !errors.As(err, &ErrUnknownProvider{}) {
+		t.Errorf("NewCloudDB() error = %v, want ErrUnknownProvider", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() of %s twice did not panic", internal)
+		}
+	}()
+	Register(internal, Capabilities{}, func(logger logr.Logger, options ProviderOptions) (Provider, error) {
+		return nil, nil
+	})
+}
+
+func TestBuiltinCapabilities(t *testing.T) {
+	tests := []struct {
+		pType  v1alpha1.ProviderType
+		client Provider
+		want   Capabilities
+	}{
+		{
+			pType:  v1alpha1.AWS,
+			client: &internalAwsImpl.InternalAwsClients{},
+			want: Capabilities{Clusters: true, Snapshots: true, Replicas: true, Serverless: true,
+				SubnetGroups: true, ParameterGroups: true, OptionGroups: true},
+		},
+		{pType: v1alpha1.GCP, client: &internalGcpImpl.InternalGcpClient{}, want: Capabilities{}},
+		{pType: v1alpha1.Azure, client: &internalAzureImpl.InternalAzureClient{}, want: Capabilities{}},
+		{pType: v1alpha1.Local, client: &internalLocalImpl.InternalLocalClient{}, want: Capabilities{}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.pType), func(t *testing.T) {
+			got, ok := GetCapabilities(tt.pType)
+			if !ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCapabilities() = %v, %v, want %v", got, ok, tt.want)
+			}
+			if implemented := capabilitiesOf(tt.client); !reflect.DeepEqual(implemented, got) {
+				t.Errorf("capabilitiesOf() = %v, want the declared %v", implemented, got)
+			}
+		})
+	}
+}
+
+// instanceOnly implements nothing but Provider
+type instanceOnly struct {
+	DBInstance
+	Tagger
+	Identity
+}
+
+func TestNewCloudDB_CheckedCapabilities(t *testing.T) {
+	const (
+		instances v1alpha1.ProviderType = "instances-test"
+		overclaim v1alpha1.ProviderType = "overclaim-test"
+	)
+	mock := &MockCloudDB{}
+	constructor := func(logger logr.Logger, options ProviderOptions) (Provider, error) {
+		return instanceOnly{DBInstance: mock, Tagger: mock, Identity: mock}, nil
+	}
+	Register(instances, Capabilities{}, constructor)
+	Register(overclaim, Capabilities{Snapshots: true, Replicas: true}, constructor)
+
+	c, err := NewCloudDB(logr.Discard(), ProviderOptions{Type: instances})
+	if err != nil {
+		t.Fatalf("NewCloudDB() error = %v", err)
+	}
+	if _, err := c.DBInstanceExists(&v1alpha1.DBInstance{}); err != nil {
+		t.Errorf("DBInstanceExists() error = %v, want the provider to answer", err)
+	}
+	if err := c.CreateDBCluster(&v1alpha1.DBCluster{}, "password"); !errors.As(err, &utils.ErrNotSupported{}) {
+		t.Errorf("CreateDBCluster() error = %v, want utils.ErrNotSupported", err)
+	}
+	if err := c.PromoteDBInstanceReadReplica(&v1alpha1.DBInstance{}); !errors.As(err, &utils.ErrNotSupported{}) {
+		t.Errorf("PromoteDBInstanceReadReplica() error = %v, want utils.ErrNotSupported", err)
+	}
+
+	if _, err := NewCloudDB(logr.Discard(), ProviderOptions{Type: overclaim}); err == nil {
+		t.Errorf("NewCloudDB() of a provider declaring snapshots and replicas it does not implement did not fail")
+	}
+}
@@ -0,0 +1,266 @@
+package unsupported
+
+import (
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/agill17/db-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloudDB returns utils.ErrNotSupported from every factory.CloudDB method. factory.NewCloudDB uses it for
+// the interfaces a provider does not implement.
+type CloudDB struct {
+	Provider v1alpha1.ProviderType
+}
+
+func (u CloudDB) notSupported(feature string) error {
+	return utils.ErrNotSupported{Message: fmt.Sprintf("%s is not supported by the %s provider", feature, u.Provider)}
+}
+
+// factory.DBCluster
+
+func (u CloudDB) CreateDBCluster(input *v1alpha1.DBCluster, password string) error {
+	return u.notSupported("CreateDBCluster")
+}
+
+func (u CloudDB) ModifyDBCluster(modifyIn interface{}) error {
+	return u.notSupported("ModifyDBCluster")
+}
+
+func (u CloudDB) IsDBClusterUpToDate(input *v1alpha1.DBCluster) (bool, interface{}, error) {
+	return false, nil, u.notSupported("IsDBClusterUpToDate")
+}
+
+func (u CloudDB) DeleteDBCluster(input *v1alpha1.DBCluster) error {
+	return u.notSupported("DeleteDBCluster")
+}
+
+func (u CloudDB) DBClusterExists(dbClusterID string) (*v1alpha1.DBStatus, error) {
+	return nil, u.notSupported("DBClusterExists")
+}
+
+func (u CloudDB) UpdateDBClusterPassword(input *v1alpha1.DBCluster, password string) error {
+	return u.notSupported("UpdateDBClusterPassword")
+}
+
+func (u CloudDB) RestoreDBClusterFromSnapshot(input *v1alpha1.DBCluster, snapshotID string) error {
+	return u.notSupported("RestoreDBClusterFromSnapshot")
+}
+
+func (u CloudDB) RestoreDBClusterToPointInTime(input *v1alpha1.DBCluster, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error) {
+	return nil, u.notSupported("RestoreDBClusterToPointInTime")
+}
+
+func (u CloudDB) BackfillDBClusterSpec(input *v1alpha1.DBCluster) error {
+	return u.notSupported("BackfillDBClusterSpec")
+}
+
+func (u CloudDB) PromoteDBClusterReadReplica(input *v1alpha1.DBCluster) error {
+	return u.notSupported("PromoteDBClusterReadReplica")
+}
+
+// factory.DBInstance
+
+func (u CloudDB) CreateDBInstance(input *v1alpha1.DBInstance, password string) error {
+	return u.notSupported("CreateDBInstance")
+}
+
+func (u CloudDB) DeleteDBInstance(input *v1alpha1.DBInstance) error {
+	return u.notSupported("DeleteDBInstance")
+}
+
+func (u CloudDB) ModifyDBInstance(modifyIn interface{}) error {
+	return u.notSupported("ModifyDBInstance")
+}
+
+func (u CloudDB) DBInstanceExists(input *v1alpha1.DBInstance) (*v1alpha1.DBStatus, error) {
+	return nil, u.notSupported("DBInstanceExists")
+}
+
+func (u CloudDB) IsDBInstanceUpToDate(input *v1alpha1.DBInstance) (bool, interface{}, error) {
+	return false, nil, u.notSupported("IsDBInstanceUpToDate")
+}
+
+func (u CloudDB) UpdateDBInstancePassword(input *v1alpha1.DBInstance, password string) error {
+	return u.notSupported("UpdateDBInstancePassword")
+}
+
+func (u CloudDB) RestoreDBInstanceFromSnapshot(input *v1alpha1.DBInstance, snapshotID string) error {
+	return u.notSupported("RestoreDBInstanceFromSnapshot")
+}
+
+func (u CloudDB) RestoreDBInstanceToPointInTime(input *v1alpha1.DBInstance, pointInTime *v1alpha1.PointInTime) (*metav1.Time, error) {
+	return nil, u.notSupported("RestoreDBInstanceToPointInTime")
+}
+
+func (u CloudDB) BackfillDBInstanceSpec(input *v1alpha1.DBInstance) error {
+	return u.notSupported("BackfillDBInstanceSpec")
+}
+
+func (u CloudDB) CreateDBInstanceReadReplica(input *v1alpha1.DBInstance, sourceID, sourceRegion string) error {
+	return u.notSupported("CreateDBInstanceReadReplica")
+}
+
+func (u CloudDB) PromoteDBInstanceReadReplica(input *v1alpha1.DBInstance) error {
+	return u.notSupported("PromoteDBInstanceReadReplica")
+}
+
+// factory.DBSnapshot
+
+func (u CloudDB) CreateDBSnapshot(input *v1alpha1.DBSnapshot, dbInstanceID string) error {
+	return u.notSupported("CreateDBSnapshot")
+}
+
+func (u CloudDB) DeleteDBSnapshot(snapshotID string) error {
+	return u.notSupported("DeleteDBSnapshot")
+}
+
+func (u CloudDB) DBSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error) {
+	return nil, u.notSupported("DBSnapshotExists")
+}
+
+func (u CloudDB) CreateDBClusterSnapshot(input *v1alpha1.DBClusterSnapshot, dbClusterID string) error {
+	return u.notSupported("CreateDBClusterSnapshot")
+}
+
+func (u CloudDB) DeleteDBClusterSnapshot(snapshotID string) error {
+	return u.notSupported("DeleteDBClusterSnapshot")
+}
+
+func (u CloudDB) DBClusterSnapshotExists(snapshotID string) (*v1alpha1.SnapshotState, error) {
+	return nil, u.notSupported("DBClusterSnapshotExists")
+}
+
+// factory.DBClusterEndpoint
+
+func (u CloudDB) DBClusterEndpoints(dbClusterID string) ([]v1alpha1.DBClusterEndpointState, error) {
+	return nil, u.notSupported("DBClusterEndpoints")
+}
+
+func (u CloudDB) CreateDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error {
+	return u.notSupported("CreateDBClusterEndpoint")
+}
+
+func (u CloudDB) ModifyDBClusterEndpoint(input *v1alpha1.DBCluster, endpoint v1alpha1.DBClusterEndpoint) error {
+	return u.notSupported("ModifyDBClusterEndpoint")
+}
+
+func (u CloudDB) DeleteDBClusterEndpoint(endpointID string) error {
+	return u.notSupported("DeleteDBClusterEndpoint")
+}
+
+// factory.GlobalCluster
+
+func (u CloudDB) CreateGlobalCluster(input *v1alpha1.GlobalCluster, sourceDBClusterArn string) error {
+	return u.notSupported("CreateGlobalCluster")
+}
+
+func (u CloudDB) GlobalClusterExists(globalClusterID string) (*v1alpha1.GlobalClusterState, error) {
+	return nil, u.notSupported("GlobalClusterExists")
+}
+
+func (u CloudDB) ModifyGlobalCluster(input *v1alpha1.GlobalCluster) error {
+	return u.notSupported("ModifyGlobalCluster")
+}
+
+func (u CloudDB) FailoverGlobalCluster(globalClusterID, targetDBClusterArn string) error {
+	return u.notSupported("FailoverGlobalCluster")
+}
+
+func (u CloudDB) RemoveFromGlobalCluster(globalClusterID, dbClusterArn string) error {
+	return u.notSupported("RemoveFromGlobalCluster")
+}
+
+func (u CloudDB) DeleteGlobalCluster(globalClusterID string) error {
+	return u.notSupported("DeleteGlobalCluster")
+}
+
+// factory.DBSubnetGroup
+
+func (u CloudDB) CreateDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error {
+	return u.notSupported("CreateDBSubnetGroup")
+}
+
+func (u CloudDB) DBSubnetGroupExists(name string) (*v1alpha1.DBSubnetGroupState, error) {
+	return nil, u.notSupported("DBSubnetGroupExists")
+}
+
+func (u CloudDB) ModifyDBSubnetGroup(input *v1alpha1.DBSubnetGroup) error {
+	return u.notSupported("ModifyDBSubnetGroup")
+}
+
+func (u CloudDB) DeleteDBSubnetGroup(name string) error {
+	return u.notSupported("DeleteDBSubnetGroup")
+}
+
+// factory.DBParameterGroup
+
+func (u CloudDB) CreateDBParameterGroup(input *v1alpha1.DBParameterGroup) error {
+	return u.notSupported("CreateDBParameterGroup")
+}
+
+func (u CloudDB) DBParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error) {
+	return nil, u.notSupported("DBParameterGroupExists")
+}
+
+func (u CloudDB) ModifyDBParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error {
+	return u.notSupported("ModifyDBParameterGroup")
+}
+
+func (u CloudDB) ResetDBParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error {
+	return u.notSupported("ResetDBParameterGroup")
+}
+
+func (u CloudDB) DeleteDBParameterGroup(name string) error {
+	return u.notSupported("DeleteDBParameterGroup")
+}
+
+func (u CloudDB) CreateDBClusterParameterGroup(input *v1alpha1.DBClusterParameterGroup) error {
+	return u.notSupported("CreateDBClusterParameterGroup")
+}
+
+func (u CloudDB) DBClusterParameterGroupExists(name string) (*v1alpha1.ParameterGroupState, error) {
+	return nil, u.notSupported("DBClusterParameterGroupExists")
+}
+
+func (u CloudDB) ModifyDBClusterParameterGroup(name string, parameters map[string]v1alpha1.Parameter) error {
+	return u.notSupported("ModifyDBClusterParameterGroup")
+}
+
+func (u CloudDB) ResetDBClusterParameterGroup(name string, parameters map[string]v1alpha1.ParameterApplyMethod) error {
+	return u.notSupported("ResetDBClusterParameterGroup")
+}
+
+func (u CloudDB) DeleteDBClusterParameterGroup(name string) error {
+	return u.notSupported("DeleteDBClusterParameterGroup")
+}
+
+// factory.OptionGroup
+
+func (u CloudDB) CreateOptionGroup(input *v1alpha1.OptionGroup) error {
+	return u.notSupported("CreateOptionGroup")
+}
+
+func (u CloudDB) OptionGroupExists(name string) (*v1alpha1.OptionGroupState, error) {
+	return nil, u.notSupported("OptionGroupExists")
+}
+
+func (u CloudDB) ModifyOptionGroup(name string, include []v1alpha1.Option, remove []string) error {
+	return u.notSupported("ModifyOptionGroup")
+}
+
+func (u CloudDB) DeleteOptionGroup(name string) error {
+	return u.notSupported("DeleteOptionGroup")
+}
+
+// factory.Tagger
+
+func (u CloudDB) AddTagsToResource(arn string, tags map[string]string) error {
+	return u.notSupported("AddTagsToResource")
+}
+
+// factory.Identity
+
+func (u CloudDB) GetAccountID() (string, error) {
+	return "", u.notSupported("GetAccountID")
+}
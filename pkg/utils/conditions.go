@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"errors"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"unicode"
+)
+
+// providerError is satisfied by cloud SDK errors that carry a machine readable code ( e.g awserr.Error )
+type providerError interface {
+	error
+	Code() string
+	Message() string
+}
+
+// error codes returned by cloud providers when credentials are missing, wrong or expired
+var credentialErrorCodes = map[string]bool{
+	"AccessDenied":                true,
+	"AccessDeniedException":       true,
+	"AuthFailure":                 true,
+	"AuthenticationFailed":        true,
+	"AuthorizationFailed":         true,
+	"ExpiredAuthenticationToken":  true,
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"IncompleteSignature":         true,
+	"InvalidAccessKeyId":          true,
+	"InvalidAuthenticationToken":  true,
+	"InvalidClientTokenId":        true,
+	"NoCredentialProviders":       true,
+	"PERMISSION_DENIED":           true,
+	"SignatureDoesNotMatch":       true,
+	"UNAUTHENTICATED":             true,
+	"UnrecognizedClientException": true,
+}
+
+// SetCondition records a condition on the object in memory, it is persisted by the next UpdateStatus call.
+func SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string, object v1alpha1.ConditionedObject) {
+	meta.SetStatusCondition(object.GetConditions(), metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             ToConditionReason(reason),
+		Message:            message,
+		ObservedGeneration: object.GetGeneration(),
+	})
+}
+
+// RecordError marks conditionType ( and Ready ) as False using the provider error code as reason when
+// there is one, persists the status and returns the original error so callers can requeue with it.
+func RecordError(conditionType, fallbackReason string, err error, object v1alpha1.ConditionedObject, client client.Client) error {
+	reason, message := ReasonFromError(err, fallbackReason)
+	if IsCredentialsError(err) {
+		conditionType = v1alpha1.ConditionCredentialsValid
+	}
+	SetCondition(conditionType, metav1.ConditionFalse, reason, message, object)
+	if conditionType != v1alpha1.ConditionReady {
+		SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, reason, message, object)
+	}
+	// the original error is more useful to the caller than a failed status write
+	_ = UpdateStatus(object, client)
+	return err
+}
+
+// ReasonFromError returns a condition reason and message for err.
+// The reason is the provider error code when err comes from a cloud SDK, otherwise fallbackReason.
+func ReasonFromError(err error, fallbackReason string) (string, string) {
+	var pErr providerError
+	if errors.As(err, &pErr) {
+		return ToConditionReason(pErr.Code()), pErr.Message()
+	}
+	return fallbackReason, err.Error()
+}
+
+// IsCredentialsError returns true when the cloud provider rejected the credentials used for a call.
+func IsCredentialsError(err error) bool {
+	var pErr providerError
+	if errors.As(err, &pErr) {
+		return credentialErrorCodes[pErr.Code()]
+	}
+	return false
+}
+
+// ToConditionReason converts provider codes and statuses ( e.g "backing-up" ) into a valid
+// CamelCase condition reason ( e.g "BackingUp" ).
+func ToConditionReason(in string) string {
+	parts := strings.FieldsFunc(in, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r)) || r > unicode.MaxASCII
+	})
+	var out strings.Builder
+	for _, part := range parts {
+		out.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	reason := out.String()
+	if reason == "" || !unicode.IsLetter(rune(reason[0])) {
+		reason = "Unknown" + reason
+	}
+	return reason
+}
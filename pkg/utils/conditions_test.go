@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestToConditionReason(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "available", want: "Available"},
+		{in: "backing-up", want: "BackingUp"},
+		{in: "AccessDenied", want: "AccessDenied"},
+		{in: "PERMISSION_DENIED", want: "PERMISSIONDENIED"},
+		{in: "storage-full (gp2)", want: "StorageFullGp2"},
+		{in: "2fa required", want: "Unknown2faRequired"},
+		{in: "", want: "Unknown"},
+		{in: "---", want: "Unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := ToConditionReason(tt.in); got != tt.want {
+				t.Errorf("ToConditionReason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCredentialsError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "expired token", err: awserr.New("ExpiredToken", "token expired", nil), want: true},
+		{name: "wrapped access denied", err: fmt.Errorf("describe: %w", awserr.New("AccessDenied", "denied", nil)), want: true},
+		{name: "other provider error", err: awserr.New("DBInstanceNotFound", "not found", nil)},
+		{name: "plain error", err: errors.New("AccessDenied")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCredentialsError(tt.err); got != tt.want {
+				t.Errorf("IsCredentialsError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	tests := []struct {
+		name          string
+		conditionType string
+		err           error
+		wantType      string
+		wantReason    string
+		wantMessage   string
+	}{
+		{
+			name:          "provider code is the reason",
+			conditionType: v1alpha1.ConditionSynced,
+			err:           awserr.New("InvalidParameterCombination", "bad combination", nil),
+			wantType:      v1alpha1.ConditionSynced,
+			wantReason:    "InvalidParameterCombination",
+			wantMessage:   "bad combination",
+		},
+		{
+			name:          "fallback reason for other errors",
+			conditionType: v1alpha1.ConditionSynced,
+			err:           errors.New("something broke"),
+			wantType:      v1alpha1.ConditionSynced,
+			wantReason:    v1alpha1.ReasonReconcileError,
+			wantMessage:   "something broke",
+		},
+		{
+			name:          "credential errors go to CredentialsValid",
+			conditionType: v1alpha1.ConditionSynced,
+			err:           awserr.New("InvalidClientTokenId", "invalid token", nil),
+			wantType:      v1alpha1.ConditionCredentialsValid,
+			wantReason:    "InvalidClientTokenId",
+			wantMessage:   "invalid token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testScheme := runtime.NewScheme()
+			v1alpha1.AddToScheme(testScheme)
+			cr := &v1alpha1.DBInstance{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+			c := fake.NewFakeClientWithScheme(testScheme, cr.DeepCopy())
+
+			if err := RecordError(tt.conditionType, v1alpha1.ReasonReconcileError, tt.err, cr, c); err != tt.err {
+				t.Errorf("RecordError() = %v, want the original error %v", err, tt.err)
+			}
+			stored := &v1alpha1.DBInstance{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "app", Namespace: "default"}, stored); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			for _, conditionType := range []string{tt.wantType, v1alpha1.ConditionReady} {
+				got := meta.FindStatusCondition(stored.Status.Conditions, conditionType)
+				if got == nil || got.Status != metav1.ConditionFalse || got.Reason != tt.wantReason || got.Message != tt.wantMessage {
+					t.Errorf("%s condition = %v, want False %s: %s", conditionType, got, tt.wantReason, tt.wantMessage)
+				}
+			}
+		})
+	}
+}
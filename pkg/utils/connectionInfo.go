@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// keys written to connection secrets
+const (
+	ConnectionHostKey     = "host"
+	ConnectionPortKey     = "port"
+	ConnectionUsernameKey = "username"
+	ConnectionPasswordKey = "password"
+	ConnectionDBNameKey   = "dbname"
+	ConnectionEngineKey   = "engine"
+	ConnectionURIKey      = "uri"
+	ConnectionDSNKey      = "dsn"
+	ConnectionJDBCURLKey  = "jdbcUrl"
+)
+
+// ConnectionInfo holds what an application needs to connect to a database
+type ConnectionInfo struct {
+	Engine   string
+	Host     string
+	Port     int64
+	Username string
+	Password string
+	DBName   string
+}
+
+// SecretData returns the connection secret data, including engine specific uri, dsn and jdbcUrl
+// when the engine is known.
+//
+// The uri is always a URL, so the credentials in it are percent-encoded. The dsn is written in the form
+// the engine's usual Go driver parses, and each driver has its own rule for the password:
+//   - mysql: raw, go-sql-driver/mysql splits on the last "@" and does not decode
+//   - postgres: libpq keyword/value, single quoted with backslash escapes
+//   - oracle: EZConnect, double quoted so "@" and "/" do not end the password
+//   - sqlserver: a URL like the uri, which go-mssqldb decodes
+func (c ConnectionInfo) SecretData() map[string][]byte {
+	port := c.Port
+	if port == 0 {
+		port = defaultPortForEngine(c.Engine)
+	}
+	out := map[string][]byte{
+		ConnectionHostKey:     []byte(c.Host),
+		ConnectionPortKey:     []byte(strconv.FormatInt(port, 10)),
+		ConnectionUsernameKey: []byte(c.Username),
+		ConnectionPasswordKey: []byte(c.Password),
+		ConnectionDBNameKey:   []byte(c.DBName),
+		ConnectionEngineKey:   []byte(c.Engine),
+	}
+
+	hostPort := net.JoinHostPort(c.Host, strconv.FormatInt(port, 10))
+	userInfo := url.UserPassword(c.Username, c.Password)
+	switch {
+	case isMysqlEngine(c.Engine):
+		jdbcScheme := "mysql"
+		if c.Engine == "mariadb" {
+			jdbcScheme = "mariadb"
+		}
+		out[ConnectionURIKey] = []byte((&url.URL{Scheme: "mysql", User: userInfo, Host: hostPort, Path: "/" + c.DBName}).String())
+		out[ConnectionDSNKey] = []byte(fmt.Sprintf("%s:%s@tcp(%s)/%s", c.Username, c.Password, hostPort, c.DBName))
+		out[ConnectionJDBCURLKey] = []byte(fmt.Sprintf("jdbc:%s://%s/%s", jdbcScheme, hostPort, c.DBName))
+	case isPostgresEngine(c.Engine):
+		dbName := c.DBName
+		if dbName == "" {
+			dbName = "postgres"
+		}
+		out[ConnectionURIKey] = []byte((&url.URL{Scheme: "postgresql", User: userInfo, Host: hostPort, Path: "/" + dbName}).String())
+		out[ConnectionDSNKey] = []byte(fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=require",
+			pgQuote(c.Host), port, pgQuote(c.Username), pgQuote(c.Password), pgQuote(dbName)))
+		out[ConnectionJDBCURLKey] = []byte(fmt.Sprintf("jdbc:postgresql://%s/%s", hostPort, dbName))
+	case strings.HasPrefix(c.Engine, "oracle"):
+		dbName := c.DBName
+		if dbName == "" {
+			dbName = "ORCL"
+		}
+		out[ConnectionURIKey] = []byte((&url.URL{Scheme: "oracle", User: userInfo, Host: hostPort, Path: "/" + dbName}).String())
+		out[ConnectionDSNKey] = []byte(fmt.Sprintf(`%s/"%s"@%s/%s`, c.Username, c.Password, hostPort, dbName))
+		out[ConnectionJDBCURLKey] = []byte(fmt.Sprintf("jdbc:oracle:thin:@//%s/%s", hostPort, dbName))
+	case strings.HasPrefix(c.Engine, "sqlserver"):
+		query := url.Values{}
+		jdbcURL := fmt.Sprintf("jdbc:sqlserver://%s", hostPort)
+		if c.DBName != "" {
+			query.Set("database", c.DBName)
+			jdbcURL = fmt.Sprintf("%s;databaseName=%s", jdbcURL, c.DBName)
+		}
+		sqlServerURL := (&url.URL{Scheme: "sqlserver", User: userInfo, Host: hostPort, RawQuery: query.Encode()}).String()
+		out[ConnectionURIKey] = []byte(sqlServerURL)
+		out[ConnectionDSNKey] = []byte(sqlServerURL)
+		out[ConnectionJDBCURLKey] = []byte(jdbcURL)
+	}
+	return out
+}
+
+func isMysqlEngine(engine string) bool {
+	return engine == "mysql" || engine == "mariadb" || engine == "aurora" || engine == "aurora-mysql"
+}
+
+func isPostgresEngine(engine string) bool {
+	return engine == "postgres" || engine == "aurora-postgresql"
+}
+
+func defaultPortForEngine(engine string) int64 {
+	switch {
+	case isPostgresEngine(engine):
+		return 5432
+	case strings.HasPrefix(engine, "oracle"):
+		return 1521
+	case strings.HasPrefix(engine, "sqlserver"):
+		return 1433
+	default:
+		return 3306
+	}
+}
+
+// pgQuote quotes a libpq keyword/value connection string value
+func pgQuote(in string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(in)
+	return fmt.Sprintf("'%s'", escaped)
+}
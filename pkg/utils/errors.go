@@ -7,3 +7,75 @@ type ErrSecretMissingKey struct {
 func (e ErrSecretMissingKey) Error() string {
 	return e.Message
 }
+
+type ErrGeneratedPasswordMissing struct {
+	Message string
+}
+
+func (e ErrGeneratedPasswordMissing) Error() string {
+	return e.Message
+}
+
+type ErrSnapshotNotAvailable struct {
+	Message string
+}
+
+func (e ErrSnapshotNotAvailable) Error() string {
+	return e.Message
+}
+
+type ErrOwnershipConflict struct {
+	Message string
+}
+
+func (e ErrOwnershipConflict) Error() string {
+	return e.Message
+}
+
+type ErrFinalSnapshotRecordConflict struct {
+	Message string
+}
+
+func (e ErrFinalSnapshotRecordConflict) Error() string {
+	return e.Message
+}
+
+type ErrReplicaSourceNotAvailable struct {
+	Message string
+}
+
+func (e ErrReplicaSourceNotAvailable) Error() string {
+	return e.Message
+}
+
+type ErrDBSubnetGroupNotAvailable struct {
+	Message string
+}
+
+func (e ErrDBSubnetGroupNotAvailable) Error() string {
+	return e.Message
+}
+
+type ErrParameterGroupNotAvailable struct {
+	Message string
+}
+
+func (e ErrParameterGroupNotAvailable) Error() string {
+	return e.Message
+}
+
+type ErrOptionGroupNotAvailable struct {
+	Message string
+}
+
+func (e ErrOptionGroupNotAvailable) Error() string {
+	return e.Message
+}
+
+type ErrNotSupported struct {
+	Message string
+}
+
+func (e ErrNotSupported) Error() string {
+	return e.Message
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -32,3 +34,27 @@ func GetSecretValue(name, namespace, key string, client client.Client) (string,
 	}
 	return string(val), s.GetResourceVersion(), nil
 }
+
+// RemoveSecretOwnerReference removes the owner reference of owner from a secret in its namespace, so the secret
+// is not garbage collected with owner. A missing secret or one owner does not own is left alone.
+func RemoveSecretOwnerReference(owner metav1.Object, name string, client client.Client) error {
+	secret, err := GetSecret(name, owner.GetNamespace(), client)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	refs := secret.GetOwnerReferences()
+	kept := make([]metav1.OwnerReference, 0, len(refs))
+	for _, ref := range refs {
+		if ref.UID != owner.GetUID() {
+			kept = append(kept, ref)
+		}
+	}
+	if len(kept) == len(refs) {
+		return nil
+	}
+	secret.SetOwnerReferences(kept)
+	return client.Update(context.TODO(), secret)
+}
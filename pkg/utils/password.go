@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"math/big"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"strings"
+)
+
+const (
+	passwordLowers = "abcdefghijklmnopqrstuvwxyz"
+	passwordUppers = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits = "0123456789"
+	// printable ASCII without "/", "'", """, "@" and space which RDS rejects
+	passwordSpecials = "!#$%&()*+,-.:;<=>?[]^_{|}~"
+	// oracle only allows "_", "$" and "#" in unquoted passwords
+	oraclePasswordSpecials = "_$#"
+	// sql server accepts most specials, but these are known to break sqlcmd/odbc connection strings
+	sqlServerPasswordSpecials = "!#$%&*()-_+=.?"
+)
+
+// GeneratePassword returns a random password that meets the rules of the given engine. It
+// always contains at least one lower case, upper case, digit and special character and, for
+// oracle, starts with a letter.
+func GeneratePassword(engine string) (string, error) {
+	length, specials := 32, passwordSpecials
+	switch {
+	case strings.HasPrefix(engine, "oracle"):
+		length, specials = 30, oraclePasswordSpecials
+	case strings.HasPrefix(engine, "sqlserver"):
+		specials = sqlServerPasswordSpecials
+	}
+
+	sets := []string{passwordLowers, passwordUppers, passwordDigits, specials}
+	out := make([]byte, 0, length)
+	// first char is always a letter, oracle does not allow anything else unquoted
+	first, err := randomChar(passwordLowers + passwordUppers)
+	if err != nil {
+		return "", err
+	}
+	out = append(out, first)
+	for _, set := range sets {
+		c, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, c)
+	}
+	all := strings.Join(sets, "")
+	for len(out) < length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		out = append(out, c)
+	}
+
+	// shuffle everything but the first char so the required sets are not always at the same position
+	for i := len(out) - 1; i > 1; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i)))
+		if err != nil {
+			return "", err
+		}
+		k := int(j.Int64()) + 1
+		out[i], out[k] = out[k], out[i]
+	}
+	return string(out), nil
+}
+
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, err
+	}
+	return set[n.Int64()], nil
+}
+
+// PasswordHash returns the hex encoded SHA-256 of a password salted with the uid of its owner, which records it
+// to notice a changed password without keeping the password itself
+func PasswordHash(owner metav1.Object, password string) string {
+	sum := sha256.Sum256([]byte(string(owner.GetUID()) + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetOrCreateGeneratedPassword returns the password and resourceVersion of the generated password secret, creating
+// the secret with a new password when it does not exist. A password is never generated when the database
+// already exists, since it would not match the one the database was created with.
+func GetOrCreateGeneratedPassword(owner metav1.Object, secretName, key, engine string, dbExists bool,
+	client client.Client, scheme *runtime.Scheme) (string, string, error) {
+	value, version, err := GetSecretValue(secretName, owner.GetNamespace(), key, client)
+	if err == nil {
+		return value, version, nil
+	}
+	if !errors.IsNotFound(err) {
+		return "", "", err
+	}
+	if dbExists {
+		return "", "", ErrGeneratedPasswordMissing{Message: fmt.Sprintf(
+			"%s/%s generated password secret does not exist and the database already exists, refusing to generate a new password",
+			owner.GetNamespace(), secretName)}
+	}
+
+	password, err := GeneratePassword(engine)
+	if err != nil {
+		return "", "", err
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: owner.GetNamespace(),
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{key: []byte(password)},
+	}
+	if err := controllerutil.SetControllerReference(owner, secret, scheme); err != nil {
+		return "", "", err
+	}
+	if err := client.Create(context.TODO(), secret); err != nil {
+		return "", "", err
+	}
+	return password, secret.GetResourceVersion(), nil
+}
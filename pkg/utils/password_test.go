@@ -0,0 +1,63 @@
+package utils
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		engine     string
+		wantLength int
+		specials   string
+	}{
+		{name: "mysql", engine: "mysql", wantLength: 32, specials: passwordSpecials},
+		{name: "aurora-postgresql", engine: "aurora-postgresql", wantLength: 32, specials: passwordSpecials},
+		{name: "oracle", engine: "oracle-ee", wantLength: 30, specials: oraclePasswordSpecials},
+		{name: "sqlserver", engine: "sqlserver-ex", wantLength: 32, specials: sqlServerPasswordSpecials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GeneratePassword(tt.engine)
+			if err != nil {
+				t.Fatalf("GeneratePassword() error = %v", err)
+			}
+			if len(got) != tt.wantLength {
+				t.Errorf("GeneratePassword() length = %v, want %v", len(got), tt.wantLength)
+			}
+			if !strings.ContainsAny(got[:1], passwordLowers+passwordUppers) {
+				t.Errorf("GeneratePassword() = %v, should start with a letter", got)
+			}
+			for _, set := range []string{passwordLowers, passwordUppers, passwordDigits, tt.specials} {
+				if !strings.ContainsAny(got, set) {
+					t.Errorf("GeneratePassword() = %v, should contain one of %v", got, set)
+				}
+			}
+			allowed := passwordLowers + passwordUppers + passwordDigits + tt.specials
+			for _, c := range got {
+				if !strings.ContainsRune(allowed, c) {
+					t.Errorf("GeneratePassword() = %v, contains %q which is not allowed for %v", got, c, tt.engine)
+				}
+			}
+		})
+	}
+}
+
+func TestPasswordHash(t *testing.T) {
+	owner := &metav1.ObjectMeta{UID: "1234"}
+	other := &metav1.ObjectMeta{UID: "5678"}
+	if PasswordHash(owner, "secret") != PasswordHash(owner, "secret") {
+		t.Errorf("PasswordHash() of the same password differs")
+	}
+	if PasswordHash(owner, "secret") == PasswordHash(owner, "changed") {
+		t.Errorf("PasswordHash() of a changed password is the same")
+	}
+	if PasswordHash(owner, "secret") == PasswordHash(other, "secret") {
+		t.Errorf("PasswordHash() of the same password of two owners is the same")
+	}
+	if strings.Contains(PasswordHash(owner, "secret"), "secret") {
+		t.Errorf("PasswordHash() contains the password")
+	}
+}
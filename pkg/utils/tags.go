@@ -0,0 +1,29 @@
+package utils
+
+// MergeTags returns the default tags of a provider plus tags, tags win when both set the same key
+func MergeTags(defaultTags, tags map[string]string) map[string]string {
+	if len(defaultTags) == 0 {
+		return tags
+	}
+	out := make(map[string]string, len(tags)+len(defaultTags))
+	for k, v := range defaultTags {
+		out[k] = v
+	}
+	for k, v := range tags {
+		out[k] = v
+	}
+	return out
+}
+
+// WithoutDefaultTags returns tags without the default tags of a provider. A tag whose value differs from the
+// default was set on purpose and is kept.
+func WithoutDefaultTags(tags, defaultTags map[string]string) map[string]string {
+	out := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if defaultValue, ok := defaultTags[k]; ok && defaultValue == v {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultTags map[string]string
+		tags        map[string]string
+		want        map[string]string
+	}{
+		{
+			name: "no default tags",
+			tags: map[string]string{"team": "db"},
+			want: map[string]string{"team": "db"},
+		},
+		{
+			name:        "tags win over default tags",
+			defaultTags: map[string]string{"environment": "prod", "team": "platform"},
+			tags:        map[string]string{"team": "db"},
+			want:        map[string]string{"environment": "prod", "team": "db"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeTags(tt.defaultTags, tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutDefaultTags(t *testing.T) {
+	got := WithoutDefaultTags(map[string]string{"environment": "prod", "team": "db", "cost-center": "42"},
+		map[string]string{"environment": "prod", "team": "platform"})
+	want := map[string]string{"team": "db", "cost-center": "42"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithoutDefaultTags() = %v, want %v", got, want)
+	}
+}
@@ -3,23 +3,50 @@ package utils
 import (
 	"context"
 	"github.com/agill17/db-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func UpdateStatusPhase(phase v1alpha1.Phase, object client.Object, client client.Client) error {
-	// i hate this
-	switch o := object.(type) {
-	case *v1alpha1.DBCluster:
-		if o.Status.Phase != phase {
-			o.Status.Phase = phase
-			return client.Status().Update(context.TODO(), o)
-		}
-	case *v1alpha1.DBInstance:
-		if o.Status.Phase != phase {
-			o.Status.Phase = phase
-			return client.Status().Update(context.TODO(), o)
-		}
+// UpdateStatusPhase sets the phase and persists the status of the object.
+func UpdateStatusPhase(phase v1alpha1.Phase, object v1alpha1.ConditionedObject, client client.Client) error {
+	object.SetPhase(phase)
+	return UpdateStatus(object, client)
+}
+
+// UpdateStatus persists the in-memory status of the object (phase, conditions, observedGeneration)
+// and skips the write when nothing changed compared to what is currently stored.
+func UpdateStatus(object v1alpha1.ConditionedObject, client client.Client) error {
+	object.SetObservedGeneration(object.GetGeneration())
+
+	// decode into a new object, decoding into a copy of object would keep the fields missing from what is stored
+	stored := reflect.New(reflect.TypeOf(object).Elem()).Interface().(v1alpha1.ConditionedObject)
+	if err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+	}, stored); err != nil {
+		return err
+	}
+	changed, err := statusChanged(stored, object)
+	if err != nil {
+		return err
 	}
-	return nil
+	if !changed {
+		return nil
+	}
+	return client.Status().Update(context.TODO(), object)
+}
 
+func statusChanged(stored, desired runtime.Object) (bool, error) {
+	storedMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(stored)
+	if err != nil {
+		return false, err
+	}
+	desiredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return false, err
+	}
+	return !equality.Semantic.DeepEqual(storedMap["status"], desiredMap["status"]), nil
 }
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"github.com/agill17/db-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"testing"
+)
+
+func TestUpdateStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		change    func(cr *v1alpha1.DBInstance)
+		wantWrite bool
+	}{
+		{
+			name:   "nothing changed",
+			change: func(cr *v1alpha1.DBInstance) {},
+		},
+		{
+			name: "the same condition again",
+			change: func(cr *v1alpha1.DBInstance) {
+				SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonAvailable, "available", cr)
+			},
+		},
+		{
+			name:      "a new phase",
+			change:    func(cr *v1alpha1.DBInstance) { cr.Status.Phase = v1alpha1.Updating },
+			wantWrite: true,
+		},
+		{
+			name: "a changed condition",
+			change: func(cr *v1alpha1.DBInstance) {
+				SetCondition(v1alpha1.ConditionReady, metav1.ConditionFalse, v1alpha1.ReasonUpdating, "modify requested", cr)
+			},
+			wantWrite: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testScheme := runtime.NewScheme()
+			v1alpha1.AddToScheme(testScheme)
+			stored := &v1alpha1.DBInstance{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+			stored.Status.Phase = v1alpha1.Available
+			SetCondition(v1alpha1.ConditionReady, metav1.ConditionTrue, v1alpha1.ReasonAvailable, "available", stored)
+			c := fake.NewFakeClientWithScheme(testScheme, stored)
+			key := types.NamespacedName{Name: "app", Namespace: "default"}
+
+			cr := &v1alpha1.DBInstance{}
+			if err := c.Get(context.TODO(), key, cr); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			resourceVersion := cr.GetResourceVersion()
+			tt.change(cr)
+			if err := UpdateStatus(cr, c); err != nil {
+				t.Fatalf("UpdateStatus() error = %v", err)
+			}
+			if err := c.Get(context.TODO(), key, cr); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if wrote := cr.GetResourceVersion() != resourceVersion; wrote != tt.wantWrite {
+				t.Errorf("UpdateStatus() wrote the status = %v, want %v", wrote, tt.wantWrite)
+			}
+		})
+	}
+}